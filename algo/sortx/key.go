@@ -0,0 +1,59 @@
+// Package sortx provides non-comparison sorts for integer and string keys.
+// This file implements StableByKey, which picks radix sort for integer keys.
+
+package sortx
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// StableByKey stably sorts s in ascending order of key(e). When K is an
+// integer type it uses RadixSortFunc; otherwise it falls back to
+// slices.SortStableFunc with cmp.Compare. It is meant for containers whose
+// keys are any cmp.Ordered type and which want radix sort whenever it
+// applies.
+func StableByKey[E any, K cmp.Ordered](s []E, key func(E) K) {
+	if ik := integerKey[K](); ik != nil {
+		RadixSortFunc(s, func(e E) uint64 {
+			return ik(key(e))
+		})
+		return
+	}
+	slices.SortStableFunc(s, func(a, b E) int {
+		return cmp.Compare(key(a), key(b))
+	})
+}
+
+// integerKey returns a function mapping values of K to uint64s in the same
+// order, or nil if K is not an integer type. K's underlying type is only
+// known at run time, so the value is read through a pointer of that type.
+func integerKey[K any]() func(K) uint64 {
+	switch reflect.TypeOf((*K)(nil)).Elem().Kind() {
+	case reflect.Int:
+		return func(k K) uint64 { return sortKey(*(*int)(unsafe.Pointer(&k))) }
+	case reflect.Int8:
+		return func(k K) uint64 { return sortKey(*(*int8)(unsafe.Pointer(&k))) }
+	case reflect.Int16:
+		return func(k K) uint64 { return sortKey(*(*int16)(unsafe.Pointer(&k))) }
+	case reflect.Int32:
+		return func(k K) uint64 { return sortKey(*(*int32)(unsafe.Pointer(&k))) }
+	case reflect.Int64:
+		return func(k K) uint64 { return sortKey(*(*int64)(unsafe.Pointer(&k))) }
+	case reflect.Uint:
+		return func(k K) uint64 { return uint64(*(*uint)(unsafe.Pointer(&k))) }
+	case reflect.Uint8:
+		return func(k K) uint64 { return uint64(*(*uint8)(unsafe.Pointer(&k))) }
+	case reflect.Uint16:
+		return func(k K) uint64 { return uint64(*(*uint16)(unsafe.Pointer(&k))) }
+	case reflect.Uint32:
+		return func(k K) uint64 { return uint64(*(*uint32)(unsafe.Pointer(&k))) }
+	case reflect.Uint64:
+		return func(k K) uint64 { return *(*uint64)(unsafe.Pointer(&k)) }
+	case reflect.Uintptr:
+		return func(k K) uint64 { return uint64(*(*uintptr)(unsafe.Pointer(&k))) }
+	}
+	return nil
+}
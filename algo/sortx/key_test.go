@@ -0,0 +1,49 @@
+package sortx
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestStableByKey(t *testing.T) {
+	type record[K any] struct {
+		key K
+		seq int
+	}
+	type level int8
+
+	rng := rand.New(rand.NewSource(1))
+	ints := make([]record[level], 500)
+	for i := range ints {
+		ints[i] = record[level]{level(rng.Intn(256) - 128), i}
+	}
+	want := append([]record[level](nil), ints...)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].key < want[j].key })
+	StableByKey(ints, func(r record[level]) level { return r.key })
+	if !reflect.DeepEqual(ints, want) {
+		t.Error("Expected integer keys to be sorted stably")
+	}
+
+	strs := []record[string]{{"b", 0}, {"a", 1}, {"b", 2}, {"a", 3}}
+	StableByKey(strs, func(r record[string]) string { return r.key })
+	wantStrs := []record[string]{{"a", 1}, {"a", 3}, {"b", 0}, {"b", 2}}
+	if !reflect.DeepEqual(strs, wantStrs) {
+		t.Errorf("Expected %v, got %v", wantStrs, strs)
+	}
+}
+
+func TestIntegerKey(t *testing.T) {
+	if integerKey[float64]() != nil || integerKey[string]() != nil {
+		t.Error("Expected no integer key for float64 and string")
+	}
+	k := integerKey[int16]()
+	if !(k(-2) < k(-1) && k(-1) < k(0) && k(0) < k(1)) {
+		t.Error("Expected integer key to preserve signed order")
+	}
+	u := integerKey[uintptr]()
+	if !(u(0) < u(1) && u(1) < u(^uintptr(0))) {
+		t.Error("Expected integer key to preserve unsigned order")
+	}
+}
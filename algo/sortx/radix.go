@@ -0,0 +1,176 @@
+// Package sortx provides non-comparison sorts for integer and string keys.
+// Radix and counting sorts run in linear time in the number of elements and
+// beat comparison sorts such as sort.Slice on large bulk inputs.
+package sortx
+
+import (
+	"unsafe"
+
+	"github.com/feepwang/br/container/constraints"
+)
+
+// Integer is a constraint that permits any integer type. It is the same
+// constraint as constraints.Integer.
+type Integer = constraints.Integer
+
+// countingThreshold is the maximum value range, relative to the slice length,
+// for which Ints prefers counting sort over radix sort.
+const countingThreshold = 2
+
+// sortKey maps an integer to a uint64 whose unsigned order matches the
+// integer's order. Signed values are sign-extended and have the top bit
+// flipped so negative numbers sort first.
+func sortKey[T Integer](v T) uint64 {
+	if T(0)-1 < 0 {
+		return uint64(int64(v)) ^ (1 << 63)
+	}
+	return uint64(v)
+}
+
+// Ints sorts s in ascending order, choosing counting sort when the value
+// range is small relative to len(s) and LSD radix sort otherwise.
+func Ints[T Integer](s []T) {
+	if len(s) < 2 {
+		return
+	}
+	lo, hi := s[0], s[0]
+	for _, v := range s[1:] {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	if span := sortKey(hi) - sortKey(lo); span < uint64(len(s))*countingThreshold {
+		countingSort(s, lo, int(span)+1)
+		return
+	}
+	RadixSort(s)
+}
+
+// CountingSort sorts s in ascending order in O(n + k) time and O(k) extra
+// memory, where k is max(s) - min(s) + 1. Use it only when k is small.
+func CountingSort[T Integer](s []T) {
+	if len(s) < 2 {
+		return
+	}
+	lo, hi := s[0], s[0]
+	for _, v := range s[1:] {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	countingSort(s, lo, int(sortKey(hi)-sortKey(lo))+1)
+}
+
+// countingSort sorts s whose values lie in [lo, lo+k).
+func countingSort[T Integer](s []T, lo T, k int) {
+	counts := make([]int, k)
+	base := sortKey(lo)
+	for _, v := range s {
+		counts[sortKey(v)-base]++
+	}
+	i := 0
+	for offset, c := range counts {
+		v := lo + T(offset)
+		for ; c > 0; c-- {
+			s[i] = v
+			i++
+		}
+	}
+}
+
+// RadixSort sorts s in ascending order using LSD radix sort on bytes.
+// It runs in O(w·n) time with O(n) extra memory, where w is the byte width
+// of T; passes in which every element shares the same byte are skipped.
+func RadixSort[T Integer](s []T) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	buf := make([]T, n)
+	src, dst := s, buf
+	for pass := 0; pass < keyWidth[T](); pass++ {
+		shift := uint(pass * 8)
+
+		var counts [256]int
+		for _, v := range src {
+			counts[(sortKey(v)>>shift)&0xff]++
+		}
+		if counts[(sortKey(src[0])>>shift)&0xff] == n {
+			continue // every element has the same byte here
+		}
+
+		// Prefix sums give each bucket's starting offset
+		offset := 0
+		for b, c := range counts {
+			counts[b] = offset
+			offset += c
+		}
+		for _, v := range src {
+			b := (sortKey(v) >> shift) & 0xff
+			dst[counts[b]] = v
+			counts[b]++
+		}
+		src, dst = dst, src
+	}
+
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// keyWidth returns the number of sortKey bytes that can differ for T.
+func keyWidth[T Integer]() int {
+	var zero T
+	if zero-1 < 0 {
+		return 8 // signed keys are sign-extended to 64 bits
+	}
+	return int(unsafe.Sizeof(zero))
+}
+
+// RadixSortFunc stably sorts s in ascending order of key(e) using LSD radix
+// sort. It is suited to bulk-loading records keyed by integers.
+func RadixSortFunc[E any, T Integer](s []E, key func(E) T) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	keys := make([]uint64, n)
+	for i, e := range s {
+		keys[i] = sortKey(key(e))
+	}
+	bufKeys := make([]uint64, n)
+	buf := make([]E, n)
+
+	src, dst := s, buf
+	srcKeys, dstKeys := keys, bufKeys
+	for pass := 0; pass < keyWidth[T](); pass++ {
+		shift := uint(pass * 8)
+
+		var counts [256]int
+		for _, k := range srcKeys {
+			counts[(k>>shift)&0xff]++
+		}
+		if counts[(srcKeys[0]>>shift)&0xff] == n {
+			continue // every element has the same byte here
+		}
+
+		// Prefix sums give each bucket's starting offset
+		offset := 0
+		for b, c := range counts {
+			counts[b] = offset
+			offset += c
+		}
+		for i, k := range srcKeys {
+			b := (k >> shift) & 0xff
+			dst[counts[b]] = src[i]
+			dstKeys[counts[b]] = k
+			counts[b]++
+		}
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
@@ -0,0 +1,118 @@
+package sortx
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRadixSortSigned(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := make([]int64, 1000)
+	for i := range s {
+		s[i] = rng.Int63() - math.MaxInt64/2
+	}
+	s = append(s, math.MinInt64, math.MaxInt64, 0, -1)
+
+	want := append([]int64(nil), s...)
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+	RadixSort(s)
+	if !reflect.DeepEqual(s, want) {
+		t.Error("RadixSort produced wrong order for int64")
+	}
+}
+
+func TestRadixSortNarrowTypes(t *testing.T) {
+	i8 := []int8{5, -128, 127, 0, -1, 3}
+	RadixSort(i8)
+	if !reflect.DeepEqual(i8, []int8{-128, -1, 0, 3, 5, 127}) {
+		t.Errorf("Unexpected int8 order %v", i8)
+	}
+
+	u16 := []uint16{65535, 0, 256, 255, 1}
+	RadixSort(u16)
+	if !reflect.DeepEqual(u16, []uint16{0, 1, 255, 256, 65535}) {
+		t.Errorf("Unexpected uint16 order %v", u16)
+	}
+
+	type score int
+	named := []score{3, 1, 2}
+	RadixSort(named)
+	if !reflect.DeepEqual(named, []score{1, 2, 3}) {
+		t.Errorf("Unexpected named type order %v", named)
+	}
+}
+
+func TestCountingSortAndInts(t *testing.T) {
+	s := []int{5, -2, 3, 3, 0, -2, 9}
+	CountingSort(s)
+	if !reflect.DeepEqual(s, []int{-2, -2, 0, 3, 3, 5, 9}) {
+		t.Errorf("Unexpected CountingSort order %v", s)
+	}
+
+	// Small range takes the counting path, wide range the radix path
+	small := []uint8{3, 1, 2, 1, 3, 2}
+	Ints(small)
+	if !reflect.DeepEqual(small, []uint8{1, 1, 2, 2, 3, 3}) {
+		t.Errorf("Unexpected Ints order %v", small)
+	}
+	wide := []int{1 << 40, -1 << 40, 7}
+	Ints(wide)
+	if !reflect.DeepEqual(wide, []int{-1 << 40, 7, 1 << 40}) {
+		t.Errorf("Unexpected Ints order %v", wide)
+	}
+
+	// Empty and single-element inputs
+	Ints([]int{})
+	CountingSort([]int{1})
+}
+
+func TestRadixSortFuncStable(t *testing.T) {
+	type record struct {
+		key  uint32
+		name string
+	}
+	s := []record{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {0, "e"}}
+	RadixSortFunc(s, func(r record) uint32 { return r.key })
+
+	want := []record{{0, "e"}, {1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Expected %v, got %v", want, s)
+	}
+}
+
+const benchLen = 1 << 20
+
+func benchInts() []int {
+	rng := rand.New(rand.NewSource(42))
+	s := make([]int, benchLen)
+	for i := range s {
+		s[i] = rng.Int()
+	}
+	return s
+}
+
+func BenchmarkRadixSortInts(b *testing.B) {
+	src := benchInts()
+	s := make([]int, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, src)
+		RadixSort(s)
+	}
+}
+
+func BenchmarkSortSliceInts(b *testing.B) {
+	src := benchInts()
+	s := make([]int, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, src)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	}
+}
@@ -0,0 +1,80 @@
+// Package sortx provides non-comparison sorts for integer and string keys.
+// This file implements MSD radix sort for strings and byte slices.
+
+package sortx
+
+// insertionCutoff is the bucket size below which MSD radix sort falls back
+// to insertion sort.
+const insertionCutoff = 16
+
+// Strings sorts s in ascending lexicographic byte order using MSD radix sort.
+func Strings[S ~string](s []S) {
+	msdSort(s, make([]S, len(s)), 0)
+}
+
+// ByteSlices sorts s in ascending lexicographic order using MSD radix sort.
+func ByteSlices[S ~[]byte](s []S) {
+	msdSort(s, make([]S, len(s)), 0)
+}
+
+// charAt returns the byte at position d plus one, or 0 past the end, so
+// shorter strings sort before their extensions.
+func charAt[S ~string | ~[]byte](s S, d int) int {
+	if d < len(s) {
+		return int(s[d]) + 1
+	}
+	return 0
+}
+
+// msdSort sorts s, whose elements share their first depth bytes, using aux
+// as scratch space of the same length.
+func msdSort[S ~string | ~[]byte](s, aux []S, depth int) {
+	if len(s) <= insertionCutoff {
+		insertionSort(s, depth)
+		return
+	}
+
+	// 257 buckets: end-of-string plus one per byte value
+	var counts [258]int
+	for _, v := range s {
+		counts[charAt(v, depth)+1]++
+	}
+	for r := 0; r < 257; r++ {
+		counts[r+1] += counts[r]
+	}
+	for _, v := range s {
+		c := charAt(v, depth)
+		aux[counts[c]] = v
+		counts[c]++
+	}
+	copy(s, aux[:len(s)])
+
+	// counts[c] is now the end of bucket c; bucket 0 (ended strings) is done
+	start := counts[0]
+	for c := 1; c < 257; c++ {
+		end := counts[c]
+		if end-start > 1 {
+			msdSort(s[start:end], aux[start:end], depth+1)
+		}
+		start = end
+	}
+}
+
+// insertionSort sorts a small s whose elements share their first depth bytes.
+func insertionSort[S ~string | ~[]byte](s []S, depth int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && less(s[j], s[j-1], depth); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// less compares a and b starting at byte depth.
+func less[S ~string | ~[]byte](a, b S, depth int) bool {
+	for i := depth; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
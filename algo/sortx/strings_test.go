@@ -0,0 +1,82 @@
+package sortx
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func randomWords(rng *rand.Rand, n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		b := make([]byte, rng.Intn(12))
+		for j := range b {
+			b[j] = byte('a' + rng.Intn(4)) // small alphabet for shared prefixes
+		}
+		words[i] = string(b)
+	}
+	return words
+}
+
+func TestStrings(t *testing.T) {
+	s := []string{"banana", "app", "apple", "", "b", "apple", "ab"}
+	Strings(s)
+	if want := []string{"", "ab", "app", "apple", "apple", "b", "banana"}; !reflect.DeepEqual(s, want) {
+		t.Errorf("Expected %v, got %v", want, s)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	words := randomWords(rng, 5000)
+	want := append([]string(nil), words...)
+	sort.Strings(want)
+	Strings(words)
+	if !reflect.DeepEqual(words, want) {
+		t.Error("Strings produced wrong order for random words")
+	}
+}
+
+func TestByteSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	words := randomWords(rng, 2000)
+	s := make([][]byte, len(words))
+	for i, w := range words {
+		s[i] = []byte(w)
+	}
+	sort.Strings(words)
+	ByteSlices(s)
+	for i := range s {
+		if string(s[i]) != words[i] {
+			t.Fatalf("At index %d, expected %q, got %q", i, words[i], s[i])
+		}
+	}
+
+	// Bytes above 0x7f sort after ASCII
+	hi := [][]byte{{0xff}, {0x00}, {0x80, 0x01}, {}}
+	ByteSlices(hi)
+	if !reflect.DeepEqual(hi, [][]byte{{}, {0x00}, {0x80, 0x01}, {0xff}}) {
+		t.Errorf("Unexpected order %v", hi)
+	}
+}
+
+func BenchmarkRadixSortStrings(b *testing.B) {
+	src := randomWords(rand.New(rand.NewSource(42)), benchLen/4)
+	s := make([]string, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, src)
+		Strings(s)
+	}
+}
+
+func BenchmarkSortSliceStrings(b *testing.B) {
+	src := randomWords(rand.New(rand.NewSource(42)), benchLen/4)
+	s := make([]string, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, src)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	}
+}
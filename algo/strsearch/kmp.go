@@ -0,0 +1,74 @@
+// Package strsearch provides exact string matching algorithms over strings
+// and byte slices. Every search reports all match offsets, overlapping
+// matches included, in ascending order. An empty pattern matches at every
+// offset from 0 to len(text).
+// This file implements Knuth-Morris-Pratt search.
+
+package strsearch
+
+// Text is a constraint that permits strings and byte slices.
+type Text interface {
+	~string | ~[]byte
+}
+
+// PrefixFunction returns the KMP prefix function of s: element i is the
+// length of the longest proper prefix of s[:i+1] that is also its suffix.
+func PrefixFunction[T Text](s T) []int {
+	pi := make([]int, len(s))
+	for i := 1; i < len(s); i++ {
+		k := pi[i-1]
+		for k > 0 && s[i] != s[k] {
+			k = pi[k-1]
+		}
+		if s[i] == s[k] {
+			k++
+		}
+		pi[i] = k
+	}
+	return pi
+}
+
+// KMP returns the offsets of all occurrences of pattern in text using the
+// Knuth-Morris-Pratt algorithm in O(len(text) + len(pattern)).
+func KMP[T Text](text, pattern T) []int {
+	var offsets []int
+	kmpEach(text, pattern, func(i int) bool {
+		offsets = append(offsets, i)
+		return true
+	})
+	return offsets
+}
+
+// kmpEach calls fn for every match offset. Stops early if fn returns false.
+func kmpEach[T Text](text, pattern T, fn func(int) bool) {
+	m := len(pattern)
+	if m == 0 {
+		emptyEach(len(text), fn)
+		return
+	}
+	pi := PrefixFunction(pattern)
+	k := 0 // number of pattern bytes currently matched
+	for i := 0; i < len(text); i++ {
+		for k > 0 && text[i] != pattern[k] {
+			k = pi[k-1]
+		}
+		if text[i] == pattern[k] {
+			k++
+		}
+		if k == m {
+			if !fn(i - m + 1) {
+				return
+			}
+			k = pi[k-1]
+		}
+	}
+}
+
+// emptyEach reports the matches of an empty pattern in a text of length n.
+func emptyEach(n int, fn func(int) bool) {
+	for i := 0; i <= n; i++ {
+		if !fn(i) {
+			return
+		}
+	}
+}
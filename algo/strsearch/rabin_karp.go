@@ -0,0 +1,63 @@
+// Package strsearch provides exact string matching algorithms over strings
+// and byte slices.
+// This file implements Rabin-Karp rolling-hash search.
+
+package strsearch
+
+// rkBase is the multiplier of the polynomial rolling hash. Hashes wrap
+// modulo 2^64, and every hash hit is verified, so collisions only cost time.
+const rkBase = 1099511628211
+
+// RabinKarp returns the offsets of all occurrences of pattern in text using
+// Rabin-Karp rolling-hash matching, in O(len(text) + len(pattern)) expected
+// time. Candidate windows are compared byte by byte before being reported.
+func RabinKarp[T Text](text, pattern T) []int {
+	var offsets []int
+	rabinKarpEach(text, pattern, func(i int) bool {
+		offsets = append(offsets, i)
+		return true
+	})
+	return offsets
+}
+
+// rabinKarpEach calls fn for every match offset. Stops early if fn returns false.
+func rabinKarpEach[T Text](text, pattern T, fn func(int) bool) {
+	n, m := len(text), len(pattern)
+	if m == 0 {
+		emptyEach(n, fn)
+		return
+	}
+	if m > n {
+		return
+	}
+
+	// pow is rkBase^(m-1), the weight of the byte leaving the window
+	var want, hash, pow uint64 = 0, 0, 1
+	for i := 0; i < m; i++ {
+		want = want*rkBase + uint64(pattern[i])
+		hash = hash*rkBase + uint64(text[i])
+		if i > 0 {
+			pow *= rkBase
+		}
+	}
+
+	for i := 0; ; i++ {
+		if hash == want && matchAt(text, pattern, i) && !fn(i) {
+			return
+		}
+		if i+m >= n {
+			return
+		}
+		hash = (hash-uint64(text[i])*pow)*rkBase + uint64(text[i+m])
+	}
+}
+
+// matchAt reports whether pattern occurs in text at offset i.
+func matchAt[T Text](text, pattern T, i int) bool {
+	for j := 0; j < len(pattern); j++ {
+		if text[i+j] != pattern[j] {
+			return false
+		}
+	}
+	return true
+}
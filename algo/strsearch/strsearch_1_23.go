@@ -0,0 +1,32 @@
+//go:build go1.23
+// +build go1.23
+
+package strsearch
+
+import (
+	"iter"
+)
+
+// KMPSeq returns an iterator over the offsets of all occurrences of pattern
+// in text, found with the Knuth-Morris-Pratt algorithm.
+func KMPSeq[T Text](text, pattern T) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		kmpEach(text, pattern, yield)
+	}
+}
+
+// ZSeq returns an iterator over the offsets of all occurrences of pattern
+// in text, found with the Z-algorithm.
+func ZSeq[T Text](text, pattern T) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		zEach(text, pattern, yield)
+	}
+}
+
+// RabinKarpSeq returns an iterator over the offsets of all occurrences of
+// pattern in text, found with Rabin-Karp rolling-hash matching.
+func RabinKarpSeq[T Text](text, pattern T) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		rabinKarpEach(text, pattern, yield)
+	}
+}
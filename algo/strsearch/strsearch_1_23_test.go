@@ -0,0 +1,29 @@
+//go:build go1.23
+// +build go1.23
+
+package strsearch
+
+import (
+	"iter"
+	"testing"
+)
+
+func TestSearchSeq(t *testing.T) {
+	seqs := map[string]func(text, pattern string) iter.Seq[int]{
+		"KMPSeq":       KMPSeq[string],
+		"ZSeq":         ZSeq[string],
+		"RabinKarpSeq": RabinKarpSeq[string],
+	}
+	for name, seq := range seqs {
+		var got []int
+		for i := range seq("aaaaaa", "aa") {
+			got = append(got, i)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if len(got) != 2 || got[0] != 0 || got[1] != 1 {
+			t.Errorf("%s: expected [0 1] before break, got %v", name, got)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package strsearch
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// naiveSearch is the reference implementation the algorithms are checked against.
+func naiveSearch(text, pattern string) []int {
+	var offsets []int
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if text[i:i+len(pattern)] == pattern {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+var searchers = map[string]func(text, pattern string) []int{
+	"KMP":       KMP[string],
+	"ZSearch":   ZSearch[string],
+	"RabinKarp": RabinKarp[string],
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		text, pattern string
+		want          []int
+	}{
+		{"abracadabra", "abra", []int{0, 7}},
+		{"aaaaa", "aa", []int{0, 1, 2, 3}},
+		{"abc", "abcd", nil},
+		{"abc", "", []int{0, 1, 2, 3}},
+		{"", "", []int{0}},
+		{"", "a", nil},
+		{"mississippi", "issi", []int{1, 4}},
+		{"abababab", "abab", []int{0, 2, 4}},
+	}
+	for name, search := range searchers {
+		for _, tt := range tests {
+			if got := search(tt.text, tt.pattern); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s(%q, %q) = %v, want %v", name, tt.text, tt.pattern, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSearchRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gen := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "ab"[rng.Intn(2)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 500; i++ {
+		text, pattern := gen(rng.Intn(40)), gen(rng.Intn(5)+1)
+		want := naiveSearch(text, pattern)
+		for name, search := range searchers {
+			if got := search(text, pattern); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s(%q, %q) = %v, want %v", name, text, pattern, got, want)
+			}
+		}
+	}
+}
+
+func TestSearchBytes(t *testing.T) {
+	text, pattern := []byte("xx\x00yx\x00y"), []byte("x\x00y")
+	want := []int{1, 4}
+	if got := KMP(text, pattern); !reflect.DeepEqual(got, want) {
+		t.Errorf("KMP = %v, want %v", got, want)
+	}
+	if got := ZSearch(text, pattern); !reflect.DeepEqual(got, want) {
+		t.Errorf("ZSearch = %v, want %v", got, want)
+	}
+	if got := RabinKarp(text, pattern); !reflect.DeepEqual(got, want) {
+		t.Errorf("RabinKarp = %v, want %v", got, want)
+	}
+}
+
+func TestPrefixFunctionAndZArray(t *testing.T) {
+	if got, want := PrefixFunction("aabaaab"), []int{0, 1, 0, 1, 2, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PrefixFunction = %v, want %v", got, want)
+	}
+	if got, want := ZArray("aabxaab"), []int{7, 1, 0, 0, 3, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ZArray = %v, want %v", got, want)
+	}
+	if len(ZArray("")) != 0 || len(PrefixFunction([]byte{})) != 0 {
+		t.Error("Expected empty arrays for empty input")
+	}
+}
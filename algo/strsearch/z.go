@@ -0,0 +1,71 @@
+// Package strsearch provides exact string matching algorithms over strings
+// and byte slices.
+// This file implements the Z-algorithm.
+
+package strsearch
+
+// ZArray returns the Z-array of s: element i is the length of the longest
+// common prefix of s and s[i:]. By convention element 0 is len(s).
+func ZArray[T Text](s T) []int {
+	n := len(s)
+	z := make([]int, n)
+	if n == 0 {
+		return z
+	}
+	z[0] = n
+	// [l, r) is the rightmost window known to match a prefix of s
+	l, r := 0, 0
+	for i := 1; i < n; i++ {
+		k := 0
+		if i < r {
+			k = min(z[i-l], r-i)
+		}
+		for i+k < n && s[k] == s[i+k] {
+			k++
+		}
+		z[i] = k
+		if i+k > r {
+			l, r = i, i+k
+		}
+	}
+	return z
+}
+
+// ZSearch returns the offsets of all occurrences of pattern in text using
+// the Z-algorithm in O(len(text) + len(pattern)). The Z-array of pattern is
+// reused to skip comparisons inside previously matched windows of text.
+func ZSearch[T Text](text, pattern T) []int {
+	var offsets []int
+	zEach(text, pattern, func(i int) bool {
+		offsets = append(offsets, i)
+		return true
+	})
+	return offsets
+}
+
+// zEach calls fn for every match offset. Stops early if fn returns false.
+func zEach[T Text](text, pattern T, fn func(int) bool) {
+	n, m := len(text), len(pattern)
+	if m == 0 {
+		emptyEach(n, fn)
+		return
+	}
+	z := ZArray(pattern)
+	// [l, r) is the rightmost window of text known to match a prefix of pattern
+	l, r := 0, 0
+	for i := 0; i+m <= n; i++ {
+		k := 0
+		if i < r {
+			k = min(z[i-l], r-i)
+		}
+		if i+k >= r {
+			for k < m && text[i+k] == pattern[k] {
+				k++
+			}
+			l, r = i, i+k
+		}
+		if k == m && !fn(i) {
+			return
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Package bitset provides a dense, growable set of non-negative integers
+// stored one bit each.
+
+package bitset
+
+import (
+	"math/bits"
+)
+
+// wordBits is the number of bits in each word.
+const wordBits = 64
+
+// BitSet is a set of non-negative integers stored as a dense array of
+// 64-bit words, so membership tests and updates are O(1) and bulk set
+// operations work a word at a time. It grows to fit the largest member; its
+// memory is proportional to that member, not to the number of members.
+//
+// The zero value is an empty BitSet ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates an empty BitSet with room for the members 0 to n-1
+// before it needs to grow.
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (max(n, 0)+wordBits-1)/wordBits)}
+}
+
+// grow extends the words to hold bit i.
+func (b *BitSet) grow(i int) {
+	if n := i/wordBits + 1; n > len(b.words) {
+		if n <= cap(b.words) {
+			b.words = b.words[:n]
+		} else {
+			b.words = append(b.words, make([]uint64, n-len(b.words))...)
+		}
+	}
+}
+
+// Set adds i to the set, growing it if needed. Negative values are ignored.
+func (b *BitSet) Set(i int) {
+	if i < 0 {
+		return
+	}
+	b.grow(i)
+	b.words[i/wordBits] |= 1 << (i % wordBits)
+}
+
+// Clear removes i from the set.
+func (b *BitSet) Clear(i int) {
+	if i < 0 || i/wordBits >= len(b.words) {
+		return
+	}
+	b.words[i/wordBits] &^= 1 << (i % wordBits)
+}
+
+// Test returns true if i is in the set.
+func (b *BitSet) Test(i int) bool {
+	if i < 0 || i/wordBits >= len(b.words) {
+		return false
+	}
+	return b.words[i/wordBits]&(1<<(i%wordBits)) != 0
+}
+
+// ClearAll removes every member, keeping the allocated memory.
+func (b *BitSet) ClearAll() {
+	clear(b.words)
+}
+
+// Count returns the number of members.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Len returns the number of bits the set spans: one more than its largest
+// member, or 0 if it is empty.
+func (b *BitSet) Len() int {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if b.words[i] != 0 {
+			return i*wordBits + bits.Len64(b.words[i])
+		}
+	}
+	return 0
+}
+
+// NextSetBit returns the smallest member greater than or equal to i.
+// Returns false if there is none.
+func (b *BitSet) NextSetBit(i int) (int, bool) {
+	i = max(i, 0)
+	w := i / wordBits
+	if w >= len(b.words) {
+		return 0, false
+	}
+	if word := b.words[w] >> (i % wordBits); word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*wordBits + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// Rank returns the number of members less than i. It runs in O(i/64).
+func (b *BitSet) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	w := min(i/wordBits, len(b.words))
+	n := 0
+	for _, word := range b.words[:w] {
+		n += bits.OnesCount64(word)
+	}
+	if w < len(b.words) {
+		n += bits.OnesCount64(b.words[w] & (1<<(i%wordBits) - 1))
+	}
+	return n
+}
+
+// Select returns the member of rank k, that is the (k+1)-th smallest, so
+// Rank(Select(k)) == k. Returns false if k is negative or not less than
+// Count(). It runs in O(m/64), where m is the member returned.
+func (b *BitSet) Select(k int) (int, bool) {
+	if k < 0 {
+		return 0, false
+	}
+	for w, word := range b.words {
+		c := bits.OnesCount64(word)
+		if k >= c {
+			k -= c
+			continue
+		}
+		// Drop the k lowest members of the word, then take the next one
+		for ; k > 0; k-- {
+			word &= word - 1
+		}
+		return w*wordBits + bits.TrailingZeros64(word), true
+	}
+	return 0, false
+}
+
+// And removes the members of b that are not in other.
+func (b *BitSet) And(other *BitSet) {
+	n := min(len(b.words), len(other.words))
+	for i := 0; i < n; i++ {
+		b.words[i] &= other.words[i]
+	}
+	clear(b.words[n:])
+}
+
+// Or adds the members of other to b.
+func (b *BitSet) Or(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(len(other.words)*wordBits - 1)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// Xor keeps the members in exactly one of b and other.
+func (b *BitSet) Xor(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(len(other.words)*wordBits - 1)
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+}
+
+// AndNot removes the members of other from b.
+func (b *BitSet) AndNot(other *BitSet) {
+	n := min(len(b.words), len(other.words))
+	for i := 0; i < n; i++ {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// Equal returns true if b and other have the same members.
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns an independent copy of b.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// Range calls fn for each member in ascending order until fn returns false.
+// fn must not modify the set.
+func (b *BitSet) Range(fn func(i int) bool) {
+	for w, word := range b.words {
+		for word != 0 {
+			if !fn(w*wordBits + bits.TrailingZeros64(word)) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// Slice returns the members in ascending order.
+func (b *BitSet) Slice() []int {
+	members := make([]int, 0, b.Count())
+	b.Range(func(i int) bool {
+		members = append(members, i)
+		return true
+	})
+	return members
+}
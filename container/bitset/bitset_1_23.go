@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+// Package bitset provides a dense, growable set of non-negative integers
+// stored one bit each.
+// This file implements the iterator-based methods of BitSet (go1.23).
+
+package bitset
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the members in ascending order.
+// The set must not be modified during iteration.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		b.Range(yield)
+	}
+}
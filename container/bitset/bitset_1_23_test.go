@@ -0,0 +1,19 @@
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitSetAll(t *testing.T) {
+	var b BitSet
+	for _, v := range []int{130, 2, 64} {
+		b.Set(v)
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, []int{2, 64, 130}) {
+		t.Errorf("Expected [2 64 130], got %v", got)
+	}
+}
@@ -0,0 +1,174 @@
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// randomSet returns a BitSet and the same members as a map.
+func randomSet(rng *rand.Rand, n, universe int) (*BitSet, map[int]bool) {
+	b := &BitSet{}
+	ref := make(map[int]bool)
+	for i := 0; i < n; i++ {
+		v := rng.Intn(universe)
+		b.Set(v)
+		ref[v] = true
+	}
+	return b, ref
+}
+
+// sortedKeys returns the members of ref in ascending order.
+func sortedKeys(ref map[int]bool) []int {
+	keys := make([]int, 0, len(ref))
+	for k, ok := range ref {
+		if ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func TestBitSetBasic(t *testing.T) {
+	var b BitSet
+	if b.Test(0) || b.Count() != 0 || b.Len() != 0 {
+		t.Error("Expected the zero value to be empty")
+	}
+	b.Set(3)
+	b.Set(64)
+	b.Set(200)
+	b.Set(-1)
+	if !b.Test(3) || !b.Test(64) || !b.Test(200) || b.Test(4) || b.Test(-1) || b.Test(1000) {
+		t.Error("Test returned unexpected results")
+	}
+	if b.Count() != 3 || b.Len() != 201 {
+		t.Errorf("Expected 3 members spanning 201 bits, got %d and %d", b.Count(), b.Len())
+	}
+
+	b.Clear(200)
+	b.Clear(5000)
+	b.Clear(-1)
+	if b.Test(200) || b.Len() != 65 {
+		t.Errorf("Expected 200 to be cleared and Len 65, got Len %d", b.Len())
+	}
+	if !reflect.DeepEqual(b.Slice(), []int{3, 64}) {
+		t.Errorf("Expected members [3 64], got %v", b.Slice())
+	}
+
+	c := b.Clone()
+	b.ClearAll()
+	if b.Count() != 0 || c.Count() != 2 {
+		t.Error("Expected ClearAll to leave the clone untouched")
+	}
+
+	n := NewBitSet(100)
+	n.Set(99)
+	if !n.Test(99) || n.Len() != 100 {
+		t.Error("Expected a presized set to hold its last member")
+	}
+}
+
+func TestBitSetRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		b, ref := randomSet(rng, 100, 50+rng.Intn(500))
+		for i := 0; i < 30; i++ {
+			v := rng.Intn(600)
+			b.Clear(v)
+			delete(ref, v)
+		}
+		keys := sortedKeys(ref)
+
+		if b.Count() != len(keys) {
+			t.Fatalf("Count() = %d, want %d", b.Count(), len(keys))
+		}
+		if !reflect.DeepEqual(b.Slice(), keys) {
+			t.Fatalf("Slice() = %v, want %v", b.Slice(), keys)
+		}
+		for k, v := range keys {
+			if r := b.Rank(v); r != k {
+				t.Fatalf("Rank(%d) = %d, want %d", v, r, k)
+			}
+			if s, ok := b.Select(k); !ok || s != v {
+				t.Fatalf("Select(%d) = %d, %v, want %d", k, s, ok, v)
+			}
+		}
+		if _, ok := b.Select(len(keys)); ok {
+			t.Fatalf("Select(%d) succeeded past the last member", len(keys))
+		}
+		if r := b.Rank(1 << 20); r != len(keys) {
+			t.Fatalf("Rank past the end = %d, want %d", r, len(keys))
+		}
+
+		for i := -1; i < 700; i++ {
+			j := sort.SearchInts(keys, i)
+			next, ok := b.NextSetBit(i)
+			if ok != (j < len(keys)) || (ok && next != keys[j]) {
+				t.Fatalf("NextSetBit(%d) = %d, %v, want index %d of %v", i, next, ok, j, keys)
+			}
+		}
+	}
+}
+
+func TestBitSetOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	ops := []struct {
+		name  string
+		apply func(b, other *BitSet)
+		keep  func(inB, inOther bool) bool
+	}{
+		{"And", (*BitSet).And, func(x, y bool) bool { return x && y }},
+		{"Or", (*BitSet).Or, func(x, y bool) bool { return x || y }},
+		{"Xor", (*BitSet).Xor, func(x, y bool) bool { return x != y }},
+		{"AndNot", (*BitSet).AndNot, func(x, y bool) bool { return x && !y }},
+	}
+	for trial := 0; trial < 20; trial++ {
+		a, refA := randomSet(rng, 60, 1+rng.Intn(400))
+		o, refO := randomSet(rng, 60, 1+rng.Intn(400))
+		for _, op := range ops {
+			got := a.Clone()
+			op.apply(got, o)
+			want := make(map[int]bool)
+			for i := 0; i < 400; i++ {
+				if op.keep(refA[i], refO[i]) {
+					want[i] = true
+				}
+			}
+			if !reflect.DeepEqual(got.Slice(), sortedKeys(want)) {
+				t.Fatalf("%s: got %v, want %v", op.name, got.Slice(), sortedKeys(want))
+			}
+		}
+	}
+}
+
+func TestBitSetEqual(t *testing.T) {
+	a, b := &BitSet{}, NewBitSet(1000)
+	a.Set(5)
+	b.Set(5)
+	b.Set(900)
+	b.Clear(900) // trailing zero words do not matter
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Error("Expected sets with the same members to be equal")
+	}
+	b.Set(6)
+	if a.Equal(b) || b.Equal(a) {
+		t.Error("Expected sets with different members to differ")
+	}
+}
+
+func TestBitSetRangeStops(t *testing.T) {
+	var b BitSet
+	for _, v := range []int{1, 70, 140} {
+		b.Set(v)
+	}
+	var got []int
+	b.Range(func(i int) bool {
+		got = append(got, i)
+		return i < 70
+	})
+	if !reflect.DeepEqual(got, []int{1, 70}) {
+		t.Errorf("Expected Range to stop after [1 70], got %v", got)
+	}
+}
@@ -0,0 +1,65 @@
+// Package bitset provides a dense, growable set of non-negative integers
+// stored one bit each.
+// This file implements binary serialization for BitSet.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// bitSetMagic identifies the binary encoding of a BitSet, followed by a
+// version byte.
+const (
+	bitSetMagic   = "BRBIT"
+	bitSetVersion = 1
+)
+
+// ErrInvalidEncoding is returned when decoding data that is not a valid
+// BitSet encoding.
+var ErrInvalidEncoding = errors.New("bitset: invalid encoding")
+
+// The encoding is the magic and version, the word count as a uvarint, then
+// each word up to the last non-zero one as a little-endian uint64.
+
+// MarshalBinary returns the binary encoding of the set.
+// It implements encoding.BinaryMarshaler.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := (b.Len() + wordBits - 1) / wordBits
+	data := make([]byte, 0, len(bitSetMagic)+1+binary.MaxVarintLen64+8*n)
+	data = append(data, bitSetMagic...)
+	data = append(data, bitSetVersion)
+	data = binary.AppendUvarint(data, uint64(n))
+	for _, w := range b.words[:n] {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the decoded data.
+// It implements encoding.BinaryUnmarshaler.
+// On error the set is left unchanged.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data) < len(bitSetMagic)+1 || string(data[:len(bitSetMagic)]) != bitSetMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if v := data[len(bitSetMagic)]; v != bitSetVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	data = data[len(bitSetMagic)+1:]
+
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n != uint64(len(data)-k)/8 || uint64(len(data)-k)%8 != 0 {
+		return fmt.Errorf("%w: bad word count", ErrInvalidEncoding)
+	}
+	data = data[k:]
+
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	b.words = words
+	return nil
+}
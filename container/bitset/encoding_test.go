@@ -0,0 +1,61 @@
+package bitset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBitSetEncoding(t *testing.T) {
+	b := NewBitSet(1000)
+	for _, v := range []int{0, 63, 64, 500} {
+		b.Set(v)
+	}
+	b.Set(999)
+	b.Clear(999) // trailing zero words are not encoded
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if want := len(bitSetMagic) + 1 + 1 + 8*8; len(data) != want {
+		t.Errorf("Expected %d bytes, got %d", want, len(data))
+	}
+	var decoded BitSet
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if !decoded.Equal(b) || decoded.Count() != 4 {
+		t.Errorf("Expected the decoded set to equal the original, got %v", decoded.Slice())
+	}
+
+	var empty BitSet
+	data, _ = empty.MarshalBinary()
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Count() != 0 {
+		t.Errorf("Expected an empty set to round-trip, got %v (err=%v)", decoded.Slice(), err)
+	}
+}
+
+func TestBitSetEncodingInvalid(t *testing.T) {
+	var b BitSet
+	b.Set(10)
+	data, _ := b.MarshalBinary()
+
+	tests := map[string][]byte{
+		"empty":     nil,
+		"magic":     append([]byte("XXBIT"), data[len(bitSetMagic):]...),
+		"version":   append(append([]byte(bitSetMagic), 9), data[len(bitSetMagic)+1:]...),
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0),
+		"count":     append(append([]byte(bitSetMagic), bitSetVersion, 2), data[len(bitSetMagic)+2:]...),
+	}
+	for name, input := range tests {
+		target := &BitSet{}
+		target.Set(3)
+		if err := target.UnmarshalBinary(input); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: error = %v, want ErrInvalidEncoding", name, err)
+		}
+		if !target.Test(3) || target.Count() != 1 {
+			t.Errorf("%s: expected the set to be left unchanged", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package cmpx provides combinators for comparison functions of the form
+// func(a, b T) int, which return a negative number, zero or a positive
+// number when a sorts before, with or after b. This is the form taken by
+// slices.SortFunc, the skip lists and the other ordered containers, so
+// orderings built here plug into all of them.
+package cmpx
+
+import (
+	"cmp"
+)
+
+// Natural returns the natural ordering of T, cmp.Compare. NaNs sort before
+// all other floating-point values.
+func Natural[T cmp.Ordered]() func(a, b T) int {
+	return cmp.Compare[T]
+}
+
+// Reverse returns the opposite ordering to compare.
+func Reverse[T any](compare func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return compare(b, a)
+	}
+}
+
+// By returns an ordering of values by the natural ordering of the key
+// extracted from each, such as a struct field. key is called twice per
+// comparison, so it should be cheap.
+func By[T any, K cmp.Ordered](key func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc returns an ordering of values by the key extracted from each,
+// ordered by compare, for keys that are not cmp.Ordered.
+func ByFunc[T, K any](key func(T) K, compare func(a, b K) int) func(a, b T) int {
+	return func(a, b T) int {
+		return compare(key(a), key(b))
+	}
+}
+
+// Chain returns an ordering that compares by each of compares in turn,
+// falling through to the next only on ties, such as ordering people by last
+// name, then first name. With no compares, all values are equal.
+func Chain[T any](compares ...func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		for _, compare := range compares {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// NilsFirst returns an ordering of pointers that sorts nil before any
+// other pointer and orders the rest by compare on the values they point to.
+func NilsFirst[T any](compare func(a, b T) int) func(a, b *T) int {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return compare(*a, *b)
+	}
+}
+
+// NilsLast returns an ordering of pointers that sorts nil after any other
+// pointer and orders the rest by compare on the values they point to.
+func NilsLast[T any](compare func(a, b T) int) func(a, b *T) int {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return compare(*a, *b)
+	}
+}
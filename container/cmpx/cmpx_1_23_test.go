@@ -0,0 +1,30 @@
+//go:build go1.23
+// +build go1.23
+
+package cmpx_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/cmpx"
+	"github.com/feepwang/br/container/skip_list"
+)
+
+func TestSkipListOrdering(t *testing.T) {
+	type key struct {
+		group string
+		rank  int
+	}
+	sl := skip_list.NewSkipList[key, bool](cmpx.Chain(
+		cmpx.By(func(k key) string { return k.group }),
+		cmpx.Reverse(cmpx.By(func(k key) int { return k.rank })),
+	))
+	for _, k := range []key{{"b", 1}, {"a", 1}, {"a", 5}, {"b", 3}} {
+		sl.Set(k, true)
+	}
+	want := []key{{"a", 5}, {"a", 1}, {"b", 3}, {"b", 1}}
+	if !reflect.DeepEqual(sl.Keys(), want) {
+		t.Errorf("Expected keys %v, got %v", want, sl.Keys())
+	}
+}
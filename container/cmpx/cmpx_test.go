@@ -0,0 +1,81 @@
+package cmpx
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// sorted returns a copy of s stably sorted by compare.
+func sorted[T any](s []T, compare func(a, b T) int) []T {
+	out := append([]T(nil), s...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return compare(out[i], out[j]) < 0
+	})
+	return out
+}
+
+type person struct {
+	first, last string
+	age         int
+}
+
+func TestNaturalAndReverse(t *testing.T) {
+	got := sorted([]float64{2, math.NaN(), -1}, Natural[float64]())
+	if !math.IsNaN(got[0]) || got[1] != -1 || got[2] != 2 {
+		t.Errorf("Expected [NaN -1 2], got %v", got)
+	}
+	if got := sorted([]int{2, 3, 1}, Reverse(Natural[int]())); !reflect.DeepEqual(got, []int{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1], got %v", got)
+	}
+}
+
+func TestByAndChain(t *testing.T) {
+	people := []person{
+		{"Ada", "Lovelace", 36},
+		{"Alan", "Turing", 41},
+		{"Grace", "Hopper", 85},
+		{"Alan", "Kay", 41},
+	}
+	byAge := By(func(p person) int { return p.age })
+	byLast := By(func(p person) string { return p.last })
+
+	got := sorted(people, Chain(Reverse(byAge), byLast))
+	want := []person{people[2], people[3], people[1], people[0]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	byFirstFold := ByFunc(func(p person) string { return p.first }, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	got = sorted(people, Chain(byFirstFold, byLast))
+	want = []person{people[0], people[3], people[1], people[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if Chain[int]()(1, 2) != 0 {
+		t.Error("Expected an empty chain to treat all values as equal")
+	}
+}
+
+func TestNils(t *testing.T) {
+	one, two := 1, 2
+	values := []*int{&two, nil, &one, nil}
+
+	got := sorted(values, NilsFirst(Natural[int]()))
+	if got[0] != nil || got[1] != nil || *got[2] != 1 || *got[3] != 2 {
+		t.Errorf("NilsFirst: expected [nil nil 1 2], got %v", got)
+	}
+	got = sorted(values, NilsLast(Natural[int]()))
+	if *got[0] != 1 || *got[1] != 2 || got[2] != nil || got[3] != nil {
+		t.Errorf("NilsLast: expected [1 2 nil nil], got %v", got)
+	}
+	got = sorted(values, NilsLast(Reverse(Natural[int]())))
+	if *got[0] != 2 || *got[1] != 1 || got[2] != nil {
+		t.Errorf("NilsLast with Reverse: expected [2 1 nil nil], got %v", got)
+	}
+}
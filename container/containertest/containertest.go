@@ -0,0 +1,126 @@
+// Package containertest provides helpers for asserting container state in
+// unit tests. Snapshot captures the contents of any container with a Range
+// method, and Diff compares two captured states entry by entry.
+package containertest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+// Ranger is implemented by containers that can enumerate their entries,
+// such as ordered_map.RedBlackTree and skip_list.Interface.
+type Ranger[K any, V any] interface {
+	Range(fn func(key K, value V) bool)
+}
+
+// State is the contents of a container in iteration order.
+type State[K comparable, V any] []pair.Pair[K, V]
+
+// Snapshot captures the current contents of c.
+func Snapshot[K comparable, V any](c Ranger[K, V]) State[K, V] {
+	var s State[K, V]
+	c.Range(func(key K, value V) bool {
+		s = append(s, pair.Pair[K, V]{First: key, Second: value})
+		return true
+	})
+	return s
+}
+
+// Of builds a state from the given entries, for writing expected states inline.
+func Of[K comparable, V any](entries ...pair.Pair[K, V]) State[K, V] {
+	return State[K, V](entries)
+}
+
+// ChangeKind classifies a single difference between two snapshots.
+type ChangeKind int
+
+const (
+	// Added means the key is only present in the second snapshot.
+	Added ChangeKind = iota
+	// Removed means the key is only present in the first snapshot.
+	Removed
+	// Modified means the key is present in both with different values.
+	Modified
+	// Moved means the key is present in both at a different position.
+	Moved
+)
+
+// Change is one difference between two snapshots.
+type Change[K comparable, V any] struct {
+	Kind ChangeKind
+	Key  K
+	Old  V // value in the first snapshot, zero for Added
+	New  V // value in the second snapshot, zero for Removed
+}
+
+// String formats the change as a single diff line.
+func (c Change[K, V]) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %v: %v", c.Key, c.New)
+	case Removed:
+		return fmt.Sprintf("- %v: %v", c.Key, c.Old)
+	case Modified:
+		return fmt.Sprintf("~ %v: %v -> %v", c.Key, c.Old, c.New)
+	default:
+		return fmt.Sprintf("> %v: moved", c.Key)
+	}
+}
+
+// Delta is the list of changes between two snapshots.
+type Delta[K comparable, V any] []Change[K, V]
+
+// Empty reports whether the snapshots were equal.
+func (d Delta[K, V]) Empty() bool {
+	return len(d) == 0
+}
+
+// String formats the delta with one change per line, or "" if empty.
+func (d Delta[K, V]) String() string {
+	lines := make([]string, len(d))
+	for i, c := range d {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares two snapshots. Changes are listed in the order keys appear
+// in a, followed by keys only present in b. Values are compared with
+// reflect.DeepEqual. A key present in both snapshots with an equal value
+// but at a different relative position is reported as Moved.
+func Diff[K comparable, V any](a, b State[K, V]) Delta[K, V] {
+	index := make(map[K]int, len(b))
+	for i, p := range b {
+		index[p.First] = i
+	}
+
+	var d Delta[K, V]
+	seen := make(map[K]bool, len(a))
+	last := -1 // position in b of the previous common key
+	for _, p := range a {
+		seen[p.First] = true
+		j, ok := index[p.First]
+		if !ok {
+			d = append(d, Change[K, V]{Kind: Removed, Key: p.First, Old: p.Second})
+			continue
+		}
+		if !reflect.DeepEqual(p.Second, b[j].Second) {
+			d = append(d, Change[K, V]{Kind: Modified, Key: p.First, Old: p.Second, New: b[j].Second})
+		} else if j < last {
+			d = append(d, Change[K, V]{Kind: Moved, Key: p.First, Old: p.Second, New: b[j].Second})
+		}
+		if j > last {
+			last = j
+		}
+	}
+	for _, p := range b {
+		if !seen[p.First] {
+			d = append(d, Change[K, V]{Kind: Added, Key: p.First, New: p.Second})
+		}
+	}
+	return d
+}
@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+package containertest
+
+import (
+	"iter"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+// SnapshotSeq captures the key-value pairs yielded by seq, such as the
+// result of a container's All method.
+func SnapshotSeq[K comparable, V any](seq iter.Seq2[K, V]) State[K, V] {
+	var s State[K, V]
+	for key, value := range seq {
+		s = append(s, pair.Pair[K, V]{First: key, Second: value})
+	}
+	return s
+}
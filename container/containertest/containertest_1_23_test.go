@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+package containertest
+
+import (
+	"testing"
+
+	"github.com/feepwang/br/container/ordered_map"
+)
+
+func TestSnapshotSeq(t *testing.T) {
+	tree := ordered_map.NewRedBlackTree[int, string]()
+	tree.Set(1, "a")
+	tree.Set(2, "b")
+
+	if d := Diff(Snapshot[int, string](tree), SnapshotSeq(tree.All())); !d.Empty() {
+		t.Errorf("Expected no diff, got:\n%s", d)
+	}
+}
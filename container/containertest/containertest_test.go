@@ -0,0 +1,54 @@
+package containertest
+
+import (
+	"testing"
+
+	"github.com/feepwang/br/container/ordered_map"
+	"github.com/feepwang/br/container/pair"
+)
+
+func TestSnapshot(t *testing.T) {
+	tree := ordered_map.NewRedBlackTree[int, string]()
+	tree.Set(2, "b")
+	tree.Set(1, "a")
+
+	want := Of(pair.Pair[int, string]{First: 1, Second: "a"}, pair.Pair[int, string]{First: 2, Second: "b"})
+	if d := Diff(Snapshot[int, string](tree), want); !d.Empty() {
+		t.Errorf("Expected no diff, got:\n%s", d)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := ordered_map.NewRedBlackTree[int, string]()
+	for i, v := range []string{"a", "b", "c", "d"} {
+		before.Set(i, v)
+	}
+	a := Snapshot[int, string](before)
+
+	before.Delete(1)
+	before.Set(2, "C")
+	before.Set(9, "z")
+	b := Snapshot[int, string](before)
+
+	d := Diff(a, b)
+	want := "- 1: b\n~ 2: c -> C\n+ 9: z"
+	if d.String() != want {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", want, d)
+	}
+	if d[0].Kind != Removed || d[1].Kind != Modified || d[2].Kind != Added {
+		t.Errorf("Unexpected change kinds: %v", d)
+	}
+}
+
+func TestDiffMoved(t *testing.T) {
+	a := Of(pair.Pair[string, int]{First: "x", Second: 1}, pair.Pair[string, int]{First: "y", Second: 2})
+	b := Of(pair.Pair[string, int]{First: "y", Second: 2}, pair.Pair[string, int]{First: "x", Second: 1})
+
+	d := Diff(a, b)
+	if len(d) != 1 || d[0].Kind != Moved || d[0].Key != "y" {
+		t.Errorf("Expected y to be reported as moved, got %v", d)
+	}
+	if d.String() != "> y: moved" {
+		t.Errorf("Expected '> y: moved', got %q", d.String())
+	}
+}
@@ -0,0 +1,196 @@
+// Package count_min_sketch provides a Count-Min Sketch for approximate
+// frequency counting in bounded memory.
+package count_min_sketch
+
+import (
+	"errors"
+	"math"
+
+	"github.com/feepwang/br/container/hashing"
+)
+
+// Default error bounds used when NewCountMinSketch is given values outside (0, 1).
+const (
+	defaultEpsilon = 0.001
+	defaultDelta   = 0.01
+)
+
+// ErrIncompatible is returned when merging sketches of different dimensions.
+var ErrIncompatible = errors.New("count_min_sketch: incompatible sketches")
+
+// Option configures a CountMinSketch.
+type Option func(*options)
+
+// options holds the configuration set by Options.
+type options struct {
+	conservative bool
+}
+
+// CountMinSketch estimates how many times each item was added, using depth
+// rows of width counters. An item increments one counter per row, chosen by
+// hashing; its estimate is the smallest of those counters. Estimates never
+// undercount, and overcount by at most ε·Total() with probability 1-δ, where
+// width = ⌈e/ε⌉ and depth = ⌈ln(1/δ)⌉.
+//
+// Memory is fixed at width·depth counters regardless of how many distinct
+// items are added, which suits hot-key detection over unbounded streams.
+type CountMinSketch[T any] struct {
+	hash         func(T) uint64
+	width        int
+	depth        int
+	counts       []uint64 // depth rows of width counters
+	total        uint64   // sum of all counts added
+	conservative bool
+}
+
+// WithConservativeUpdate makes Add raise only the counters that are below
+// the new estimate of the item, instead of all of them. This lowers the
+// overcount of every item at no memory cost. Merged sketches still never
+// undercount, but lose part of the benefit.
+func WithConservativeUpdate() Option {
+	return func(o *options) {
+		o.conservative = true
+	}
+}
+
+// NewCountMinSketch creates a sketch whose estimates exceed the true count
+// by at most epsilon·Total() with probability 1-delta. Values of epsilon or
+// delta outside (0, 1) are replaced by 0.001 and 0.01.
+//
+// hash maps items to 64-bit hashes; if nil, hashing.For[T] is used. Sketches
+// are only comparable, for Merge or after a round trip through
+// MarshalBinary, when they hash items identically. hashing.For[T] returns
+// the same Hasher for every sketch in a process, so default-hashed sketches
+// can be merged with each other, but its seed changes between processes;
+// sketches shared between processes need a deterministic hash such as one
+// built on hash/fnv.
+func NewCountMinSketch[T any](epsilon, delta float64, hash func(T) uint64, opts ...Option) *CountMinSketch[T] {
+	if !(epsilon > 0 && epsilon < 1) {
+		epsilon = defaultEpsilon
+	}
+	if !(delta > 0 && delta < 1) {
+		delta = defaultDelta
+	}
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketchSize(width, depth, hash, opts...)
+}
+
+// NewCountMinSketchSize creates a sketch with explicit dimensions. width and
+// depth are raised to at least 1. See NewCountMinSketch for hash.
+func NewCountMinSketchSize[T any](width, depth int, hash func(T) uint64, opts ...Option) *CountMinSketch[T] {
+	if hash == nil {
+		hash = hashing.For[T]().Hash
+	}
+	width, depth = max(width, 1), max(depth, 1)
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &CountMinSketch[T]{
+		hash:         hash,
+		width:        width,
+		depth:        depth,
+		counts:       make([]uint64, width*depth),
+		conservative: o.conservative,
+	}
+}
+
+// Width returns the number of counters per row.
+func (s *CountMinSketch[T]) Width() int {
+	return s.width
+}
+
+// Depth returns the number of rows.
+func (s *CountMinSketch[T]) Depth() int {
+	return s.depth
+}
+
+// Total returns the sum of all counts added to the sketch.
+func (s *CountMinSketch[T]) Total() uint64 {
+	return s.total
+}
+
+// indexes fills idx with the counter of item in each row. Rows are indexed
+// with double hashing, h1 + i·h2, so one hash of the item serves all rows.
+func (s *CountMinSketch[T]) indexes(item T, idx []int) {
+	h1 := s.hash(item)
+	h2 := mix(h1) | 1 // odd, so rows differ even when width is a power of two
+	for i := range idx {
+		idx[i] = i*s.width + int((h1+uint64(i)*h2)%uint64(s.width))
+	}
+}
+
+// mix is the SplitMix64 finalizer, used to derive a second hash from the first.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Add records count occurrences of item.
+func (s *CountMinSketch[T]) Add(item T, count uint64) {
+	if count == 0 {
+		return
+	}
+	idx := make([]int, s.depth)
+	s.indexes(item, idx)
+	s.total += count
+
+	if !s.conservative {
+		for _, i := range idx {
+			s.counts[i] += count
+		}
+		return
+	}
+
+	// Conservative update: raise counters only up to the new estimate
+	target := s.min(idx) + count
+	for _, i := range idx {
+		if s.counts[i] < target {
+			s.counts[i] = target
+		}
+	}
+}
+
+// Estimate returns the estimated number of occurrences of item. It is never
+// less than the true count.
+func (s *CountMinSketch[T]) Estimate(item T) uint64 {
+	idx := make([]int, s.depth)
+	s.indexes(item, idx)
+	return s.min(idx)
+}
+
+// min returns the smallest of the counters at idx.
+func (s *CountMinSketch[T]) min(idx []int) uint64 {
+	m := uint64(math.MaxUint64)
+	for _, i := range idx {
+		m = min(m, s.counts[i])
+	}
+	return m
+}
+
+// Merge adds the counts of other to s, as if every item added to other had
+// been added to s. Both sketches must have the same dimensions and hash
+// items identically; ErrIncompatible is returned for mismatched dimensions,
+// and s is left unchanged.
+func (s *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, c := range other.counts {
+		s.counts[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+// Clear resets all counters to zero.
+func (s *CountMinSketch[T]) Clear() {
+	clear(s.counts)
+	s.total = 0
+}
@@ -0,0 +1,159 @@
+package count_min_sketch
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// fnvHash is a deterministic hash for string items.
+func fnvHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// zipfStream returns n items drawn from a Zipf distribution, with their true counts.
+func zipfStream(n int) ([]string, map[string]uint64) {
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 10000)
+	items := make([]string, n)
+	counts := make(map[string]uint64)
+	for i := range items {
+		items[i] = "key" + strconv.FormatUint(zipf.Uint64(), 10)
+		counts[items[i]]++
+	}
+	return items, counts
+}
+
+func TestCountMinSketchDimensions(t *testing.T) {
+	s := NewCountMinSketch[string](0.01, 0.01, fnvHash)
+	if s.Width() != 272 {
+		t.Errorf("Expected width 272, got %d", s.Width())
+	}
+	if s.Depth() != 5 {
+		t.Errorf("Expected depth 5, got %d", s.Depth())
+	}
+
+	s = NewCountMinSketch[string](0, 2, fnvHash)
+	if s.Width() != int(math.Ceil(math.E/defaultEpsilon)) || s.Depth() != int(math.Ceil(math.Log(1/defaultDelta))) {
+		t.Errorf("Expected default dimensions, got %dx%d", s.Width(), s.Depth())
+	}
+
+	s = NewCountMinSketchSize[string](0, -1, nil)
+	if s.Width() != 1 || s.Depth() != 1 {
+		t.Errorf("Expected dimensions 1x1, got %dx%d", s.Width(), s.Depth())
+	}
+}
+
+func TestCountMinSketchEstimate(t *testing.T) {
+	const epsilon = 0.005
+	items, counts := zipfStream(50000)
+
+	for _, conservative := range []bool{false, true} {
+		var opts []Option
+		if conservative {
+			opts = append(opts, WithConservativeUpdate())
+		}
+		s := NewCountMinSketch[string](epsilon, 0.001, fnvHash, opts...)
+		for _, item := range items {
+			s.Add(item, 1)
+		}
+		if s.Total() != uint64(len(items)) {
+			t.Errorf("Expected total %d, got %d", len(items), s.Total())
+		}
+
+		bound := uint64(epsilon * float64(len(items)))
+		for item, want := range counts {
+			got := s.Estimate(item)
+			if got < want {
+				t.Errorf("conservative=%v: estimate of %s is %d, below true count %d", conservative, item, got, want)
+			}
+			if got > want+bound {
+				t.Errorf("conservative=%v: estimate of %s is %d, above bound %d", conservative, item, got, want+bound)
+			}
+		}
+	}
+}
+
+func TestCountMinSketchConservative(t *testing.T) {
+	items, counts := zipfStream(20000)
+	plain := NewCountMinSketchSize[string](64, 4, fnvHash)
+	conservative := NewCountMinSketchSize[string](64, 4, fnvHash, WithConservativeUpdate())
+	for _, item := range items {
+		plain.Add(item, 1)
+		conservative.Add(item, 1)
+	}
+
+	var plainErr, conservativeErr uint64
+	for item, want := range counts {
+		p, c := plain.Estimate(item), conservative.Estimate(item)
+		if c < want || c > p {
+			t.Errorf("Expected %d <= conservative estimate %d <= plain estimate %d for %s", want, c, p, item)
+		}
+		plainErr += p - want
+		conservativeErr += c - want
+	}
+	if conservativeErr >= plainErr {
+		t.Errorf("Expected conservative update to lower the total error, got %d vs %d", conservativeErr, plainErr)
+	}
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	items, _ := zipfStream(10000)
+	whole := NewCountMinSketchSize[string](100, 4, fnvHash)
+	left := NewCountMinSketchSize[string](100, 4, fnvHash)
+	right := NewCountMinSketchSize[string](100, 4, fnvHash)
+	for i, item := range items {
+		whole.Add(item, 2)
+		if i%2 == 0 {
+			left.Add(item, 2)
+		} else {
+			right.Add(item, 2)
+		}
+	}
+
+	if err := left.Merge(right); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if left.Total() != whole.Total() {
+		t.Errorf("Expected total %d, got %d", whole.Total(), left.Total())
+	}
+	for _, item := range items[:100] {
+		if left.Estimate(item) != whole.Estimate(item) {
+			t.Errorf("Expected merged estimate %d for %s, got %d", whole.Estimate(item), item, left.Estimate(item))
+		}
+	}
+
+	if err := left.Merge(NewCountMinSketchSize[string](100, 5, fnvHash)); err != ErrIncompatible {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestCountMinSketchMergeDefaultHash(t *testing.T) {
+	x := NewCountMinSketchSize[string](100, 4, nil)
+	y := NewCountMinSketchSize[string](100, 4, nil)
+	y.Add("hot", 100)
+
+	if err := x.Merge(y); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if got := x.Estimate("hot"); got < 100 {
+		t.Errorf("Expected merged estimate of at least 100, got %d", got)
+	}
+}
+
+func TestCountMinSketchClear(t *testing.T) {
+	s := NewCountMinSketchSize[int](16, 3, nil)
+	s.Add(1, 5)
+	s.Add(2, 0)
+	if s.Estimate(1) != 5 {
+		t.Errorf("Expected 5, got %d", s.Estimate(1))
+	}
+	s.Clear()
+	if s.Estimate(1) != 0 || s.Total() != 0 {
+		t.Errorf("Expected empty sketch after Clear, got estimate %d and total %d", s.Estimate(1), s.Total())
+	}
+}
@@ -0,0 +1,99 @@
+// Package count_min_sketch provides a Count-Min Sketch for approximate
+// frequency counting in bounded memory.
+// This file implements binary serialization for CountMinSketch.
+
+package count_min_sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// sketchMagic identifies the binary encoding of a CountMinSketch, followed
+// by a version byte.
+const (
+	sketchMagic   = "BRCMS"
+	sketchVersion = 1
+)
+
+// ErrInvalidEncoding is returned when decoding data that is not a valid
+// CountMinSketch encoding.
+var ErrInvalidEncoding = errors.New("count_min_sketch: invalid encoding")
+
+// The encoding is the magic and version, a flags byte (bit 0 set for
+// conservative update), the width, depth and total as uvarints, then the
+// counters row by row as uvarints.
+
+// MarshalBinary returns the binary encoding of the sketch. The hash function
+// is not encoded.
+// It implements encoding.BinaryMarshaler.
+func (s *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(sketchMagic)+2+3*binary.MaxVarintLen64+len(s.counts))
+	data = append(data, sketchMagic...)
+	data = append(data, sketchVersion)
+	var flags byte
+	if s.conservative {
+		flags |= 1
+	}
+	data = append(data, flags)
+	data = binary.AppendUvarint(data, uint64(s.width))
+	data = binary.AppendUvarint(data, uint64(s.depth))
+	data = binary.AppendUvarint(data, s.total)
+	for _, c := range s.counts {
+		data = binary.AppendUvarint(data, c)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the sketch with the decoded data,
+// including its dimensions. The hash function is kept, and must match the
+// one the encoded sketch was built with.
+// It implements encoding.BinaryUnmarshaler.
+// On error the sketch is left unchanged.
+func (s *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(sketchMagic)+2 || string(data[:len(sketchMagic)]) != sketchMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if v := data[len(sketchMagic)]; v != sketchVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	flags := data[len(sketchMagic)+1]
+	if flags&^1 != 0 {
+		return fmt.Errorf("%w: unknown flags %#x", ErrInvalidEncoding, flags)
+	}
+	data = data[len(sketchMagic)+2:]
+
+	var header [3]uint64 // width, depth, total
+	for i := range header {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("%w: truncated header", ErrInvalidEncoding)
+		}
+		header[i] = v
+		data = data[n:]
+	}
+	width, depth := header[0], header[1]
+	// Every counter takes at least one byte, which bounds the allocation
+	if width == 0 || depth == 0 || width > uint64(len(data)) || depth > uint64(len(data))/width {
+		return fmt.Errorf("%w: bad dimensions %dx%d", ErrInvalidEncoding, width, depth)
+	}
+
+	counts := make([]uint64, width*depth)
+	for i := range counts {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("%w: truncated counters", ErrInvalidEncoding)
+		}
+		counts[i] = v
+		data = data[n:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+
+	s.width, s.depth, s.total = int(width), int(depth), header[2]
+	s.counts = counts
+	s.conservative = flags&1 != 0
+	return nil
+}
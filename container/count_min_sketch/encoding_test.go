@@ -0,0 +1,67 @@
+package count_min_sketch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCountMinSketchBinaryRoundTrip(t *testing.T) {
+	items, _ := zipfStream(5000)
+	s := NewCountMinSketchSize[string](50, 3, fnvHash, WithConservativeUpdate())
+	for _, item := range items {
+		s.Add(item, 1)
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	decoded := NewCountMinSketchSize[string](1, 1, fnvHash)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if decoded.Width() != 50 || decoded.Depth() != 3 || decoded.Total() != s.Total() {
+		t.Errorf("Expected 50x3 sketch with total %d, got %dx%d with total %d",
+			s.Total(), decoded.Width(), decoded.Depth(), decoded.Total())
+	}
+	for _, item := range items[:100] {
+		if decoded.Estimate(item) != s.Estimate(item) {
+			t.Errorf("Expected estimate %d for %s, got %d", s.Estimate(item), item, decoded.Estimate(item))
+		}
+	}
+
+	// The conservative flag survives the round trip
+	decoded.Add("new", 1)
+	decoded.Add("new", 1)
+	s.Add("new", 1)
+	s.Add("new", 1)
+	if decoded.Estimate("new") != s.Estimate("new") {
+		t.Errorf("Expected estimate %d after round trip, got %d", s.Estimate("new"), decoded.Estimate("new"))
+	}
+}
+
+func TestCountMinSketchUnmarshalInvalid(t *testing.T) {
+	s := NewCountMinSketchSize[string](4, 2, fnvHash)
+	s.Add("a", 3)
+	data, _ := s.MarshalBinary()
+
+	cases := map[string][]byte{
+		"empty":      nil,
+		"bad magic":  append([]byte("XXXXX"), data[5:]...),
+		"version":    append(append([]byte(sketchMagic), 9), data[6:]...),
+		"flags":      append(append([]byte(sketchMagic), sketchVersion, 2), data[7:]...),
+		"truncated":  data[:len(data)-1],
+		"trailing":   append(append([]byte(nil), data...), 0),
+		"huge width": append(append([]byte(sketchMagic), sketchVersion, 0, 0xff, 0xff, 0xff, 0xff, 0x0f, 1, 0), data[10:]...),
+	}
+	for name, input := range cases {
+		decoded := NewCountMinSketchSize[string](7, 7, fnvHash)
+		if err := decoded.UnmarshalBinary(input); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: expected ErrInvalidEncoding, got %v", name, err)
+		}
+		if decoded.Width() != 7 || decoded.Depth() != 7 {
+			t.Errorf("%s: expected sketch to be unchanged, got %dx%d", name, decoded.Width(), decoded.Depth())
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package delay_queue provides a DelayQueue that releases items once their
+// ready time has passed.
+// The queue owns no goroutines or timers: callers pass the current time to
+// Poll, which makes it easy to embed in event loops and to test with an
+// injected clock.
+package delay_queue
+
+import (
+	"container/heap"
+	"time"
+)
+
+// delayItem is an element of the queue together with its ready time.
+type delayItem[T any] struct {
+	value   T
+	readyAt time.Time
+	seq     uint64 // insertion order, breaks ties between equal ready times
+}
+
+// delayHeap is a min-heap of items ordered by (readyAt, seq).
+type delayHeap[T any] []delayItem[T]
+
+func (h delayHeap[T]) Len() int { return len(h) }
+
+func (h delayHeap[T]) Less(i, j int) bool {
+	if h[i].readyAt.Equal(h[j].readyAt) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].readyAt.Before(h[j].readyAt)
+}
+
+func (h delayHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *delayHeap[T]) Push(x any) { *h = append(*h, x.(delayItem[T])) }
+
+func (h *delayHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = delayItem[T]{} // release references held by value
+	*h = old[:n-1]
+	return item
+}
+
+// DelayQueue is a priority queue keyed by ready time.
+// Items with equal ready times are released in insertion order, so Poll is
+// fully deterministic for a given sequence of Push and Poll calls.
+type DelayQueue[T any] struct {
+	items delayHeap[T]
+	seq   uint64
+}
+
+// NewDelayQueue creates a new empty DelayQueue.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{}
+}
+
+// Len returns the number of items in the queue, ready or not.
+func (q *DelayQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push adds an item that becomes ready at readyAt.
+func (q *DelayQueue[T]) Push(value T, readyAt time.Time) {
+	heap.Push(&q.items, delayItem[T]{value: value, readyAt: readyAt, seq: q.seq})
+	q.seq++
+}
+
+// Peek returns the item with the earliest ready time and that time,
+// without removing it. Returns false if the queue is empty.
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, time.Time{}, false
+	}
+	return q.items[0].value, q.items[0].readyAt, true
+}
+
+// Delay returns how long after now the earliest item becomes ready.
+// The result is zero or negative if an item is already ready, and false is
+// returned if the queue is empty. Event loops can use it to size their sleep.
+func (q *DelayQueue[T]) Delay(now time.Time) (time.Duration, bool) {
+	if len(q.items) == 0 {
+		return 0, false
+	}
+	return q.items[0].readyAt.Sub(now), true
+}
+
+// Poll removes and returns the earliest item if it is ready at now
+// (its ready time is not after now). Returns false otherwise.
+func (q *DelayQueue[T]) Poll(now time.Time) (T, bool) {
+	if len(q.items) == 0 || q.items[0].readyAt.After(now) {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&q.items).(delayItem[T]).value, true
+}
+
+// PollAll removes and returns every item ready at now, in release order.
+func (q *DelayQueue[T]) PollAll(now time.Time) []T {
+	var ready []T
+	for {
+		v, ok := q.Poll(now)
+		if !ok {
+			return ready
+		}
+		ready = append(ready, v)
+	}
+}
+
+// Clear removes all items from the queue.
+func (q *DelayQueue[T]) Clear() {
+	q.items = nil
+}
@@ -0,0 +1,26 @@
+//go:build go1.23
+// +build go1.23
+
+// Package delay_queue provides go1.23-specific methods for DelayQueue.
+// This file adds iter.Seq related methods.
+
+package delay_queue
+
+import (
+	"iter"
+	"time"
+)
+
+// Ready returns an iterator that drains the items ready at now, in release
+// order (go1.23). Items are removed as they are yielded; stopping early
+// leaves the remaining ready items in the queue.
+func (q *DelayQueue[T]) Ready(now time.Time) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := q.Poll(now)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
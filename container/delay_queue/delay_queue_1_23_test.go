@@ -0,0 +1,38 @@
+//go:build go1.23
+// +build go1.23
+
+package delay_queue
+
+import (
+	"testing"
+)
+
+func TestDelayQueueReady(t *testing.T) {
+	q := NewDelayQueue[int]()
+	for i := 1; i <= 5; i++ {
+		q.Push(i, at(i))
+	}
+
+	var got []int
+	for v := range q.Ready(at(3)) {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Expected [1 2], got %v", got)
+	}
+
+	// Item 3 is still ready and queued after stopping early
+	got = got[:0]
+	for v := range q.Ready(at(3)) {
+		got = append(got, v)
+	}
+	if len(got) != 1 || got[0] != 3 {
+		t.Errorf("Expected [3], got %v", got)
+	}
+	if q.Len() != 2 {
+		t.Errorf("Expected 2 items left, got %d", q.Len())
+	}
+}
@@ -0,0 +1,73 @@
+package delay_queue
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return epoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestDelayQueueBasic(t *testing.T) {
+	q := NewDelayQueue[string]()
+
+	// Test empty queue
+	if q.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", q.Len())
+	}
+	if _, ok := q.Poll(at(100)); ok {
+		t.Error("Expected false when polling empty queue")
+	}
+	if _, ok := q.Delay(at(0)); ok {
+		t.Error("Expected false for Delay of empty queue")
+	}
+
+	q.Push("c", at(30))
+	q.Push("a", at(10))
+	q.Push("b", at(20))
+
+	if v, readyAt, ok := q.Peek(); !ok || v != "a" || !readyAt.Equal(at(10)) {
+		t.Errorf("Expected Peek to return a@10, got %s@%v", v, readyAt)
+	}
+	if d, _ := q.Delay(at(4)); d != 6*time.Second {
+		t.Errorf("Expected delay 6s, got %v", d)
+	}
+
+	// Nothing is ready yet
+	if _, ok := q.Poll(at(9)); ok {
+		t.Error("Expected nothing ready at t=9")
+	}
+
+	if v, ok := q.Poll(at(10)); !ok || v != "a" {
+		t.Errorf("Expected (a, true) at t=10, got (%s, %t)", v, ok)
+	}
+	if q.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", q.Len())
+	}
+
+	if got := q.PollAll(at(25)); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Expected [b] at t=25, got %v", got)
+	}
+
+	q.Clear()
+	if q.Len() != 0 {
+		t.Errorf("Expected length 0 after Clear, got %d", q.Len())
+	}
+}
+
+func TestDelayQueueDeterministicTies(t *testing.T) {
+	q := NewDelayQueue[int]()
+	for i := 0; i < 10; i++ {
+		q.Push(i, at(5))
+	}
+	q.Push(-1, at(1))
+
+	want := []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got := q.PollAll(at(5)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,128 @@
+// Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
+// This file implements ConcurrentDSU, a lock-free DSU for concurrent use.
+
+package dsu
+
+import (
+	"sync/atomic"
+)
+
+// ConcurrentDSU is a Disjoint Set Union that is safe for concurrent use by
+// multiple goroutines without locks, so parallel workers can process the
+// edges of a graph directly.
+//
+// It follows Rem's algorithm: a root is always linked under a root with a
+// smaller index, which rules out cycles without ranks, and links are
+// published with compare-and-swap, retrying when another goroutine changed
+// either root first. Find halves paths as it goes, also with
+// compare-and-swap.
+//
+// Union, Connected and Find are linearizable: each takes effect at a single
+// point during the call. ComponentCount is decremented just after the link
+// of a successful Union is published, so while unions are in flight it may
+// briefly exceed the true count; once they return it is exact.
+type ConcurrentDSU struct {
+	parent     []atomic.Int64 // parent[i] is the parent of element i in the tree
+	components atomic.Int64   // number of disjoint components
+}
+
+// NewConcurrentDSU creates a new ConcurrentDSU with n elements (0 to n-1),
+// each in its own set. Returns nil if n <= 0.
+func NewConcurrentDSU(n int) *ConcurrentDSU {
+	if n <= 0 {
+		return nil
+	}
+
+	d := &ConcurrentDSU{parent: make([]atomic.Int64, n)}
+	for i := range d.parent {
+		d.parent[i].Store(int64(i))
+	}
+	d.components.Store(int64(n))
+	return d
+}
+
+// valid returns true if x is an element of the DSU.
+func (d *ConcurrentDSU) valid(x int) bool {
+	return x >= 0 && x < len(d.parent)
+}
+
+// Find returns the representative (root) of the set containing element x,
+// or -1 if x is not an element. Concurrent unions may make the result
+// stale as soon as it is returned.
+func (d *ConcurrentDSU) Find(x int) int {
+	if !d.valid(x) {
+		return -1
+	}
+	return int(d.find(int64(x)))
+}
+
+// find returns the root of x, pointing every other node on the path to its
+// grandparent (path halving).
+func (d *ConcurrentDSU) find(x int64) int64 {
+	for {
+		p := d.parent[x].Load()
+		if p == x {
+			return x
+		}
+		gp := d.parent[p].Load()
+		if gp != p {
+			// Losing this race is harmless: another goroutine moved x closer to the root
+			d.parent[x].CompareAndSwap(p, gp)
+		}
+		x = gp
+	}
+}
+
+// Union merges the sets containing elements x and y.
+// Returns true if this call merged two sets, false if the elements were
+// already in the same set or an element is invalid.
+func (d *ConcurrentDSU) Union(x, y int) bool {
+	if !d.valid(x) || !d.valid(y) {
+		return false
+	}
+
+	for {
+		rootX, rootY := d.find(int64(x)), d.find(int64(y))
+		if rootX == rootY {
+			return false
+		}
+		// Link the larger index under the smaller; fails if rootX stopped being a root
+		if rootX < rootY {
+			rootX, rootY = rootY, rootX
+		}
+		if d.parent[rootX].CompareAndSwap(rootX, rootY) {
+			d.components.Add(-1)
+			return true
+		}
+	}
+}
+
+// Connected returns true if elements x and y are in the same set.
+func (d *ConcurrentDSU) Connected(x, y int) bool {
+	if !d.valid(x) || !d.valid(y) {
+		return false
+	}
+
+	for {
+		rootX, rootY := d.find(int64(x)), d.find(int64(y))
+		if rootX == rootY {
+			return true
+		}
+		// rootY was found after rootX; if rootX is still a root, the sets
+		// were distinct when rootY was read
+		if d.parent[rootX].Load() == rootX {
+			return false
+		}
+	}
+}
+
+// ComponentCount returns the number of disjoint sets (connected components).
+// See ConcurrentDSU for its consistency while unions are in flight.
+func (d *ConcurrentDSU) ComponentCount() int {
+	return int(d.components.Load())
+}
+
+// Size returns the total number of elements in the DSU.
+func (d *ConcurrentDSU) Size() int {
+	return len(d.parent)
+}
@@ -0,0 +1,91 @@
+package dsu
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentDSUBasic(t *testing.T) {
+	if d := NewConcurrentDSU(0); d != nil {
+		t.Errorf("NewConcurrentDSU(0) = %v, want nil", d)
+	}
+
+	d := NewConcurrentDSU(5)
+	if !d.Union(3, 1) || !d.Union(1, 4) {
+		t.Error("Union returned false for disjoint sets")
+	}
+	if d.Union(4, 3) || d.Union(0, 5) {
+		t.Error("Union returned true for joined sets or an invalid element")
+	}
+	if !d.Connected(3, 4) || d.Connected(0, 3) || d.Connected(-1, 3) {
+		t.Error("Connected returned unexpected results")
+	}
+	if got := d.Find(4); got != 1 {
+		t.Errorf("Find(4) = %d, want 1", got)
+	}
+	if got := d.Find(5); got != -1 {
+		t.Errorf("Find(5) = %d, want -1", got)
+	}
+	if got := d.ComponentCount(); got != 3 {
+		t.Errorf("ComponentCount() = %d, want 3", got)
+	}
+	if got := d.Size(); got != 5 {
+		t.Errorf("Size() = %d, want 5", got)
+	}
+}
+
+func TestConcurrentDSUParallelUnions(t *testing.T) {
+	const n, edges, workers = 10000, 8000, 8
+	rng := rand.New(rand.NewSource(1))
+	pairs := make([][2]int, edges)
+	ref := NewDSU(n)
+	for i := range pairs {
+		pairs[i] = [2]int{rng.Intn(n), rng.Intn(n)}
+		ref.Union(pairs[i][0], pairs[i][1])
+	}
+
+	d := NewConcurrentDSU(n)
+	var merged atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Every worker processes every edge, so unions race on the same roots
+			for i := range pairs {
+				p := pairs[(i+w*edges/workers)%edges]
+				if d.Union(p[0], p[1]) {
+					merged.Add(1)
+				}
+				d.Connected(p[1], p[0])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if got, want := d.ComponentCount(), ref.ComponentCount(); got != want {
+		t.Errorf("ComponentCount() = %d, want %d", got, want)
+	}
+	if got, want := int(merged.Load()), n-ref.ComponentCount(); got != want {
+		t.Errorf("%d unions reported a merge, want %d", got, want)
+	}
+	for i := 0; i < 2000; i++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		if got, want := d.Connected(x, y), ref.Connected(x, y); got != want {
+			t.Fatalf("Connected(%d, %d) = %v, want %v", x, y, got, want)
+		}
+	}
+}
+
+func BenchmarkConcurrentDSUUnion(b *testing.B) {
+	const n = 1 << 16
+	d := NewConcurrentDSU(n)
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			d.Union(rng.Intn(n), rng.Intn(n))
+		}
+	})
+}
@@ -1,23 +1,45 @@
-//go:build !go1.23
-// +build !go1.23
-
 package dsu
 
+import (
+	"sort"
+)
+
 // DSU represents a Disjoint Set Union (Union-Find) data structure.
 // It maintains a forest of trees where each tree represents a disjoint set.
 // The structure uses path compression and union by rank optimizations
 // to achieve nearly constant time complexity for operations.
+//
+// Elements can be added after construction with MakeSet and EnsureSize.
+// The zero value is an empty DSU ready to use.
 type DSU struct {
 	parent     []int // parent[i] is the parent of element i in the tree
 	rank       []int // rank[i] is the approximate depth of the tree rooted at i
 	components int   // number of disjoint components
 	size       int   // total number of elements
+
+	// members[r] lists the elements of the set rooted at r, maintained only
+	// when trackMembers is set
+	members      [][]int
+	trackMembers bool
+}
+
+// Option configures a DSU created by NewDSU.
+type Option func(*DSU)
+
+// WithMemberLists makes the DSU maintain the list of elements of each set,
+// merging the shorter list into the longer one on Union. SetMembers then
+// runs in O(k log k) for a set of k elements instead of O(n), at the cost
+// of O(n) extra memory and O(n log n) total work across all unions.
+func WithMemberLists() Option {
+	return func(d *DSU) {
+		d.trackMembers = true
+	}
 }
 
 // NewDSU creates a new Disjoint Set Union with n elements (0 to n-1).
 // Initially, each element forms its own singleton set.
-// Returns nil if n <= 0.
-func NewDSU(n int) Interface {
+// Returns nil if n <= 0; use NewEmptyDSU to start empty with options.
+func NewDSU(n int, opts ...Option) Interface {
 	if n <= 0 {
 		return nil
 	}
@@ -28,16 +50,68 @@ func NewDSU(n int) Interface {
 		components: n,
 		size:       n,
 	}
+	for _, opt := range opts {
+		opt(dsu)
+	}
 
 	// Initialize each element as its own parent (singleton sets)
 	for i := 0; i < n; i++ {
 		dsu.parent[i] = i
 		// rank[i] = 0 (default zero value)
 	}
+	if dsu.trackMembers {
+		dsu.members = make([][]int, n)
+		for i := range dsu.members {
+			dsu.members[i] = []int{i}
+		}
+	}
 
 	return dsu
 }
 
+// NewEmptyDSU creates a Disjoint Set Union with no elements, configured by
+// opts. Elements are added with MakeSet and EnsureSize.
+func NewEmptyDSU(opts ...Option) Interface {
+	dsu := &DSU{}
+	for _, opt := range opts {
+		opt(dsu)
+	}
+	return dsu
+}
+
+// MakeSet adds a new element as a singleton set and returns it.
+// The new element is the previous Size().
+func (d *DSU) MakeSet() int {
+	x := d.size
+	d.parent = append(d.parent, x)
+	d.rank = append(d.rank, 0)
+	if d.trackMembers {
+		d.members = append(d.members, []int{x})
+	}
+	d.components++
+	d.size++
+	return x
+}
+
+// EnsureSize grows the DSU to at least n elements, adding each missing
+// element as a singleton set. It does nothing if the DSU already has n or
+// more elements.
+func (d *DSU) EnsureSize(n int) {
+	if n <= d.size {
+		return
+	}
+	d.parent = append(d.parent, make([]int, n-d.size)...)
+	d.rank = append(d.rank, make([]int, n-d.size)...)
+	for i := d.size; i < n; i++ {
+		d.parent[i] = i
+		if d.trackMembers {
+			d.members = append(d.members, []int{i})
+		}
+	}
+	d.components += n - d.size
+	d.size = n
+}
+
 // Find returns the representative (root) of the set containing element x.
 // Implements path compression optimization: during traversal to the root,
 // all nodes on the path are directly connected to the root, flattening
@@ -75,15 +149,24 @@ func (d *DSU) Union(x, y int) bool {
 
 	// Union by rank: attach the tree with smaller rank under the tree with larger rank
 	if d.rank[rootX] < d.rank[rootY] {
-		d.parent[rootX] = rootY
-	} else if d.rank[rootX] > d.rank[rootY] {
-		d.parent[rootY] = rootX
-	} else {
-		// Same rank: make one the parent and increase its rank
-		d.parent[rootY] = rootX
+		rootX, rootY = rootY, rootX
+	}
+	d.parent[rootY] = rootX
+	if d.rank[rootX] == d.rank[rootY] {
+		// Same rank: the new root's tree grows one level deeper
 		d.rank[rootX]++
 	}
 
+	if d.trackMembers {
+		// Small-to-large: copy the shorter list into the longer one
+		long, short := d.members[rootX], d.members[rootY]
+		if len(long) < len(short) {
+			long, short = short, long
+		}
+		d.members[rootX] = append(long, short...)
+		d.members[rootY] = nil
+	}
+
 	// Decrease the number of components since we merged two sets
 	d.components--
 	return true
@@ -98,6 +181,82 @@ func (d *DSU) Connected(x, y int) bool {
 	return d.Find(x) == d.Find(y)
 }
 
+// SetMembers returns the elements of the set containing x in ascending
+// order, or nil if x is not an element. It scans all elements unless the
+// DSU was created with WithMemberLists.
+func (d *DSU) SetMembers(x int) []int {
+	root := d.Find(x)
+	if root < 0 {
+		return nil
+	}
+
+	if d.trackMembers {
+		members := append([]int(nil), d.members[root]...)
+		sort.Ints(members)
+		return members
+	}
+	var members []int
+	for i := 0; i < d.size; i++ {
+		if d.Find(i) == root {
+			members = append(members, i)
+		}
+	}
+	return members
+}
+
+// Sets returns every set as a slice of its elements in ascending order, with
+// the sets ordered by their smallest element.
+func (d *DSU) Sets() [][]int {
+	sets := make([][]int, 0, d.components)
+	index := make(map[int]int, d.components) // root -> index in sets
+	for i := 0; i < d.size; i++ {
+		root := d.Find(i)
+		j, ok := index[root]
+		if !ok {
+			j = len(sets)
+			index[root] = j
+			sets = append(sets, nil)
+		}
+		sets[j] = append(sets[j], i)
+	}
+	return sets
+}
+
+// RangeSetMembers calls fn for each element of the set containing x until
+// fn returns false. Elements come in ascending order, or in no particular
+// order when the DSU was created with WithMemberLists. It does nothing if x
+// is not an element. fn must not modify the DSU.
+func (d *DSU) RangeSetMembers(x int, fn func(member int) bool) {
+	root := d.Find(x)
+	if root < 0 {
+		return
+	}
+
+	if d.trackMembers {
+		for _, member := range d.members[root] {
+			if !fn(member) {
+				return
+			}
+		}
+		return
+	}
+	for i := 0; i < d.size; i++ {
+		if d.Find(i) == root && !fn(i) {
+			return
+		}
+	}
+}
+
+// RangeSets calls fn for each set, in the order and form of Sets, until fn
+// returns false. fn must not modify the DSU.
+func (d *DSU) RangeSets(fn func(set []int) bool) {
+	for _, set := range d.Sets() {
+		if !fn(set) {
+			return
+		}
+	}
+}
+
 // ComponentCount returns the current number of disjoint sets (connected components).
 // This value starts at n (when each element is its own set) and decreases
 // by 1 with each successful union operation.
@@ -106,7 +265,8 @@ func (d *DSU) ComponentCount() int {
 }
 
 // Size returns the total number of elements in the DSU.
-// This is the n value that was used during initialization.
+// This is the n value that was used during initialization, plus the
+// elements added since.
 func (d *DSU) Size() int {
 	return d.size
 }
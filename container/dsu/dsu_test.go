@@ -1,6 +1,9 @@
 package dsu
 
 import (
+	"math/rand"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -314,6 +317,160 @@ func TestSingleElement(t *testing.T) {
 	}
 }
 
+func TestGrowth(t *testing.T) {
+	dsu := NewDSU(2)
+	dsu.Union(0, 1)
+
+	if got := dsu.MakeSet(); got != 2 {
+		t.Errorf("MakeSet() = %d, want 2", got)
+	}
+	dsu.EnsureSize(5)
+	dsu.EnsureSize(3) // no-op
+	if got := dsu.Size(); got != 5 {
+		t.Errorf("Size() = %d, want 5", got)
+	}
+	if got := dsu.ComponentCount(); got != 4 {
+		t.Errorf("ComponentCount() = %d, want 4", got)
+	}
+	for x := 2; x < 5; x++ {
+		if got := dsu.Find(x); got != x {
+			t.Errorf("Find(%d) = %d, want %d", x, got, x)
+		}
+	}
+
+	// New elements take part in unions like the initial ones
+	if !dsu.Union(4, 0) || !dsu.Connected(1, 4) {
+		t.Error("Union(4, 0) did not connect 4 with the set of 0 and 1")
+	}
+	if dsu.Connected(2, 3) {
+		t.Error("Connected(2, 3) = true, want false")
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var dsu DSU
+	if got := dsu.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+	a, b := dsu.MakeSet(), dsu.MakeSet()
+	if a != 0 || b != 1 {
+		t.Errorf("MakeSet() = %d, %d, want 0, 1", a, b)
+	}
+	if !dsu.Union(a, b) || dsu.ComponentCount() != 1 {
+		t.Errorf("Union(0, 1) left ComponentCount() = %d, want 1", dsu.ComponentCount())
+	}
+}
+
+func TestNewEmptyDSU(t *testing.T) {
+	dsu := NewEmptyDSU(WithMemberLists())
+	if got := dsu.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+	if !dsu.(*DSU).trackMembers {
+		t.Fatal("NewEmptyDSU(WithMemberLists()) does not track members")
+	}
+
+	dsu.EnsureSize(3)
+	dsu.Union(0, dsu.MakeSet())
+	dsu.Union(3, 2)
+	if got := dsu.SetMembers(2); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Errorf("SetMembers(2) = %v, want [0 2 3]", got)
+	}
+	if got := dsu.(*DSU).members[dsu.Find(0)]; len(got) != 3 {
+		t.Errorf("member list of the root has %d elements, want 3", len(got))
+	}
+}
+
+func TestSetMembers(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithMemberLists()}} {
+		dsu := NewDSU(6, opts...)
+		dsu.Union(4, 1)
+		dsu.Union(0, 4)
+		dsu.Union(3, 5)
+
+		if got := dsu.SetMembers(1); !reflect.DeepEqual(got, []int{0, 1, 4}) {
+			t.Errorf("SetMembers(1) = %v, want [0 1 4]", got)
+		}
+		if got := dsu.SetMembers(2); !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("SetMembers(2) = %v, want [2]", got)
+		}
+		if got := dsu.SetMembers(6); got != nil {
+			t.Errorf("SetMembers(6) = %v, want nil", got)
+		}
+		want := [][]int{{0, 1, 4}, {2}, {3, 5}}
+		if got := dsu.Sets(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Sets() = %v, want %v", got, want)
+		}
+
+		dsu.EnsureSize(8)
+		dsu.Union(7, dsu.MakeSet())
+		if got := dsu.SetMembers(8); !reflect.DeepEqual(got, []int{7, 8}) {
+			t.Errorf("SetMembers(8) = %v, want [7 8]", got)
+		}
+	}
+}
+
+func TestSetMembersRandom(t *testing.T) {
+	const n = 300
+	rng := rand.New(rand.NewSource(1))
+	scan := NewDSU(n)
+	lists := NewDSU(n, WithMemberLists())
+	for i := 0; i < 250; i++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		scan.Union(x, y)
+		lists.Union(x, y)
+
+		x = rng.Intn(n)
+		if got, want := lists.SetMembers(x), scan.SetMembers(x); !reflect.DeepEqual(got, want) {
+			t.Fatalf("SetMembers(%d) = %v, want %v", x, got, want)
+		}
+	}
+	if got, want := lists.Sets(), scan.Sets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sets() = %v, want %v", got, want)
+	}
+}
+
+func TestRangeSets(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithMemberLists()}} {
+		dsu := NewDSU(6, opts...)
+		dsu.Union(4, 1)
+		dsu.Union(0, 4)
+		dsu.Union(3, 5)
+
+		var members []int
+		dsu.RangeSetMembers(1, func(member int) bool {
+			members = append(members, member)
+			return true
+		})
+		sort.Ints(members)
+		if !reflect.DeepEqual(members, []int{0, 1, 4}) {
+			t.Errorf("RangeSetMembers(1) visited %v, want [0 1 4]", members)
+		}
+
+		count := 0
+		dsu.RangeSetMembers(1, func(int) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("RangeSetMembers visited %d elements after stopping at 2", count)
+		}
+		dsu.RangeSetMembers(6, func(int) bool {
+			t.Error("RangeSetMembers(6) called fn for an invalid element")
+			return true
+		})
+
+		var sets [][]int
+		dsu.RangeSets(func(set []int) bool {
+			sets = append(sets, set)
+			return len(sets) < 2
+		})
+		if want := [][]int{{0, 1, 4}, {2}}; !reflect.DeepEqual(sets, want) {
+			t.Errorf("RangeSets visited %v, want %v", sets, want)
+		}
+	}
+}
+
 // Benchmark tests for performance analysis
 func BenchmarkFind(b *testing.B) {
 	dsu := NewDSU(1000)
@@ -352,3 +509,27 @@ func BenchmarkConnected(b *testing.B) {
 		dsu.Connected(x, y)
 	}
 }
+
+func BenchmarkSetMembers(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"Scan", nil},
+		{"MemberLists", []Option{WithMemberLists()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			// Many small sets of four elements
+			dsu := NewDSU(1<<16, bc.opts...)
+			for i := 0; i < 1<<16; i += 4 {
+				dsu.Union(i, i+1)
+				dsu.Union(i, i+2)
+				dsu.Union(i, i+3)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dsu.SetMembers(i & (1<<16 - 1))
+			}
+		})
+	}
+}
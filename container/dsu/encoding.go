@@ -0,0 +1,196 @@
+// Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
+// This file implements parent-array export and binary serialization for DSU.
+
+package dsu
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// dsuMagic identifies the binary encoding of a DSU, followed by a version byte.
+const (
+	dsuMagic   = "BRDSU"
+	dsuVersion = 1
+)
+
+// memberListsFlag is set in the encoding of a DSU created with WithMemberLists.
+const memberListsFlag = 1
+
+var (
+	// ErrInvalidParents is returned by NewDSUFromParents when the parent
+	// array has an out-of-range entry or a cycle.
+	ErrInvalidParents = errors.New("dsu: invalid parent array")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid
+	// DSU encoding.
+	ErrInvalidEncoding = errors.New("dsu: invalid encoding")
+)
+
+// Parents returns the compact form of the DSU: the representative of each
+// element, so that x and y are connected exactly when their entries are
+// equal. Paths are fully compressed as a side effect.
+func (d *DSU) Parents() []int {
+	parents := make([]int, d.size)
+	for i := range parents {
+		parents[i] = d.Find(i)
+	}
+	return parents
+}
+
+// NewDSUFromParents creates a DSU from a parent array, such as one returned
+// by Parents: parents[i] is the parent of element i, and roots are their own
+// parents. The parents need not be roots, but must form a forest; an entry
+// out of range or a cycle yields ErrInvalidParents. An empty array yields an
+// empty DSU.
+func NewDSUFromParents(parents []int, opts ...Option) (Interface, error) {
+	roots, err := resolveRoots(parents)
+	if err != nil {
+		return nil, err
+	}
+	return newDSUFromRoots(roots, opts), nil
+}
+
+// resolveRoots returns the root of each element of the forest described by
+// parents.
+func resolveRoots(parents []int) ([]int, error) {
+	const (
+		unknown  = -1
+		visiting = -2
+	)
+	n := len(parents)
+	roots := make([]int, n)
+	for i := range roots {
+		roots[i] = unknown
+	}
+
+	var path []int
+	for i := range parents {
+		path = path[:0]
+		x := i
+		for roots[x] == unknown {
+			p := parents[x]
+			if p < 0 || p >= n {
+				return nil, fmt.Errorf("%w: parent %d of element %d out of range", ErrInvalidParents, p, x)
+			}
+			roots[x] = visiting
+			path = append(path, x)
+			if p == x {
+				break
+			}
+			x = p
+		}
+
+		root := roots[x]
+		if root == visiting {
+			// Either x is a root reached just now, or the walk came back to x
+			if parents[x] != x {
+				return nil, fmt.Errorf("%w: cycle through element %d", ErrInvalidParents, x)
+			}
+			root = x
+		}
+		for _, y := range path {
+			roots[y] = root
+		}
+	}
+	return roots, nil
+}
+
+// newDSUFromRoots creates a DSU where each element i hangs directly off roots[i].
+func newDSUFromRoots(roots []int, opts []Option) *DSU {
+	n := len(roots)
+	d := &DSU{
+		parent: roots,
+		rank:   make([]int, n),
+		size:   n,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	for i, root := range roots {
+		if root == i {
+			d.components++
+		} else {
+			d.rank[root] = 1
+		}
+	}
+	if d.trackMembers {
+		d.members = make([][]int, n)
+		for i, root := range roots {
+			d.members[root] = append(d.members[root], i)
+		}
+	}
+	return d
+}
+
+// The encoding is the magic and version, a flags byte, the element count as
+// a uvarint, then the representative of each element as a uvarint.
+
+// MarshalBinary returns the binary encoding of the DSU. Paths are fully
+// compressed as a side effect.
+// It implements encoding.BinaryMarshaler.
+func (d *DSU) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(dsuMagic)+2+binary.MaxVarintLen64+2*d.size)
+	data = append(data, dsuMagic...)
+	var flags byte
+	if d.trackMembers {
+		flags |= memberListsFlag
+	}
+	data = append(data, dsuVersion, flags)
+	data = binary.AppendUvarint(data, uint64(d.size))
+	for i := 0; i < d.size; i++ {
+		data = binary.AppendUvarint(data, uint64(d.Find(i)))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the DSU with the decoded data,
+// including whether member lists are maintained.
+// It implements encoding.BinaryUnmarshaler.
+// On error the DSU is left unchanged.
+func (d *DSU) UnmarshalBinary(data []byte) error {
+	if len(data) < len(dsuMagic)+2 || string(data[:len(dsuMagic)]) != dsuMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if v := data[len(dsuMagic)]; v != dsuVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	flags := data[len(dsuMagic)+1]
+	if flags&^memberListsFlag != 0 {
+		return fmt.Errorf("%w: unknown flags %#x", ErrInvalidEncoding, flags)
+	}
+	data = data[len(dsuMagic)+2:]
+
+	n, k := binary.Uvarint(data)
+	// Every element takes at least one byte, which bounds the allocation
+	if k <= 0 || n > uint64(len(data)-k) {
+		return fmt.Errorf("%w: bad element count", ErrInvalidEncoding)
+	}
+	data = data[k:]
+
+	parents := make([]int, n)
+	for i := range parents {
+		p, k := binary.Uvarint(data)
+		if k <= 0 || p >= n {
+			return fmt.Errorf("%w: bad parent of element %d", ErrInvalidEncoding, i)
+		}
+		parents[i] = int(p)
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+
+	roots, err := resolveRoots(parents)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	var opts []Option
+	if flags&memberListsFlag != 0 {
+		opts = append(opts, WithMemberLists())
+	}
+	*d = *newDSUFromRoots(roots, opts)
+	return nil
+}
@@ -0,0 +1,124 @@
+package dsu
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// randomDSU returns a DSU of n elements after random unions.
+func randomDSU(n int, opts ...Option) *DSU {
+	rng := rand.New(rand.NewSource(1))
+	d := NewDSU(n, opts...).(*DSU)
+	for i := 0; i < n/2; i++ {
+		d.Union(rng.Intn(n), rng.Intn(n))
+	}
+	return d
+}
+
+func TestParentsRoundTrip(t *testing.T) {
+	d := randomDSU(100)
+	parents := d.Parents()
+	for i, p := range parents {
+		if parents[p] != p {
+			t.Fatalf("Parents()[%d] = %d, which is not a root", i, p)
+		}
+	}
+
+	restored, err := NewDSUFromParents(parents)
+	if err != nil {
+		t.Fatalf("NewDSUFromParents: %v", err)
+	}
+	if got, want := restored.ComponentCount(), d.ComponentCount(); got != want {
+		t.Errorf("ComponentCount() = %d, want %d", got, want)
+	}
+	if got, want := restored.Sets(), d.Sets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sets() = %v, want %v", got, want)
+	}
+}
+
+func TestNewDSUFromParents(t *testing.T) {
+	// A chain 3 -> 2 -> 0 and a separate root 1
+	d, err := NewDSUFromParents([]int{0, 1, 0, 2}, WithMemberLists())
+	if err != nil {
+		t.Fatalf("NewDSUFromParents: %v", err)
+	}
+	if got := d.ComponentCount(); got != 2 {
+		t.Errorf("ComponentCount() = %d, want 2", got)
+	}
+	if got := d.SetMembers(3); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Errorf("SetMembers(3) = %v, want [0 2 3]", got)
+	}
+	if !d.Union(1, 3) || d.ComponentCount() != 1 {
+		t.Errorf("Union(1, 3) left ComponentCount() = %d, want 1", d.ComponentCount())
+	}
+
+	empty, err := NewDSUFromParents(nil)
+	if err != nil || empty.Size() != 0 {
+		t.Errorf("NewDSUFromParents(nil) = %v, %v, want an empty DSU", empty, err)
+	}
+
+	for _, parents := range [][]int{
+		{0, 2},       // out of range
+		{0, -1},      // negative
+		{1, 0},       // cycle of two
+		{0, 2, 3, 1}, // cycle not through the start
+	} {
+		if _, err := NewDSUFromParents(parents); !errors.Is(err, ErrInvalidParents) {
+			t.Errorf("NewDSUFromParents(%v) error = %v, want ErrInvalidParents", parents, err)
+		}
+	}
+}
+
+func TestDSUBinaryRoundTrip(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithMemberLists()}} {
+		d := randomDSU(200, opts...)
+		data, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+
+		var restored DSU
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary: %v", err)
+		}
+		if restored.trackMembers != d.trackMembers {
+			t.Errorf("trackMembers = %v, want %v", restored.trackMembers, d.trackMembers)
+		}
+		if got, want := restored.Sets(), d.Sets(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Sets() = %v, want %v", got, want)
+		}
+		if got, want := restored.SetMembers(7), d.SetMembers(7); !reflect.DeepEqual(got, want) {
+			t.Errorf("SetMembers(7) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDSUUnmarshalInvalid(t *testing.T) {
+	d := NewDSU(3).(*DSU)
+	d.Union(0, 2)
+	data, _ := d.MarshalBinary()
+	header := len(dsuMagic) + 2
+
+	cases := map[string][]byte{
+		"empty":     nil,
+		"bad magic": append([]byte("XXXXX"), data[5:]...),
+		"version":   append(append([]byte(dsuMagic), 9), data[6:]...),
+		"flags":     append(append([]byte(dsuMagic), dsuVersion, 2), data[7:]...),
+		"count":     append(append([]byte(nil), data[:header]...), 0x7f, 0),
+		"parent":    append(append([]byte(nil), data[:header]...), 2, 0, 2),
+		"cycle":     append(append([]byte(nil), data[:header]...), 2, 1, 0),
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0),
+	}
+	for name, input := range cases {
+		target := NewDSU(5).(*DSU)
+		if err := target.UnmarshalBinary(input); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: expected ErrInvalidEncoding, got %v", name, err)
+		}
+		if target.Size() != 5 || target.ComponentCount() != 5 {
+			t.Errorf("%s: expected DSU to be unchanged", name)
+		}
+	}
+}
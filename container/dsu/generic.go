@@ -0,0 +1,135 @@
+// Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
+// This file implements GenericDSU, a DSU keyed by arbitrary comparable values.
+
+package dsu
+
+// GenericDSU is a Disjoint Set Union over elements of any comparable type,
+// such as string IDs or structs. Elements are added lazily, either with
+// MakeSet or by passing them to Union, so there is no need to map them to
+// dense integer indexes by hand.
+//
+// Internally each element is assigned an index on first sight, and the
+// forest uses path compression and union by rank like DSU.
+type GenericDSU[T comparable] struct {
+	index      map[T]int // index of each element
+	elements   []T       // elements by index
+	parent     []int     // parent[i] is the parent of element i in the tree
+	rank       []int     // rank[i] is the approximate depth of the tree rooted at i
+	components int       // number of disjoint components
+}
+
+// NewGenericDSU creates an empty GenericDSU.
+func NewGenericDSU[T comparable]() *GenericDSU[T] {
+	return &GenericDSU[T]{
+		index: make(map[T]int),
+	}
+}
+
+// MakeSet adds x as a singleton set.
+// Returns true if x was added, false if it was already present.
+func (d *GenericDSU[T]) MakeSet(x T) bool {
+	if _, ok := d.index[x]; ok {
+		return false
+	}
+	d.add(x)
+	return true
+}
+
+// add adds x as a singleton set and returns its index. x must not be present.
+func (d *GenericDSU[T]) add(x T) int {
+	i := len(d.elements)
+	d.index[x] = i
+	d.elements = append(d.elements, x)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.components++
+	return i
+}
+
+// indexOf returns the index of x, adding x as a singleton set if needed.
+func (d *GenericDSU[T]) indexOf(x T) int {
+	if i, ok := d.index[x]; ok {
+		return i
+	}
+	return d.add(x)
+}
+
+// Has returns true if x has been added.
+func (d *GenericDSU[T]) Has(x T) bool {
+	_, ok := d.index[x]
+	return ok
+}
+
+// Find returns the representative of the set containing x.
+// Returns the zero value and false if x has not been added.
+func (d *GenericDSU[T]) Find(x T) (T, bool) {
+	i, ok := d.index[x]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return d.elements[d.find(i)], true
+}
+
+// find returns the root of element i, compressing the path to it.
+func (d *GenericDSU[T]) find(i int) int {
+	root := i
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Path compression: make every node on the path point directly to the root
+	for d.parent[i] != root {
+		d.parent[i], i = root, d.parent[i]
+	}
+	return root
+}
+
+// Union merges the sets containing x and y, adding either element as a
+// singleton set first if it has not been added.
+// Returns true if union was performed (elements were in different sets),
+// false if elements were already in the same set.
+func (d *GenericDSU[T]) Union(x, y T) bool {
+	rootX := d.find(d.indexOf(x))
+	rootY := d.find(d.indexOf(y))
+
+	// Already in the same set
+	if rootX == rootY {
+		return false
+	}
+
+	// Union by rank: attach the tree with smaller rank under the tree with larger rank
+	if d.rank[rootX] < d.rank[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	d.parent[rootY] = rootX
+	if d.rank[rootX] == d.rank[rootY] {
+		d.rank[rootX]++
+	}
+
+	d.components--
+	return true
+}
+
+// Connected returns true if x and y are in the same set.
+// Elements that have not been added are not connected to anything.
+func (d *GenericDSU[T]) Connected(x, y T) bool {
+	i, ok := d.index[x]
+	if !ok {
+		return false
+	}
+	j, ok := d.index[y]
+	if !ok {
+		return false
+	}
+	return d.find(i) == d.find(j)
+}
+
+// ComponentCount returns the current number of disjoint sets (connected components).
+func (d *GenericDSU[T]) ComponentCount() int {
+	return d.components
+}
+
+// Size returns the number of elements added.
+func (d *GenericDSU[T]) Size() int {
+	return len(d.elements)
+}
@@ -0,0 +1,104 @@
+package dsu
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestGenericDSUMakeSet(t *testing.T) {
+	d := NewGenericDSU[string]()
+	if got := d.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+
+	if !d.MakeSet("a") {
+		t.Error("MakeSet(a) = false, want true")
+	}
+	if d.MakeSet("a") {
+		t.Error("MakeSet(a) again = true, want false")
+	}
+	if !d.Has("a") || d.Has("b") {
+		t.Error("Has returned unexpected results")
+	}
+	if root, ok := d.Find("a"); !ok || root != "a" {
+		t.Errorf("Find(a) = %q, %v, want a, true", root, ok)
+	}
+	if root, ok := d.Find("b"); ok || root != "" {
+		t.Errorf("Find(b) = %q, %v, want \"\", false", root, ok)
+	}
+	if d.Has("b") {
+		t.Error("Find added an unknown element")
+	}
+}
+
+func TestGenericDSUUnion(t *testing.T) {
+	d := NewGenericDSU[string]()
+	d.MakeSet("solo")
+
+	// Union adds unknown elements
+	if !d.Union("a", "b") {
+		t.Error("Union(a, b) = false, want true")
+	}
+	if !d.Union("b", "c") {
+		t.Error("Union(b, c) = false, want true")
+	}
+	if d.Union("a", "c") {
+		t.Error("Union(a, c) = true, want false")
+	}
+	if got := d.Size(); got != 4 {
+		t.Errorf("Size() = %d, want 4", got)
+	}
+	if got := d.ComponentCount(); got != 2 {
+		t.Errorf("ComponentCount() = %d, want 2", got)
+	}
+
+	if !d.Connected("a", "c") {
+		t.Error("Connected(a, c) = false, want true")
+	}
+	if d.Connected("a", "solo") || d.Connected("a", "missing") || d.Connected("missing", "missing") {
+		t.Error("Connected returned true for disconnected elements")
+	}
+	rootA, _ := d.Find("a")
+	rootC, _ := d.Find("c")
+	if rootA != rootC {
+		t.Errorf("Find(a) = %q, Find(c) = %q, want equal roots", rootA, rootC)
+	}
+}
+
+func TestGenericDSUStructKeys(t *testing.T) {
+	type cell struct{ row, col int }
+	d := NewGenericDSU[cell]()
+	d.Union(cell{0, 0}, cell{0, 1})
+	d.Union(cell{1, 1}, cell{0, 1})
+	if !d.Connected(cell{0, 0}, cell{1, 1}) {
+		t.Error("Connected({0 0}, {1 1}) = false, want true")
+	}
+	if got := d.ComponentCount(); got != 1 {
+		t.Errorf("ComponentCount() = %d, want 1", got)
+	}
+}
+
+func TestGenericDSUMatchesDSU(t *testing.T) {
+	const n = 200
+	rng := rand.New(rand.NewSource(1))
+	ref := NewDSU(n)
+	d := NewGenericDSU[string]()
+	for i := 0; i < n; i++ {
+		d.MakeSet(strconv.Itoa(i))
+	}
+
+	for i := 0; i < 300; i++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		if got, want := d.Union(strconv.Itoa(x), strconv.Itoa(y)), ref.Union(x, y); got != want {
+			t.Fatalf("Union(%d, %d) = %v, want %v", x, y, got, want)
+		}
+		x, y = rng.Intn(n), rng.Intn(n)
+		if got, want := d.Connected(strconv.Itoa(x), strconv.Itoa(y)), ref.Connected(x, y); got != want {
+			t.Fatalf("Connected(%d, %d) = %v, want %v", x, y, got, want)
+		}
+	}
+	if got, want := d.ComponentCount(), ref.ComponentCount(); got != want {
+		t.Errorf("ComponentCount() = %d, want %d", got, want)
+	}
+}
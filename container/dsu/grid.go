@@ -0,0 +1,156 @@
+// Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
+// This file implements GridDSU for connectivity between the cells of a 2D grid.
+
+package dsu
+
+// Connectivity selects which cells of a grid are neighbors.
+type Connectivity int
+
+const (
+	// Connectivity4 joins cells that share an edge.
+	Connectivity4 Connectivity = 4
+	// Connectivity8 joins cells that share an edge or a corner.
+	Connectivity8 Connectivity = 8
+)
+
+// neighborOffsets lists the row and column offsets of the neighbors of a
+// cell, edges first, so that Connectivity4 uses the first four.
+var neighborOffsets = [8][2]int{
+	{-1, 0}, {0, -1}, {0, 1}, {1, 0},
+	{-1, -1}, {-1, 1}, {1, -1}, {1, 1},
+}
+
+// GridDSU is a Disjoint Set Union over the cells of a rows×cols grid, for
+// problems such as counting islands or checking percolation.
+//
+// Cells are either open or blocked, and start blocked. Opening a cell joins
+// it to its open neighbors, as selected by the grid's connectivity. Only
+// open cells take part in sets: blocked cells are never connected, and
+// ComponentCount counts the components of open cells.
+type GridDSU struct {
+	rows, cols   int
+	connectivity Connectivity
+	dsu          DSU
+	open         []bool // open[r*cols+c] is true if cell (r, c) is open
+	openCount    int    // number of open cells
+}
+
+// NewGridDSU creates a rows×cols grid of blocked cells whose neighbors are
+// selected by connectivity. A connectivity other than Connectivity8 selects
+// Connectivity4. Returns nil if rows <= 0 or cols <= 0.
+func NewGridDSU(rows, cols int, connectivity Connectivity) *GridDSU {
+	if rows <= 0 || cols <= 0 {
+		return nil
+	}
+	if connectivity != Connectivity8 {
+		connectivity = Connectivity4
+	}
+
+	g := &GridDSU{
+		rows:         rows,
+		cols:         cols,
+		connectivity: connectivity,
+		open:         make([]bool, rows*cols),
+	}
+	g.dsu.EnsureSize(rows * cols)
+	return g
+}
+
+// Rows returns the number of rows of the grid.
+func (g *GridDSU) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns of the grid.
+func (g *GridDSU) Cols() int {
+	return g.cols
+}
+
+// index returns the element of cell (r, c), or -1 if the cell is outside the grid.
+func (g *GridDSU) index(r, c int) int {
+	if r < 0 || r >= g.rows || c < 0 || c >= g.cols {
+		return -1
+	}
+	return r*g.cols + c
+}
+
+// openIndex returns the element of cell (r, c), or -1 if the cell is
+// outside the grid or blocked.
+func (g *GridDSU) openIndex(r, c int) int {
+	i := g.index(r, c)
+	if i < 0 || !g.open[i] {
+		return -1
+	}
+	return i
+}
+
+// IsOpen returns true if cell (r, c) is inside the grid and open.
+func (g *GridDSU) IsOpen(r, c int) bool {
+	return g.openIndex(r, c) >= 0
+}
+
+// Open opens cell (r, c) and joins it to its open neighbors.
+// Returns true if the cell was opened, false if it was already open or is
+// outside the grid.
+func (g *GridDSU) Open(r, c int) bool {
+	i := g.index(r, c)
+	if i < 0 || g.open[i] {
+		return false
+	}
+	g.open[i] = true
+	g.openCount++
+	g.UnionNeighbors(r, c)
+	return true
+}
+
+// UnionNeighbors joins open cell (r, c) to each of its open neighbors, as
+// selected by the grid's connectivity. Open does this already; it is
+// useful after Union calls that skipped neighbors. Returns the number of
+// sets merged into the set of the cell.
+func (g *GridDSU) UnionNeighbors(r, c int) int {
+	i := g.openIndex(r, c)
+	if i < 0 {
+		return 0
+	}
+
+	merged := 0
+	for _, off := range neighborOffsets[:g.connectivity] {
+		if j := g.openIndex(r+off[0], c+off[1]); j >= 0 && g.dsu.Union(i, j) {
+			merged++
+		}
+	}
+	return merged
+}
+
+// Union merges the sets containing cells (r1, c1) and (r2, c2), which need
+// not be neighbors. Returns true if union was performed, false if the cells
+// were already in the same set or either is blocked or outside the grid.
+func (g *GridDSU) Union(r1, c1, r2, c2 int) bool {
+	i, j := g.openIndex(r1, c1), g.openIndex(r2, c2)
+	if i < 0 || j < 0 {
+		return false
+	}
+	return g.dsu.Union(i, j)
+}
+
+// Connected returns true if cells (r1, c1) and (r2, c2) are open and in
+// the same set.
+func (g *GridDSU) Connected(r1, c1, r2, c2 int) bool {
+	i, j := g.openIndex(r1, c1), g.openIndex(r2, c2)
+	if i < 0 || j < 0 {
+		return false
+	}
+	return g.dsu.Connected(i, j)
+}
+
+// OpenCount returns the number of open cells.
+func (g *GridDSU) OpenCount() int {
+	return g.openCount
+}
+
+// ComponentCount returns the number of connected components of open cells,
+// such as the number of islands when land cells are open.
+func (g *GridDSU) ComponentCount() int {
+	// Blocked cells remain singleton sets of the underlying DSU
+	return g.dsu.ComponentCount() - (len(g.open) - g.openCount)
+}
@@ -0,0 +1,115 @@
+package dsu
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// openCells returns a grid with the cells marked '#' in rows opened.
+func openCells(rows []string, connectivity Connectivity) *GridDSU {
+	g := NewGridDSU(len(rows), len(rows[0]), connectivity)
+	for r, row := range rows {
+		for c := range row {
+			if row[c] == '#' {
+				g.Open(r, c)
+			}
+		}
+	}
+	return g
+}
+
+func TestGridDSUIslands(t *testing.T) {
+	rows := []string{
+		"##..#",
+		"#..#.",
+		"..#..",
+		"....#",
+	}
+	tests := []struct {
+		connectivity Connectivity
+		want         int
+	}{
+		{Connectivity4, 5},
+		{Connectivity8, 3},
+		{0, 5}, // defaults to Connectivity4
+	}
+	for _, tt := range tests {
+		g := openCells(rows, tt.connectivity)
+		if got := g.ComponentCount(); got != tt.want {
+			t.Errorf("connectivity %d: ComponentCount() = %d, want %d", tt.connectivity, got, tt.want)
+		}
+		if got := g.OpenCount(); got != 7 {
+			t.Errorf("connectivity %d: OpenCount() = %d, want 7", tt.connectivity, got)
+		}
+	}
+
+	g := openCells(rows, Connectivity8)
+	if !g.Connected(0, 4, 2, 2) || g.Connected(0, 0, 0, 4) {
+		t.Error("Connected returned unexpected results with Connectivity8")
+	}
+}
+
+func TestGridDSUBlockedCells(t *testing.T) {
+	if g := NewGridDSU(0, 3, Connectivity4); g != nil {
+		t.Errorf("NewGridDSU(0, 3) = %v, want nil", g)
+	}
+
+	g := NewGridDSU(2, 3, Connectivity4)
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Errorf("Rows(), Cols() = %d, %d, want 2, 3", g.Rows(), g.Cols())
+	}
+	if got := g.ComponentCount(); got != 0 {
+		t.Errorf("ComponentCount() = %d, want 0 with all cells blocked", got)
+	}
+	if g.Union(0, 0, 0, 1) || g.Connected(0, 0, 0, 0) {
+		t.Error("Blocked cells took part in a set")
+	}
+
+	if !g.Open(0, 0) || g.Open(0, 0) || g.Open(2, 0) {
+		t.Error("Open returned unexpected results")
+	}
+	if !g.IsOpen(0, 0) || g.IsOpen(0, 1) || g.IsOpen(-1, 0) {
+		t.Error("IsOpen returned unexpected results")
+	}
+	g.Open(1, 2)
+	if g.Connected(0, 0, 1, 2) {
+		t.Error("Connected(0, 0, 1, 2) = true before any union")
+	}
+	if !g.Union(0, 0, 1, 2) || !g.Connected(0, 0, 1, 2) {
+		t.Error("Union of distant open cells did not connect them")
+	}
+	if got := g.ComponentCount(); got != 1 {
+		t.Errorf("ComponentCount() = %d, want 1", got)
+	}
+	if got := g.UnionNeighbors(0, 1); got != 0 {
+		t.Errorf("UnionNeighbors on a blocked cell = %d, want 0", got)
+	}
+}
+
+func TestGridDSUPercolation(t *testing.T) {
+	// Open random cells until the top row connects to the bottom row
+	const n = 30
+	rng := rand.New(rand.NewSource(1))
+	g := NewGridDSU(n, n, Connectivity4)
+	percolates := func() bool {
+		for top := 0; top < n; top++ {
+			for bottom := 0; bottom < n; bottom++ {
+				if g.Connected(0, top, n-1, bottom) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, cell := range rng.Perm(n * n) {
+		g.Open(cell/n, cell%n)
+		if percolates() {
+			break
+		}
+	}
+	// The site percolation threshold of the square lattice is about 0.593
+	if p := float64(g.OpenCount()) / (n * n); p < 0.45 || p > 0.75 {
+		t.Errorf("Percolated with %.2f of the cells open, want about 0.59", p)
+	}
+}
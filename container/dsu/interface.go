@@ -1,6 +1,3 @@
-//go:build !go1.23
-// +build !go1.23
-
 // Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
 // A Disjoint Set Union maintains a collection of disjoint sets and supports efficient
 // find and union operations with path compression and union by rank optimizations.
@@ -8,8 +5,9 @@
 package dsu
 
 // Interface defines the operations for a Disjoint Set Union data structure.
-// A DSU maintains a collection of disjoint sets of integers from 0 to n-1
-// and provides efficient operations to find set representatives and union sets.
+// A DSU maintains a collection of disjoint sets of integers from 0 to n-1,
+// where n can grow, and provides efficient operations to find set
+// representatives and union sets.
 type Interface interface {
 	// Find returns the representative (root) of the set containing element x.
 	// Uses path compression optimization to flatten the tree structure.
@@ -28,13 +26,51 @@ type Interface interface {
 	// Time complexity: O(α(n)) amortized.
 	Connected(x, y int) bool
 
+	// SetMembers returns the elements of the set containing x in ascending
+	// order, or nil if x is not an element.
+	// Time complexity: O(n α(n)), or O(k log k) for a set of k elements
+	// when created with WithMemberLists.
+	SetMembers(x int) []int
+
+	// Sets returns every set as a slice of its elements in ascending order,
+	// with the sets ordered by their smallest element.
+	// Time complexity: O(n α(n)).
+	Sets() [][]int
+
+	// Parents returns the representative of each element, a compact form of
+	// the DSU that NewDSUFromParents restores.
+	// Time complexity: O(n α(n)).
+	Parents() []int
+
+	// RangeSetMembers calls fn for each element of the set containing x
+	// until fn returns false.
+	// Time complexity: O(n α(n)), or O(k) for a set of k elements when
+	// created with WithMemberLists.
+	RangeSetMembers(x int, fn func(member int) bool)
+
+	// RangeSets calls fn for each set, in the order and form of Sets, until
+	// fn returns false.
+	// Time complexity: O(n α(n)).
+	RangeSets(fn func(set []int) bool)
+
 	// ComponentCount returns the number of disjoint sets (connected components).
 	// Initially equals n, decreases by 1 with each successful union operation.
 	// Time complexity: O(1).
 	ComponentCount() int
 
 	// Size returns the total number of elements in the DSU.
-	// This is the n value used during initialization.
+	// This is the n value used during initialization, plus the elements
+	// added since.
 	// Time complexity: O(1).
 	Size() int
+
+	// MakeSet adds a new element as a singleton set and returns it.
+	// The new element is the previous Size().
+	// Time complexity: O(1) amortized.
+	MakeSet() int
+
+	// EnsureSize grows the DSU to at least n elements, adding each missing
+	// element as a singleton set.
+	// Time complexity: O(n - Size()) amortized.
+	EnsureSize(n int)
 }
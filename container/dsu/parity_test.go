@@ -0,0 +1,106 @@
+package dsu
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParityDSUBasic(t *testing.T) {
+	if d := NewParityDSU(0); d != nil {
+		t.Errorf("NewParityDSU(0) = %v, want nil", d)
+	}
+
+	// 0 and 1 are enemies, 1 and 2 are enemies, so 0 and 2 are friends
+	d := NewParityDSU(5)
+	if !d.Union(0, 1, false) || !d.Union(1, 2, false) {
+		t.Error("Union returned false for consistent relations")
+	}
+	if same, known := d.SameSide(0, 2); !same || !known {
+		t.Errorf("SameSide(0, 2) = %v, %v, want true, true", same, known)
+	}
+	if same, known := d.SameSide(0, 1); same || !known {
+		t.Errorf("SameSide(0, 1) = %v, %v, want false, true", same, known)
+	}
+	if _, known := d.SameSide(0, 3); known {
+		t.Error("SameSide(0, 3) is known for disconnected elements")
+	}
+	if _, known := d.SameSide(0, 5); known {
+		t.Error("SameSide(0, 5) is known for an invalid element")
+	}
+
+	if !d.Union(2, 0, true) {
+		t.Error("Union(2, 0, true) = false, want true for an implied relation")
+	}
+	if d.Union(0, 2, false) {
+		t.Error("Union(0, 2, false) = true, want false for a contradiction")
+	}
+	if same, _ := d.SameSide(0, 2); !same {
+		t.Error("Rejected Union changed SameSide(0, 2)")
+	}
+
+	d.Union(3, 4, true)
+	if got := d.ComponentCount(); got != 2 {
+		t.Errorf("ComponentCount() = %d, want 2", got)
+	}
+	if !d.Connected(3, 4) || d.Connected(2, 3) || d.Find(-1) != -1 || d.Size() != 5 {
+		t.Error("Connected, Find or Size returned unexpected results")
+	}
+}
+
+func TestParityDSUBipartite(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		edges     [][2]int
+		bipartite bool
+	}{
+		{"even cycle", 4, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}}, true},
+		{"odd cycle", 5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}}, false},
+		{"tree", 5, [][2]int{{0, 1}, {0, 2}, {2, 3}, {2, 4}}, true},
+		{"self loop", 2, [][2]int{{0, 1}, {1, 1}}, false},
+	}
+	for _, tt := range tests {
+		d := NewParityDSU(tt.n)
+		bipartite := true
+		for _, e := range tt.edges {
+			if !d.Union(e[0], e[1], false) {
+				bipartite = false
+			}
+		}
+		if bipartite != tt.bipartite {
+			t.Errorf("%s: bipartite = %v, want %v", tt.name, bipartite, tt.bipartite)
+		}
+	}
+}
+
+func TestParityDSURandom(t *testing.T) {
+	const n = 100
+	rng := rand.New(rand.NewSource(1))
+	side := make([]bool, n)
+	for i := range side {
+		side[i] = rng.Intn(2) == 0
+	}
+
+	d := NewParityDSU(n)
+	for i := 0; i < 300; i++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		truth := side[x] == side[y]
+		if d.Connected(x, y) && x != y && rng.Intn(3) == 0 {
+			if d.Union(x, y, !truth) {
+				t.Fatalf("Union(%d, %d) accepted a false relation", x, y)
+			}
+			continue
+		}
+		if !d.Union(x, y, truth) {
+			t.Fatalf("Union(%d, %d) rejected a true relation", x, y)
+		}
+	}
+
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			if same, known := d.SameSide(x, y); known && same != (side[x] == side[y]) {
+				t.Fatalf("SameSide(%d, %d) = %v, want %v", x, y, same, side[x] == side[y])
+			}
+		}
+	}
+}
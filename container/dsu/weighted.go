@@ -0,0 +1,133 @@
+// Package dsu provides a Disjoint Set Union (Union-Find) data structure implementation.
+// This file implements WeightedDSU, a DSU that tracks differences between elements.
+
+package dsu
+
+// Number is a constraint that permits signed integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// WeightedDSU is a Disjoint Set Union whose elements carry unknown values
+// related by known differences. Union(x, y, w) records that
+// value(y) - value(x) = w, and Diff derives the difference between any two
+// connected elements. Relations that contradict earlier ones are detected
+// and rejected, which suits constraint systems such as consistency checks
+// on exchange rates (using logarithms) or parity problems (using 0 and 1
+// modulo 2).
+//
+// Each element stores its difference to its parent, and Find folds these
+// into differences to the root while compressing paths.
+type WeightedDSU[W Number] struct {
+	parent     []int // parent[i] is the parent of element i in the tree
+	rank       []int // rank[i] is the approximate depth of the tree rooted at i
+	diff       []W   // diff[i] is value(i) - value(parent[i])
+	components int   // number of disjoint components
+}
+
+// NewWeightedDSU creates a new WeightedDSU with n elements (0 to n-1), each
+// in its own set. Returns nil if n <= 0.
+func NewWeightedDSU[W Number](n int) *WeightedDSU[W] {
+	if n <= 0 {
+		return nil
+	}
+
+	d := &WeightedDSU[W]{
+		parent:     make([]int, n),
+		rank:       make([]int, n),
+		diff:       make([]W, n),
+		components: n,
+	}
+	for i := range d.parent {
+		d.parent[i] = i
+	}
+	return d
+}
+
+// valid returns true if x is an element of the DSU.
+func (d *WeightedDSU[W]) valid(x int) bool {
+	return x >= 0 && x < len(d.parent)
+}
+
+// Find returns the representative (root) of the set containing element x,
+// or -1 if x is not an element.
+func (d *WeightedDSU[W]) Find(x int) int {
+	if !d.valid(x) {
+		return -1
+	}
+	return d.find(x)
+}
+
+// find returns the root of x, compressing the path so that afterwards
+// diff[x] is value(x) - value(root).
+func (d *WeightedDSU[W]) find(x int) int {
+	p := d.parent[x]
+	if p == x {
+		return x
+	}
+	root := d.find(p)
+	d.diff[x] += d.diff[p]
+	d.parent[x] = root
+	return root
+}
+
+// Union records that value(y) - value(x) = w, merging the sets of x and y
+// if needed. Returns true if the relation was recorded or is already
+// implied, and false, changing nothing, if it contradicts the known
+// relations or an element is invalid. Floating-point differences are
+// compared exactly, so rounding may report a contradiction.
+func (d *WeightedDSU[W]) Union(x, y int, w W) bool {
+	if !d.valid(x) || !d.valid(y) {
+		return false
+	}
+
+	rootX, rootY := d.find(x), d.find(y)
+	if rootX == rootY {
+		return d.diff[y]-d.diff[x] == w
+	}
+
+	// value(rootY) - value(rootX), from value(y) - value(x) = w
+	delta := w + d.diff[x] - d.diff[y]
+
+	// Union by rank: attach the tree with smaller rank under the tree with larger rank
+	if d.rank[rootX] < d.rank[rootY] {
+		d.parent[rootX] = rootY
+		d.diff[rootX] = -delta
+	} else {
+		d.parent[rootY] = rootX
+		d.diff[rootY] = delta
+		if d.rank[rootX] == d.rank[rootY] {
+			d.rank[rootX]++
+		}
+	}
+
+	d.components--
+	return true
+}
+
+// Diff returns value(y) - value(x) as implied by the recorded relations.
+// Returns false if x and y are not connected or an element is invalid.
+func (d *WeightedDSU[W]) Diff(x, y int) (W, bool) {
+	if !d.valid(x) || !d.valid(y) || d.find(x) != d.find(y) {
+		return 0, false
+	}
+	return d.diff[y] - d.diff[x], true
+}
+
+// Connected returns true if elements x and y are in the same set.
+func (d *WeightedDSU[W]) Connected(x, y int) bool {
+	if !d.valid(x) || !d.valid(y) {
+		return false
+	}
+	return d.find(x) == d.find(y)
+}
+
+// ComponentCount returns the current number of disjoint sets (connected components).
+func (d *WeightedDSU[W]) ComponentCount() int {
+	return d.components
+}
+
+// Size returns the total number of elements in the DSU.
+func (d *WeightedDSU[W]) Size() int {
+	return len(d.parent)
+}
@@ -0,0 +1,111 @@
+package dsu
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedDSUBasic(t *testing.T) {
+	if d := NewWeightedDSU[int](0); d != nil {
+		t.Errorf("NewWeightedDSU(0) = %v, want nil", d)
+	}
+
+	d := NewWeightedDSU[int](5)
+	if !d.Union(0, 1, 3) { // v1 = v0 + 3
+		t.Error("Union(0, 1, 3) = false, want true")
+	}
+	if !d.Union(1, 2, -1) { // v2 = v1 - 1
+		t.Error("Union(1, 2, -1) = false, want true")
+	}
+	if got := d.ComponentCount(); got != 3 {
+		t.Errorf("ComponentCount() = %d, want 3", got)
+	}
+
+	tests := []struct {
+		x, y int
+		want int
+		ok   bool
+	}{
+		{0, 2, 2, true},
+		{2, 0, -2, true},
+		{1, 1, 0, true},
+		{0, 3, 0, false},
+		{0, 9, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := d.Diff(tt.x, tt.y); got != tt.want || ok != tt.ok {
+			t.Errorf("Diff(%d, %d) = %d, %v, want %d, %v", tt.x, tt.y, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Implied relations are accepted, contradictions rejected
+	if !d.Union(2, 0, -2) {
+		t.Error("Union(2, 0, -2) = false, want true for an implied relation")
+	}
+	if d.Union(0, 2, 5) {
+		t.Error("Union(0, 2, 5) = true, want false for a contradiction")
+	}
+	if d.Union(0, 7, 1) || d.Connected(0, 7) {
+		t.Error("Union with an invalid element succeeded")
+	}
+	if got, _ := d.Diff(0, 2); got != 2 {
+		t.Errorf("Diff(0, 2) = %d after rejected Union, want 2", got)
+	}
+	if got := d.Size(); got != 5 {
+		t.Errorf("Size() = %d, want 5", got)
+	}
+	if got := d.Find(-1); got != -1 {
+		t.Errorf("Find(-1) = %d, want -1", got)
+	}
+}
+
+func TestWeightedDSUParity(t *testing.T) {
+	// Bipartiteness of an odd cycle: edges mean "different sides", difference 1 mod 2
+	d := NewWeightedDSU[int](3)
+	d.Union(0, 1, 1)
+	d.Union(1, 2, 1)
+	diff, _ := d.Diff(0, 2)
+	if diff%2 != 0 {
+		t.Errorf("Diff(0, 2) = %d, want even", diff)
+	}
+}
+
+func TestWeightedDSURandom(t *testing.T) {
+	const n = 100
+	rng := rand.New(rand.NewSource(1))
+	values := make([]int, n)
+	for i := range values {
+		values[i] = rng.Intn(1000)
+	}
+
+	d := NewWeightedDSU[int](n)
+	for i := 0; i < 500; i++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		if rng.Intn(4) == 0 && d.Connected(x, y) {
+			if d.Union(x, y, values[y]-values[x]+1) {
+				t.Fatalf("Union(%d, %d) accepted a false difference", x, y)
+			}
+			continue
+		}
+		if !d.Union(x, y, values[y]-values[x]) {
+			t.Fatalf("Union(%d, %d) rejected a true difference", x, y)
+		}
+	}
+
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			if got, ok := d.Diff(x, y); ok && got != values[y]-values[x] {
+				t.Fatalf("Diff(%d, %d) = %d, want %d", x, y, got, values[y]-values[x])
+			}
+		}
+	}
+}
+
+func TestWeightedDSUFloat(t *testing.T) {
+	d := NewWeightedDSU[float64](3)
+	d.Union(0, 1, 0.5)
+	d.Union(1, 2, 0.25)
+	if got, ok := d.Diff(0, 2); !ok || got != 0.75 {
+		t.Errorf("Diff(0, 2) = %v, %v, want 0.75, true", got, ok)
+	}
+}
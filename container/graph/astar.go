@@ -0,0 +1,47 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements A* search.
+
+package graph
+
+// AStar returns a shortest path from start to goal, including both ends,
+// and its length, using the edge payloads as weights, which must not be
+// negative. Returns false if goal is not reachable from start.
+//
+// heuristic estimates the remaining distance from a node to goal and
+// steers the search towards it, so far fewer nodes are explored than by
+// ShortestPath on large graphs such as grids. The path is shortest when the
+// heuristic never overestimates, as the straight-line or Manhattan distance
+// on a grid does; a nil heuristic makes AStar behave like ShortestPath.
+func AStar[N comparable, W Weight](g *Graph[N, W], start, goal N, heuristic func(N) float64) ([]N, W, bool) {
+	if !g.HasNode(start) || !g.HasNode(goal) {
+		return nil, 0, false
+	}
+	if heuristic == nil {
+		heuristic = func(N) float64 { return 0 }
+	}
+
+	dist := map[N]W{start: 0}
+	pred := make(map[N]N)
+	queue := newIndexedHeap[N, float64]()
+	queue.push(start, heuristic(start))
+	for queue.Len() > 0 {
+		n, _ := queue.pop()
+		if n == goal {
+			return Path(pred, start, goal), dist[goal], true
+		}
+
+		d := dist[n]
+		g.RangeNeighbors(n, func(m N, w W) bool {
+			// A node is queued again if a shorter path to it turns up after
+			// it was expanded, which only happens for inconsistent heuristics
+			if old, seen := dist[m]; !seen || d+w < old {
+				dist[m] = d + w
+				pred[m] = n
+				queue.push(m, float64(d+w)+heuristic(m))
+			}
+			return true
+		})
+	}
+	return nil, 0, false
+}
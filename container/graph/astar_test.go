@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// cell is a position on a grid.
+type cell struct{ r, c int }
+
+// gridGraph returns the undirected 4-connected graph of the '.' cells of rows.
+func gridGraph(rows []string) *Graph[cell, int] {
+	g := NewGraph[cell, int](false)
+	for r, row := range rows {
+		for c := range row {
+			if row[c] != '.' {
+				continue
+			}
+			g.AddNode(cell{r, c})
+			if r > 0 && rows[r-1][c] == '.' {
+				g.AddEdge(cell{r - 1, c}, cell{r, c}, 1)
+			}
+			if c > 0 && row[c-1] == '.' {
+				g.AddEdge(cell{r, c - 1}, cell{r, c}, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestAStarGrid(t *testing.T) {
+	g := gridGraph([]string{
+		".....",
+		".###.",
+		"...#.",
+		".#.#.",
+		".#...",
+	})
+	goal := cell{4, 4}
+	manhattan := func(n cell) float64 {
+		return float64(max(goal.r-n.r, n.r-goal.r) + max(goal.c-n.c, n.c-goal.c))
+	}
+
+	path, cost, ok := AStar(g, cell{2, 0}, goal, manhattan)
+	if !ok || cost != 6 || len(path) != 7 {
+		t.Fatalf("Expected a path of cost 6, got %v of cost %d (ok=%v)", path, cost, ok)
+	}
+	if path[0] != (cell{2, 0}) || path[len(path)-1] != goal {
+		t.Errorf("Expected the path to run from the start to the goal, got %v", path)
+	}
+	for i := 1; i < len(path); i++ {
+		if !g.HasEdge(path[i-1], path[i]) {
+			t.Fatalf("Path %v uses a missing edge", path)
+		}
+	}
+
+	if _, _, ok := AStar(g, cell{0, 0}, cell{1, 1}, manhattan); ok {
+		t.Error("Expected no path to a wall cell")
+	}
+	if path, cost, ok := AStar(g, goal, goal, manhattan); !ok || cost != 0 || !reflect.DeepEqual(path, []cell{goal}) {
+		t.Errorf("Expected a path of just the goal, got %v of cost %d (ok=%v)", path, cost, ok)
+	}
+}
+
+func TestAStarUnreachable(t *testing.T) {
+	g := NewGraph[int, int](true)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(2, 1, 1)
+	if _, _, ok := AStar(g, 0, 2, nil); ok {
+		t.Error("Expected no path against the edge direction")
+	}
+}
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 30; trial++ {
+		g := randomGraph(rng, trial%2 == 0, 30, 90, 10)
+		source, target := rng.Intn(30), rng.Intn(30)
+		dist, _ := ShortestPaths(g, target)
+
+		// Half the exact remaining distance is admissible (for undirected graphs)
+		heuristics := map[string]func(int) float64{"nil": nil}
+		if !g.Directed() {
+			heuristics["half"] = func(n int) float64 { return float64(dist[n]) / 2 }
+		}
+		_, want, wantOK := ShortestPath(g, source, target)
+		for name, h := range heuristics {
+			path, cost, ok := AStar(g, source, target, h)
+			if ok != wantOK || cost != want {
+				t.Fatalf("trial %d, %s heuristic: got cost %d (ok=%v), want %d (ok=%v)", trial, name, cost, ok, want, wantOK)
+			}
+			total := 0
+			for i := 1; i < len(path); i++ {
+				w, _ := g.Edge(path[i-1], path[i])
+				total += w
+			}
+			if total != cost {
+				t.Fatalf("trial %d, %s heuristic: path %v has length %d, want %d", trial, name, path, total, cost)
+			}
+		}
+	}
+}
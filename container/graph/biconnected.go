@@ -0,0 +1,132 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements bridges, articulation points and biconnected
+// components using Tarjan's low-link values.
+
+package graph
+
+// lowLink holds the results of one depth-first pass over an undirected graph.
+type lowLink[N comparable, E any] struct {
+	bridges      []Edge[N, E]
+	articulation map[N]bool
+	components   [][]Edge[N, E]
+}
+
+// Bridges returns the edges of an undirected graph whose removal would
+// disconnect their two ends, so a network has no redundant route across
+// them. Each bridge is reported once, with From being the end first reached
+// by a depth-first search from the earliest added node of its component.
+// Returns nil for a directed graph.
+func (g *Graph[N, E]) Bridges() []Edge[N, E] {
+	if g.directed {
+		return nil
+	}
+	return g.lowLink().bridges
+}
+
+// ArticulationPoints returns, in insertion order, the nodes of an undirected
+// graph whose removal would split their connected component, that is the
+// single points of failure of a network. Returns nil for a directed graph.
+func (g *Graph[N, E]) ArticulationPoints() []N {
+	if g.directed {
+		return nil
+	}
+	articulation := g.lowLink().articulation
+	var nodes []N
+	for _, n := range g.nodes {
+		if articulation[n] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// BiconnectedComponents partitions the edges of an undirected graph into
+// its biconnected components: maximal groups of edges in which any two lie
+// on a common simple cycle, so no single node removal disconnects them. A
+// bridge forms a component on its own; nodes are shared between components
+// exactly at articulation points. Self-loops belong to no component, and
+// isolated nodes have no edges to report. Returns nil for a directed graph.
+func (g *Graph[N, E]) BiconnectedComponents() [][]Edge[N, E] {
+	if g.directed {
+		return nil
+	}
+	return g.lowLink().components
+}
+
+// lowLink runs an iterative depth-first search from every unvisited node in
+// insertion order, computing the discovery time of each node and the
+// earliest discovery time reachable from its subtree through one back edge.
+func (g *Graph[N, E]) lowLink() lowLink[N, E] {
+	result := lowLink[N, E]{articulation: make(map[N]bool)}
+	disc := make(map[N]int, len(g.nodes))
+	low := make(map[N]int, len(g.nodes))
+	var edges []Edge[N, E] // edges of the components still being explored
+
+	// frame is a node on the current path and its next neighbor to explore
+	type frame struct {
+		node, parent N
+		next         int
+	}
+	for _, root := range g.nodes {
+		if _, seen := disc[root]; seen {
+			continue
+		}
+		disc[root], low[root] = len(disc), len(disc)
+		rootChildren := 0
+		stack := []frame{{node: root}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			n := top.node
+			adj := g.out[n]
+			if top.next < len(adj.order) {
+				m := adj.order[top.next]
+				top.next++
+				if m == n || (len(stack) > 1 && m == top.parent) {
+					continue // self-loop, or the tree edge just followed
+				}
+				if d, seen := disc[m]; seen {
+					if d < disc[n] { // back edge, seen once from its lower end
+						low[n] = min(low[n], d)
+						edges = append(edges, Edge[N, E]{From: n, To: m, Payload: adj.payload[m]})
+					}
+					continue
+				}
+				disc[m], low[m] = len(disc), len(disc)
+				edges = append(edges, Edge[N, E]{From: n, To: m, Payload: adj.payload[m]})
+				if n == root {
+					rootChildren++
+				}
+				stack = append(stack, frame{node: m, parent: n})
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			p := top.parent
+			low[p] = min(low[p], low[n])
+			if low[n] > disc[p] {
+				result.bridges = append(result.bridges, Edge[N, E]{From: p, To: n, Payload: g.out[p].payload[n]})
+			}
+			if low[n] >= disc[p] {
+				// Nothing below n reaches above p, so the edges explored
+				// since the tree edge p-n form a component
+				if p != root {
+					result.articulation[p] = true
+				}
+				i := len(edges) - 1
+				for edges[i].From != p || edges[i].To != n {
+					i--
+				}
+				result.components = append(result.components, append([]Edge[N, E](nil), edges[i:]...))
+				edges = edges[:i]
+			}
+		}
+		if rootChildren > 1 {
+			result.articulation[root] = true
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// bowtieGraph returns the undirected graph of two triangles 1-2-3 and
+// 3-4-5 sharing node 3, with a tail 5-6-7, a self-loop on 7 and an
+// isolated node 8.
+func bowtieGraph() *Graph[int, int] {
+	g := NewGraph[int, int](false)
+	for i, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}, {5, 6}, {6, 7}, {7, 7}} {
+		g.AddEdge(e[0], e[1], i)
+	}
+	g.AddNode(8)
+	return g
+}
+
+// componentCount returns the number of connected components of g without
+// the node skip and the edge between a and b.
+func componentCount(g *Graph[int, int], skip, a, b int) int {
+	visited := make(map[int]bool)
+	count := 0
+	for _, n := range g.Nodes() {
+		if n == skip || visited[n] {
+			continue
+		}
+		count++
+		visited[n] = true
+		stack := []int{n}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range g.Neighbors(u) {
+				if v == skip || visited[v] || (u == a && v == b) || (u == b && v == a) {
+					continue
+				}
+				visited[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return count
+}
+
+func TestBridgesAndArticulationPoints(t *testing.T) {
+	g := bowtieGraph()
+
+	want := []Edge[int, int]{{From: 6, To: 7, Payload: 7}, {From: 5, To: 6, Payload: 6}}
+	if got := g.Bridges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Bridges() = %v, want %v", got, want)
+	}
+	if got := g.ArticulationPoints(); !reflect.DeepEqual(got, []int{3, 5, 6}) {
+		t.Errorf("ArticulationPoints() = %v, want [3 5 6]", got)
+	}
+
+	components := g.BiconnectedComponents()
+	if len(components) != 4 {
+		t.Fatalf("Expected 4 biconnected components, got %v", components)
+	}
+	sizes := make([]int, len(components))
+	for i, c := range components {
+		sizes[i] = len(c)
+	}
+	if !reflect.DeepEqual(sizes, []int{1, 1, 3, 3}) {
+		t.Errorf("Expected component sizes [1 1 3 3], got %v", sizes)
+	}
+}
+
+func TestBiconnectedDirected(t *testing.T) {
+	g := NewGraph[int, int](true)
+	g.AddEdge(1, 2, 0)
+	if g.Bridges() != nil || g.ArticulationPoints() != nil || g.BiconnectedComponents() != nil {
+		t.Error("Expected nil results for a directed graph")
+	}
+}
+
+func TestBiconnectedRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		g := randomGraph(rng, false, 15, 10+trial%20, 5)
+		base := componentCount(g, -1, -1, -1)
+
+		bridges := make(map[[2]int]bool)
+		for _, e := range g.Bridges() {
+			bridges[[2]int{e.From, e.To}] = true
+			bridges[[2]int{e.To, e.From}] = true
+		}
+		for _, e := range g.Edges() {
+			want := e.From != e.To && componentCount(g, -1, e.From, e.To) > base
+			if bridges[[2]int{e.From, e.To}] != want {
+				t.Fatalf("trial %d: edge %d-%d bridge = %v, want %v", trial, e.From, e.To, !want, want)
+			}
+		}
+
+		articulation := make(map[int]bool)
+		for _, n := range g.ArticulationPoints() {
+			articulation[n] = true
+		}
+		for _, n := range g.Nodes() {
+			isolated := g.Degree(n) == 0 || (g.Degree(n) == 1 && g.HasEdge(n, n))
+			want := !isolated && componentCount(g, n, -1, -1) > base
+			if articulation[n] != want {
+				t.Fatalf("trial %d: node %d articulation = %v, want %v", trial, n, articulation[n], want)
+			}
+		}
+
+		// Components partition the edges, and single-edge components are bridges
+		seen := make(map[[2]int]bool)
+		for _, c := range g.BiconnectedComponents() {
+			if len(c) == 1 && !bridges[[2]int{c[0].From, c[0].To}] {
+				t.Fatalf("trial %d: single-edge component %v is not a bridge", trial, c)
+			}
+			for _, e := range c {
+				key := [2]int{min(e.From, e.To), max(e.From, e.To)}
+				if seen[key] {
+					t.Fatalf("trial %d: edge %v is in two components", trial, e)
+				}
+				seen[key] = true
+			}
+		}
+		for _, e := range g.Edges() {
+			if e.From != e.To && !seen[[2]int{min(e.From, e.To), max(e.From, e.To)}] {
+				t.Fatalf("trial %d: edge %v is in no component", trial, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements export to the Graphviz DOT language.
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dotEscaper escapes text for a double-quoted DOT string.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteDOT writes the graph to w in the Graphviz DOT language, for
+// rendering with tools such as dot. Every node is written, in insertion
+// order, followed by the edges in the order of RangeEdges. Nodes are named
+// and edges labeled by their default fmt formatting; edges carrying
+// struct{} are left unlabeled.
+func (g *Graph[N, E]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	quote := func(v any) string {
+		return `"` + dotEscaper.Replace(fmt.Sprint(v)) + `"`
+	}
+
+	kind, arrow := "graph", "--"
+	if g.directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s {\n", kind) // the first error is reported by Flush
+	for _, n := range g.nodes {
+		fmt.Fprintf(bw, "\t%s;\n", quote(n))
+	}
+	g.RangeEdges(func(e Edge[N, E]) bool {
+		fmt.Fprintf(bw, "\t%s %s %s", quote(e.From), arrow, quote(e.To))
+		if _, empty := any(e.Payload).(struct{}); !empty {
+			fmt.Fprintf(bw, " [label=%s]", quote(e.Payload))
+		}
+		bw.WriteString(";\n")
+		return true
+	})
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+func TestNewGraphFromEdges(t *testing.T) {
+	g := NewGraphFromEdges(true, []pair.Pair[string, string]{
+		{First: "b", Second: "a"},
+		{First: "a", Second: "c"},
+		{First: "b", Second: "a"},
+	})
+	if !g.Directed() || g.EdgeCount() != 2 {
+		t.Errorf("Expected a directed graph with 2 edges, got %d edges", g.EdgeCount())
+	}
+	if !reflect.DeepEqual(g.Nodes(), []string{"b", "a", "c"}) {
+		t.Errorf("Expected nodes [b a c], got %v", g.Nodes())
+	}
+	if !g.HasEdge("a", "c") || g.HasEdge("c", "a") {
+		t.Error("Expected only the edge a->c between a and c")
+	}
+
+	u := NewGraphFromEdges(false, []pair.Pair[int, int]{{First: 1, Second: 2}, {First: 2, Second: 1}})
+	if u.Directed() || u.EdgeCount() != 1 || !u.HasEdge(2, 1) {
+		t.Error("Expected an undirected graph with the single edge 1-2")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := NewGraph[string, int](true)
+	g.AddEdge("a", `say "hi"`, 3)
+	g.AddNode("lone")
+	var sb strings.Builder
+	if err := g.WriteDOT(&sb); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	want := "digraph {\n" +
+		"\t\"a\";\n" +
+		"\t\"say \\\"hi\\\"\";\n" +
+		"\t\"lone\";\n" +
+		"\t\"a\" -> \"say \\\"hi\\\"\" [label=\"3\"];\n" +
+		"}\n"
+	if sb.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, sb.String())
+	}
+
+	u := NewGraphFromEdges(false, []pair.Pair[int, int]{{First: 1, Second: 2}})
+	sb.Reset()
+	if err := u.WriteDOT(&sb); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	want = "graph {\n\t\"1\";\n\t\"2\";\n\t\"1\" -- \"2\";\n}\n"
+	if sb.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, sb.String())
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWriteDOTError(t *testing.T) {
+	g := NewGraphFromEdges(true, []pair.Pair[int, int]{{First: 1, Second: 2}})
+	if err := g.WriteDOT(failingWriter{}); err == nil {
+		t.Error("Expected the write error to be returned")
+	}
+}
@@ -0,0 +1,312 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+package graph
+
+import (
+	"github.com/feepwang/br/container/pair"
+)
+
+// Edge is an edge of a graph with its payload, such as a weight.
+type Edge[N comparable, E any] struct {
+	From    N
+	To      N
+	Payload E
+}
+
+// adjacency holds the edges leaving a node, in insertion order.
+type adjacency[N comparable, E any] struct {
+	order   []N     // neighbors in insertion order
+	payload map[N]E // payload of the edge to each neighbor
+}
+
+func newAdjacency[N comparable, E any]() *adjacency[N, E] {
+	return &adjacency[N, E]{payload: make(map[N]E)}
+}
+
+// set adds or updates the edge to n. Returns true if the edge is new.
+func (a *adjacency[N, E]) set(n N, payload E) bool {
+	_, exists := a.payload[n]
+	a.payload[n] = payload
+	if !exists {
+		a.order = append(a.order, n)
+	}
+	return !exists
+}
+
+// remove removes the edge to n. Returns true if it existed.
+func (a *adjacency[N, E]) remove(n N) bool {
+	if _, exists := a.payload[n]; !exists {
+		return false
+	}
+	delete(a.payload, n)
+	for i, m := range a.order {
+		if m == n {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Graph is a directed or undirected graph over nodes of type N, whose edges
+// carry a payload of type E, such as a weight or a label. Use struct{} for
+// E when edges carry nothing.
+//
+// Each node keeps its edges in an adjacency list, so iterating the
+// neighbors of a node is O(degree) and looking up an edge is O(1). There is
+// at most one edge from one node to another; adding it again replaces its
+// payload. Nodes and neighbors are reported in insertion order, so results
+// are deterministic.
+//
+// In an undirected graph every edge joins its two ends both ways and is
+// counted and reported once.
+type Graph[N comparable, E any] struct {
+	directed bool
+	nodes    []N                    // nodes in insertion order
+	index    map[N]int              // position of each node in nodes
+	out      map[N]*adjacency[N, E] // edges leaving each node
+	in       map[N]*adjacency[N, E] // edges entering each node, directed graphs only
+	edges    int                    // number of edges
+}
+
+// NewGraph creates an empty graph, directed if directed is true.
+func NewGraph[N comparable, E any](directed bool) *Graph[N, E] {
+	g := &Graph[N, E]{
+		directed: directed,
+		index:    make(map[N]int),
+		out:      make(map[N]*adjacency[N, E]),
+	}
+	if directed {
+		g.in = make(map[N]*adjacency[N, E])
+	}
+	return g
+}
+
+// NewGraphFromEdges creates a graph, directed if directed is true, with an
+// edge from First to Second for each pair, adding the nodes in the order
+// they first appear. Repeated pairs add a single edge.
+func NewGraphFromEdges[N comparable](directed bool, edges []pair.Pair[N, N]) *Graph[N, struct{}] {
+	g := NewGraph[N, struct{}](directed)
+	for _, e := range edges {
+		g.AddEdge(e.First, e.Second, struct{}{})
+	}
+	return g
+}
+
+// Directed returns true if the graph is directed.
+func (g *Graph[N, E]) Directed() bool {
+	return g.directed
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph[N, E]) NodeCount() int {
+	return len(g.nodes)
+}
+
+// EdgeCount returns the number of edges. Each edge of an undirected graph
+// counts once.
+func (g *Graph[N, E]) EdgeCount() int {
+	return g.edges
+}
+
+// AddNode adds n to the graph.
+// Returns true if n was added, false if it was already present.
+func (g *Graph[N, E]) AddNode(n N) bool {
+	if _, exists := g.index[n]; exists {
+		return false
+	}
+	g.index[n] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+	g.out[n] = newAdjacency[N, E]()
+	if g.directed {
+		g.in[n] = newAdjacency[N, E]()
+	}
+	return true
+}
+
+// HasNode returns true if n is in the graph.
+func (g *Graph[N, E]) HasNode(n N) bool {
+	_, exists := g.index[n]
+	return exists
+}
+
+// RemoveNode removes n and every edge incident to it. It runs in O(V) to
+// keep the insertion order of the remaining nodes.
+// Returns true if n was found and removed, false otherwise.
+func (g *Graph[N, E]) RemoveNode(n N) bool {
+	i, exists := g.index[n]
+	if !exists {
+		return false
+	}
+
+	for _, m := range append([]N(nil), g.out[n].order...) {
+		g.RemoveEdge(n, m)
+	}
+	if g.directed {
+		for _, m := range append([]N(nil), g.in[n].order...) {
+			g.RemoveEdge(m, n)
+		}
+		delete(g.in, n)
+	}
+	delete(g.out, n)
+
+	delete(g.index, n)
+	g.nodes = append(g.nodes[:i], g.nodes[i+1:]...)
+	for j := i; j < len(g.nodes); j++ {
+		g.index[g.nodes[j]] = j
+	}
+	return true
+}
+
+// AddEdge adds an edge from one node to another with the given payload,
+// adding either node first if it is not in the graph. If the edge already
+// exists its payload is replaced.
+// Returns true if the edge was added, false if it was updated.
+func (g *Graph[N, E]) AddEdge(from, to N, payload E) bool {
+	g.AddNode(from)
+	g.AddNode(to)
+
+	added := g.out[from].set(to, payload)
+	if g.directed {
+		g.in[to].set(from, payload)
+	} else if from != to {
+		g.out[to].set(from, payload)
+	}
+	if added {
+		g.edges++
+	}
+	return added
+}
+
+// RemoveEdge removes the edge from one node to another.
+// Returns true if the edge was found and removed, false otherwise.
+func (g *Graph[N, E]) RemoveEdge(from, to N) bool {
+	adj, exists := g.out[from]
+	if !exists || !adj.remove(to) {
+		return false
+	}
+	if g.directed {
+		g.in[to].remove(from)
+	} else if from != to {
+		g.out[to].remove(from)
+	}
+	g.edges--
+	return true
+}
+
+// Edge returns the payload of the edge from one node to another.
+// Returns the payload and true if the edge exists, zero value and false otherwise.
+func (g *Graph[N, E]) Edge(from, to N) (E, bool) {
+	if adj, exists := g.out[from]; exists {
+		payload, ok := adj.payload[to]
+		return payload, ok
+	}
+	var zero E
+	return zero, false
+}
+
+// HasEdge returns true if there is an edge from one node to another.
+func (g *Graph[N, E]) HasEdge(from, to N) bool {
+	_, ok := g.Edge(from, to)
+	return ok
+}
+
+// Degree returns the number of edges leaving n, or 0 if n is not in the
+// graph. In an undirected graph this is the number of incident edges, with
+// a self-loop counting once.
+func (g *Graph[N, E]) Degree(n N) int {
+	if adj, exists := g.out[n]; exists {
+		return len(adj.order)
+	}
+	return 0
+}
+
+// InDegree returns the number of edges entering n, or 0 if n is not in the
+// graph. In an undirected graph it equals Degree.
+func (g *Graph[N, E]) InDegree(n N) int {
+	if !g.directed {
+		return g.Degree(n)
+	}
+	if adj, exists := g.in[n]; exists {
+		return len(adj.order)
+	}
+	return 0
+}
+
+// Nodes returns a slice of all nodes in insertion order.
+func (g *Graph[N, E]) Nodes() []N {
+	return append([]N(nil), g.nodes...)
+}
+
+// Neighbors returns the nodes that edges leaving n lead to, in the order
+// the edges were added. Returns nil if n is not in the graph.
+func (g *Graph[N, E]) Neighbors(n N) []N {
+	adj, exists := g.out[n]
+	if !exists {
+		return nil
+	}
+	return append([]N(nil), adj.order...)
+}
+
+// RangeNeighbors calls fn for each edge leaving n, with the node it leads
+// to and its payload, in the order the edges were added.
+// If fn returns false, the iteration stops. fn must not modify the graph.
+func (g *Graph[N, E]) RangeNeighbors(n N, fn func(to N, payload E) bool) {
+	adj, exists := g.out[n]
+	if !exists {
+		return
+	}
+	for _, m := range adj.order {
+		if !fn(m, adj.payload[m]) {
+			return
+		}
+	}
+}
+
+// RangePredecessors calls fn for each edge entering n, with the node it
+// comes from and its payload. In an undirected graph it is the same as
+// RangeNeighbors. If fn returns false, the iteration stops. fn must not
+// modify the graph.
+func (g *Graph[N, E]) RangePredecessors(n N, fn func(from N, payload E) bool) {
+	if !g.directed {
+		g.RangeNeighbors(n, fn)
+		return
+	}
+	adj, exists := g.in[n]
+	if !exists {
+		return
+	}
+	for _, m := range adj.order {
+		if !fn(m, adj.payload[m]) {
+			return
+		}
+	}
+}
+
+// RangeEdges calls fn for each edge, grouped by the node it leaves in
+// insertion order. Each edge of an undirected graph is reported once, from
+// the end that was added to the graph first.
+// If fn returns false, the iteration stops. fn must not modify the graph.
+func (g *Graph[N, E]) RangeEdges(fn func(edge Edge[N, E]) bool) {
+	for i, n := range g.nodes {
+		adj := g.out[n]
+		for _, m := range adj.order {
+			if !g.directed && g.index[m] < i {
+				continue // reported from m
+			}
+			if !fn(Edge[N, E]{From: n, To: m, Payload: adj.payload[m]}) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns a slice of all edges in the order of RangeEdges.
+func (g *Graph[N, E]) Edges() []Edge[N, E] {
+	edges := make([]Edge[N, E], 0, g.edges)
+	g.RangeEdges(func(edge Edge[N, E]) bool {
+		edges = append(edges, edge)
+		return true
+	})
+	return edges
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+// +build go1.23
+
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements the iterators of Graph (go1.23).
+
+package graph
+
+import (
+	"iter"
+)
+
+// NodeSeq returns an iterator over all nodes in insertion order.
+func (g *Graph[N, E]) NodeSeq() iter.Seq[N] {
+	return func(yield func(N) bool) {
+		for _, n := range g.nodes {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// NeighborSeq returns an iterator over the edges leaving n, yielding the
+// node each leads to and its payload. See RangeNeighbors.
+func (g *Graph[N, E]) NeighborSeq(n N) iter.Seq2[N, E] {
+	return func(yield func(N, E) bool) {
+		g.RangeNeighbors(n, yield)
+	}
+}
+
+// EdgeSeq returns an iterator over all edges. See RangeEdges.
+func (g *Graph[N, E]) EdgeSeq() iter.Seq[Edge[N, E]] {
+	return func(yield func(Edge[N, E]) bool) {
+		g.RangeEdges(yield)
+	}
+}
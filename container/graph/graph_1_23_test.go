@@ -0,0 +1,42 @@
+//go:build go1.23
+// +build go1.23
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphIterators(t *testing.T) {
+	g := NewGraph[string, int](false)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+
+	var nodes []string
+	for n := range g.NodeSeq() {
+		nodes = append(nodes, n)
+	}
+	if !reflect.DeepEqual(nodes, []string{"a", "b", "c"}) {
+		t.Errorf("Expected nodes [a b c], got %v", nodes)
+	}
+
+	total := 0
+	for n, w := range g.NeighborSeq("b") {
+		if w != map[string]int{"a": 1, "c": 2}[n] {
+			t.Errorf("Unexpected payload %d for neighbor %s", w, n)
+		}
+		total += w
+	}
+	if total != 3 {
+		t.Errorf("Expected payloads of b to sum to 3, got %d", total)
+	}
+
+	var edges []Edge[string, int]
+	for e := range g.EdgeSeq() {
+		edges = append(edges, e)
+	}
+	if !reflect.DeepEqual(edges, g.Edges()) {
+		t.Errorf("Expected EdgeSeq to match Edges, got %v", edges)
+	}
+}
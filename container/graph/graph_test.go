@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphDirected(t *testing.T) {
+	g := NewGraph[string, int](true)
+	if !g.Directed() {
+		t.Error("Directed() = false, want true")
+	}
+	if !g.AddNode("a") || g.AddNode("a") {
+		t.Error("AddNode returned unexpected results")
+	}
+	if !g.AddEdge("a", "b", 1) || !g.AddEdge("a", "c", 2) || !g.AddEdge("c", "a", 3) {
+		t.Error("AddEdge returned false for new edges")
+	}
+	if g.AddEdge("a", "b", 10) {
+		t.Error("AddEdge returned true when updating an edge")
+	}
+
+	if g.NodeCount() != 3 || g.EdgeCount() != 3 {
+		t.Errorf("Expected 3 nodes and 3 edges, got %d and %d", g.NodeCount(), g.EdgeCount())
+	}
+	if w, ok := g.Edge("a", "b"); !ok || w != 10 {
+		t.Errorf("Expected edge a->b with payload 10, got %d (ok=%v)", w, ok)
+	}
+	if g.HasEdge("b", "a") {
+		t.Error("Expected no edge b->a in a directed graph")
+	}
+	if !reflect.DeepEqual(g.Neighbors("a"), []string{"b", "c"}) {
+		t.Errorf("Expected neighbors [b c], got %v", g.Neighbors("a"))
+	}
+	if g.Degree("a") != 2 || g.InDegree("a") != 1 || g.InDegree("b") != 1 || g.Degree("x") != 0 {
+		t.Error("Degree or InDegree returned unexpected results")
+	}
+
+	var preds []string
+	g.RangePredecessors("a", func(from string, _ int) bool {
+		preds = append(preds, from)
+		return true
+	})
+	if !reflect.DeepEqual(preds, []string{"c"}) {
+		t.Errorf("Expected predecessors [c], got %v", preds)
+	}
+
+	want := []Edge[string, int]{{"a", "b", 10}, {"a", "c", 2}, {"c", "a", 3}}
+	if !reflect.DeepEqual(g.Edges(), want) {
+		t.Errorf("Expected edges %v, got %v", want, g.Edges())
+	}
+
+	if !g.RemoveEdge("a", "c") || g.RemoveEdge("a", "c") || g.RemoveEdge("x", "a") {
+		t.Error("RemoveEdge returned unexpected results")
+	}
+	if g.EdgeCount() != 2 || g.InDegree("c") != 0 {
+		t.Errorf("Expected 2 edges and no edge into c, got %d and %d", g.EdgeCount(), g.InDegree("c"))
+	}
+}
+
+func TestGraphUndirected(t *testing.T) {
+	g := NewGraph[int, float64](false)
+	g.AddEdge(1, 2, 0.5)
+	g.AddEdge(2, 3, 1.5)
+	g.AddEdge(3, 3, 2) // self-loop
+	if g.AddEdge(2, 1, 0.25) {
+		t.Error("AddEdge(2, 1) returned true for the existing edge 1-2")
+	}
+
+	if g.EdgeCount() != 3 {
+		t.Errorf("Expected 3 edges, got %d", g.EdgeCount())
+	}
+	if w, _ := g.Edge(1, 2); w != 0.25 {
+		t.Errorf("Expected edge 1-2 with payload 0.25, got %v", w)
+	}
+	if !g.HasEdge(3, 2) || !g.HasEdge(3, 3) {
+		t.Error("Expected edges 3-2 and 3-3")
+	}
+	if g.Degree(3) != 2 || g.InDegree(2) != 2 {
+		t.Errorf("Expected Degree(3) = 2 and InDegree(2) = 2, got %d and %d", g.Degree(3), g.InDegree(2))
+	}
+
+	want := []Edge[int, float64]{{1, 2, 0.25}, {2, 3, 1.5}, {3, 3, 2}}
+	if !reflect.DeepEqual(g.Edges(), want) {
+		t.Errorf("Expected edges %v, got %v", want, g.Edges())
+	}
+
+	if !g.RemoveEdge(3, 2) || g.HasEdge(2, 3) {
+		t.Error("RemoveEdge(3, 2) did not remove the edge 2-3")
+	}
+}
+
+func TestGraphRemoveNode(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := NewGraph[string, struct{}](directed)
+		for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"b", "b"}, {"c", "d"}} {
+			g.AddEdge(e[0], e[1], struct{}{})
+		}
+
+		if !g.RemoveNode("b") || g.RemoveNode("b") {
+			t.Errorf("directed=%v: RemoveNode returned unexpected results", directed)
+		}
+		if g.HasNode("b") || g.HasEdge("a", "b") {
+			t.Errorf("directed=%v: node b or its edges remain", directed)
+		}
+		if !reflect.DeepEqual(g.Nodes(), []string{"a", "c", "d"}) {
+			t.Errorf("directed=%v: expected nodes [a c d], got %v", directed, g.Nodes())
+		}
+		if g.EdgeCount() != 2 {
+			t.Errorf("directed=%v: expected 2 edges, got %d", directed, g.EdgeCount())
+		}
+		if len(g.Edges()) != 2 {
+			t.Errorf("directed=%v: expected 2 reported edges, got %v", directed, g.Edges())
+		}
+		if g.Neighbors("b") != nil {
+			t.Errorf("directed=%v: expected nil neighbors for a removed node", directed)
+		}
+	}
+}
+
+func TestGraphRangeStops(t *testing.T) {
+	g := NewGraph[int, int](true)
+	for i := 1; i <= 5; i++ {
+		g.AddEdge(0, i, i)
+	}
+
+	count := 0
+	g.RangeNeighbors(0, func(int, int) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Expected RangeNeighbors to stop after 2 neighbors, got %d", count)
+	}
+
+	count = 0
+	g.RangeEdges(func(Edge[int, int]) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Expected RangeEdges to stop after 3 edges, got %d", count)
+	}
+}
@@ -0,0 +1,209 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements lowest common ancestor queries by binary lifting.
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidParents is returned by NewLCA when the parent array has an
+// out-of-range entry or a cycle.
+var ErrInvalidParents = errors.New("graph: invalid parent array")
+
+// LCA answers lowest common ancestor, k-th ancestor and distance queries on
+// a rooted tree, or a forest of them, that never changes. Construction
+// takes O(n log n) time and space, after which each query is O(log n).
+type LCA[N comparable] struct {
+	nodes []N       // nodes by index
+	index map[N]int // index of each node in nodes
+	depth []int     // number of edges from each node to its root
+	up    [][]int   // up[k][i] is the 2^k-th ancestor of i, or its root
+}
+
+// NewLCA builds an LCA over the nodes 0 to len(parents)-1, where
+// parents[i] is the parent of node i and roots have parent -1 or
+// themselves. An entry out of range or a cycle yields ErrInvalidParents.
+func NewLCA(parents []int) (*LCA[int], error) {
+	const unknown = -1
+	n := len(parents)
+	depth := make([]int, n)
+	for i := range depth {
+		depth[i] = unknown
+	}
+
+	// Walk up from each node to one of known depth, then fill in the path
+	var path []int
+	for i := range parents {
+		path = path[:0]
+		x := i
+		for depth[x] == unknown {
+			p := parents[x]
+			if p < -1 || p >= n {
+				return nil, fmt.Errorf("%w: parent %d of node %d out of range", ErrInvalidParents, p, x)
+			}
+			if p == -1 || p == x {
+				depth[x] = 0
+				break
+			}
+			if len(path) == n {
+				return nil, fmt.Errorf("%w: cycle through node %d", ErrInvalidParents, x)
+			}
+			path = append(path, x)
+			x = p
+		}
+		for j := len(path) - 1; j >= 0; j-- {
+			depth[path[j]] = depth[parents[path[j]]] + 1
+		}
+	}
+
+	nodes := make([]int, n)
+	up := make([]int, n)
+	for i, p := range parents {
+		nodes[i] = i
+		up[i] = p
+		if p == -1 {
+			up[i] = i
+		}
+	}
+	return newLCA(nodes, up, depth), nil
+}
+
+// NewLCAFromGraph builds an LCA over the nodes of g reachable from root,
+// following the edges of a directed graph forwards. If g is not a tree,
+// the tree of its breadth-first traversal from root is used. The LCA is
+// empty if root is not in g.
+func NewLCAFromGraph[N comparable, E any](g *Graph[N, E], root N) *LCA[N] {
+	var nodes []N
+	var up, depth []int
+	index := make(map[N]int)
+	g.BFS(root, func(v Visit[N]) bool {
+		index[v.Node] = len(nodes)
+		nodes = append(nodes, v.Node)
+		parent := index[v.Parent]
+		if v.Depth == 0 {
+			parent = 0
+		}
+		up = append(up, parent)
+		depth = append(depth, v.Depth)
+		return true
+	})
+	return newLCA(nodes, up, depth)
+}
+
+// newLCA builds the lifting table from the parent of each node, roots
+// being their own parents, and the depth of each node.
+func newLCA[N comparable](nodes []N, parents, depth []int) *LCA[N] {
+	l := &LCA[N]{
+		nodes: nodes,
+		index: make(map[N]int, len(nodes)),
+		depth: depth,
+	}
+	for i, n := range nodes {
+		l.index[n] = i
+	}
+
+	maxDepth := 0
+	for _, d := range depth {
+		maxDepth = max(maxDepth, d)
+	}
+	l.up = make([][]int, max(bits.Len(uint(maxDepth)), 1))
+	l.up[0] = parents
+	for k := 1; k < len(l.up); k++ {
+		prev := l.up[k-1]
+		level := make([]int, len(nodes))
+		for i := range level {
+			level[i] = prev[prev[i]]
+		}
+		l.up[k] = level
+	}
+	return l
+}
+
+// Depth returns the number of edges from u to the root of its tree.
+// Returns false if u is not in the tree.
+func (l *LCA[N]) Depth(u N) (int, bool) {
+	i, ok := l.index[u]
+	if !ok {
+		return 0, false
+	}
+	return l.depth[i], true
+}
+
+// KthAncestor returns the ancestor k edges above u; the 0th ancestor is u
+// itself. Returns false if u is not in the tree or k is negative or greater
+// than the depth of u.
+func (l *LCA[N]) KthAncestor(u N, k int) (N, bool) {
+	i, ok := l.index[u]
+	if !ok || k < 0 || k > l.depth[i] {
+		var zero N
+		return zero, false
+	}
+	return l.nodes[l.lift(i, k)], true
+}
+
+// lift returns the ancestor k edges above node index i.
+func (l *LCA[N]) lift(i, k int) int {
+	for bit := 0; k > 0; bit++ {
+		if k&1 == 1 {
+			i = l.up[bit][i]
+		}
+		k >>= 1
+	}
+	return i
+}
+
+// CommonAncestor returns the lowest common ancestor of u and v: the deepest
+// node that is an ancestor of both, where each node counts as its own
+// ancestor. Returns false if either node is not in the tree, or they lie in
+// different trees of a forest.
+func (l *LCA[N]) CommonAncestor(u, v N) (N, bool) {
+	i, ok := l.commonAncestor(u, v)
+	if !ok {
+		var zero N
+		return zero, false
+	}
+	return l.nodes[i], true
+}
+
+// commonAncestor returns the index of the lowest common ancestor of u and v.
+func (l *LCA[N]) commonAncestor(u, v N) (int, bool) {
+	i, okU := l.index[u]
+	j, okV := l.index[v]
+	if !okU || !okV {
+		return 0, false
+	}
+
+	// Bring both to the same depth, then lift them together to just below
+	// their lowest common ancestor
+	if l.depth[i] < l.depth[j] {
+		i, j = j, i
+	}
+	i = l.lift(i, l.depth[i]-l.depth[j])
+	if i == j {
+		return i, true
+	}
+	for k := len(l.up) - 1; k >= 0; k-- {
+		if l.up[k][i] != l.up[k][j] {
+			i, j = l.up[k][i], l.up[k][j]
+		}
+	}
+	if l.up[0][i] != l.up[0][j] {
+		return 0, false // both are roots of different trees
+	}
+	return l.up[0][i], true
+}
+
+// Distance returns the number of edges on the path between u and v.
+// Returns false if either node is not in the tree, or they lie in
+// different trees of a forest.
+func (l *LCA[N]) Distance(u, v N) (int, bool) {
+	a, ok := l.commonAncestor(u, v)
+	if !ok {
+		return 0, false
+	}
+	return l.depth[l.index[u]] + l.depth[l.index[v]] - 2*l.depth[a], true
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// naiveLCA returns the lowest common ancestor of u and v by walking up
+// the parent array, or -1 if they are in different trees.
+func naiveLCA(parents []int, u, v int) int {
+	ancestors := make(map[int]bool)
+	for x := u; x != -1; x = parents[x] {
+		ancestors[x] = true
+	}
+	for x := v; x != -1; x = parents[x] {
+		if ancestors[x] {
+			return x
+		}
+	}
+	return -1
+}
+
+func naiveDepth(parents []int, u int) int {
+	d := 0
+	for ; parents[u] != -1; u = parents[u] {
+		d++
+	}
+	return d
+}
+
+func TestLCARandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		// Each node's parent is an earlier node, or -1 for a few roots
+		n := 1 + rng.Intn(200)
+		parents := make([]int, n)
+		for i := range parents {
+			parents[i] = -1
+			if i > 0 && rng.Intn(20) != 0 {
+				parents[i] = rng.Intn(i)
+			}
+		}
+		l, err := NewLCA(parents)
+		if err != nil {
+			t.Fatalf("NewLCA() error = %v", err)
+		}
+
+		for q := 0; q < 200; q++ {
+			u, v := rng.Intn(n), rng.Intn(n)
+			want := naiveLCA(parents, u, v)
+			got, ok := l.CommonAncestor(u, v)
+			if ok != (want >= 0) || (ok && got != want) {
+				t.Fatalf("CommonAncestor(%d, %d) = %d, %v, want %d", u, v, got, ok, want)
+			}
+			dist, ok := l.Distance(u, v)
+			if want >= 0 {
+				wantDist := naiveDepth(parents, u) + naiveDepth(parents, v) - 2*naiveDepth(parents, want)
+				if !ok || dist != wantDist {
+					t.Fatalf("Distance(%d, %d) = %d, %v, want %d", u, v, dist, ok, wantDist)
+				}
+			} else if ok {
+				t.Fatalf("Distance(%d, %d) = %d, want no path", u, v, dist)
+			}
+
+			depth := naiveDepth(parents, u)
+			if d, ok := l.Depth(u); !ok || d != depth {
+				t.Fatalf("Depth(%d) = %d, want %d", u, d, depth)
+			}
+			k := rng.Intn(depth + 2)
+			wantAnc := u
+			for i := 0; i < k && wantAnc != -1; i++ {
+				wantAnc = parents[wantAnc]
+			}
+			anc, ok := l.KthAncestor(u, k)
+			if ok != (k <= depth) || (ok && anc != wantAnc) {
+				t.Fatalf("KthAncestor(%d, %d) = %d, %v, want %d", u, k, anc, ok, wantAnc)
+			}
+		}
+	}
+}
+
+func TestLCAInvalid(t *testing.T) {
+	for _, parents := range [][]int{{-1, 2}, {-1, -2}, {1, 2, 0}, {-1, 2, 1}} {
+		if _, err := NewLCA(parents); !errors.Is(err, ErrInvalidParents) {
+			t.Errorf("NewLCA(%v) error = %v, want ErrInvalidParents", parents, err)
+		}
+	}
+
+	// Roots may also be their own parents
+	l, err := NewLCA([]int{0, 0, 1})
+	if err != nil {
+		t.Fatalf("NewLCA() error = %v", err)
+	}
+	if a, ok := l.CommonAncestor(2, 1); !ok || a != 1 {
+		t.Errorf("CommonAncestor(2, 1) = %d, want 1", a)
+	}
+	if _, ok := l.CommonAncestor(0, 3); ok {
+		t.Error("Expected no common ancestor with a missing node")
+	}
+}
+
+func TestLCAFromGraph(t *testing.T) {
+	g := NewGraph[string, struct{}](false)
+	for _, e := range [][2]string{{"b", "a"}, {"a", "c"}, {"c", "d"}, {"c", "e"}, {"e", "f"}} {
+		g.AddEdge(e[0], e[1], struct{}{})
+	}
+	g.AddNode("lone")
+	l := NewLCAFromGraph(g, "a")
+
+	if anc, ok := l.CommonAncestor("d", "f"); !ok || anc != "c" {
+		t.Errorf("CommonAncestor(d, f) = %q, want c", anc)
+	}
+	if anc, ok := l.CommonAncestor("b", "f"); !ok || anc != "a" {
+		t.Errorf("CommonAncestor(b, f) = %q, want a", anc)
+	}
+	if d, ok := l.Distance("b", "f"); !ok || d != 4 {
+		t.Errorf("Distance(b, f) = %d, want 4", d)
+	}
+	if anc, ok := l.KthAncestor("f", 2); !ok || anc != "c" {
+		t.Errorf("KthAncestor(f, 2) = %q, want c", anc)
+	}
+	if _, ok := l.Depth("lone"); ok {
+		t.Error("Expected an unreachable node to be missing")
+	}
+
+	if _, ok := NewLCAFromGraph(g, "missing").CommonAncestor("a", "a"); ok {
+		t.Error("Expected an empty LCA for a missing root")
+	}
+}
@@ -0,0 +1,83 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements the indexed priority queue shared by the
+// shortest-path and spanning-tree algorithms.
+
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/feepwang/br/container/constraints"
+)
+
+// Weight is a constraint that permits the numeric types usable as edge weights.
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+// pqItem is a node queued with its priority.
+type pqItem[N comparable, W Weight] struct {
+	node     N
+	priority W
+}
+
+// indexedHeap is a min-heap of nodes by priority that tracks the position
+// of each node, so a queued node's priority can be lowered in O(log n)
+// instead of queueing it again.
+type indexedHeap[N comparable, W Weight] struct {
+	items []pqItem[N, W]
+	index map[N]int // position of each queued node in items
+}
+
+func newIndexedHeap[N comparable, W Weight]() *indexedHeap[N, W] {
+	return &indexedHeap[N, W]{index: make(map[N]int)}
+}
+
+func (h *indexedHeap[N, W]) Len() int { return len(h.items) }
+
+func (h *indexedHeap[N, W]) Less(i, j int) bool {
+	return h.items[i].priority < h.items[j].priority
+}
+
+func (h *indexedHeap[N, W]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].node] = i
+	h.index[h.items[j].node] = j
+}
+
+func (h *indexedHeap[N, W]) Push(x any) {
+	item := x.(pqItem[N, W])
+	h.index[item.node] = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *indexedHeap[N, W]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items[n-1] = pqItem[N, W]{} // release references held by the node
+	h.items = h.items[:n-1]
+	delete(h.index, item.node)
+	return item
+}
+
+// push queues n with the given priority, or lowers the priority of n if it
+// is already queued with a higher one. Returns true if the queue changed.
+func (h *indexedHeap[N, W]) push(n N, priority W) bool {
+	if i, queued := h.index[n]; queued {
+		if priority >= h.items[i].priority {
+			return false
+		}
+		h.items[i].priority = priority
+		heap.Fix(h, i)
+		return true
+	}
+	heap.Push(h, pqItem[N, W]{node: n, priority: priority})
+	return true
+}
+
+// pop removes and returns the node with the lowest priority.
+func (h *indexedHeap[N, W]) pop() (N, W) {
+	item := heap.Pop(h).(pqItem[N, W])
+	return item.node, item.priority
+}
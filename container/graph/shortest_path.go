@@ -0,0 +1,97 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements Dijkstra's shortest-path algorithm.
+
+package graph
+
+// ShortestPaths computes the shortest paths from source to every reachable
+// node with Dijkstra's algorithm, using the edge payloads as weights, which
+// must not be negative. It returns the distance to each reachable node,
+// including source at distance 0, and the predecessor of each reachable
+// node other than source on a shortest path; pass them to Path to rebuild a
+// path. Both maps are empty if source is not in the graph.
+//
+// It runs in O((V + E) log V) with an indexed priority queue, which keeps
+// each node queued at most once.
+func ShortestPaths[N comparable, W Weight](g *Graph[N, W], source N) (dist map[N]W, pred map[N]N) {
+	return dijkstra(g, source, nil)
+}
+
+// ShortestPath returns a shortest path from source to target, including
+// both ends, and its length. The search stops as soon as target is
+// reached. Returns false if target is not reachable from source.
+func ShortestPath[N comparable, W Weight](g *Graph[N, W], source, target N) ([]N, W, bool) {
+	dist, pred := dijkstra(g, source, func(n N) bool { return n == target })
+	d, ok := dist[target]
+	if !ok {
+		return nil, 0, false
+	}
+	return Path(pred, source, target), d, true
+}
+
+// dijkstra runs Dijkstra's algorithm from source, stopping early once a
+// node for which stop returns true is settled. dist holds final distances
+// for settled nodes and tentative ones for the nodes still queued.
+func dijkstra[N comparable, W Weight](g *Graph[N, W], source N, stop func(N) bool) (dist map[N]W, pred map[N]N) {
+	dist = make(map[N]W)
+	pred = make(map[N]N)
+	if !g.HasNode(source) {
+		return dist, pred
+	}
+
+	settled := make(map[N]bool)
+	queue := newIndexedHeap[N, W]()
+	dist[source] = 0
+	queue.push(source, 0)
+	for queue.Len() > 0 {
+		n, d := queue.pop()
+		settled[n] = true
+		if stop != nil && stop(n) {
+			break
+		}
+		g.RangeNeighbors(n, func(m N, w W) bool {
+			if settled[m] {
+				return true
+			}
+			if old, seen := dist[m]; !seen || d+w < old {
+				dist[m] = d + w
+				pred[m] = n
+				queue.push(m, d+w)
+			}
+			return true
+		})
+	}
+
+	// Drop tentative distances left by an early stop
+	for n := range dist {
+		if !settled[n] {
+			delete(dist, n)
+			delete(pred, n)
+		}
+	}
+	return dist, pred
+}
+
+// Path rebuilds the path from source to target, including both ends, from
+// the predecessor map returned by ShortestPaths. Returns nil if target was
+// not reached from source.
+func Path[N comparable](pred map[N]N, source, target N) []N {
+	path := []N{target}
+	for n := target; n != source; {
+		p, ok := pred[n]
+		if !ok {
+			return nil
+		}
+		path = append(path, p)
+		n = p
+		if len(path) > len(pred)+1 {
+			return nil // pred does not lead back to source
+		}
+	}
+
+	// Reverse the path to go from source to target
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// randomGraph returns a random graph of n nodes and m edges with weights in [0, maxWeight).
+func randomGraph(rng *rand.Rand, directed bool, n, m, maxWeight int) *Graph[int, int] {
+	g := NewGraph[int, int](directed)
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < m; i++ {
+		g.AddEdge(rng.Intn(n), rng.Intn(n), rng.Intn(maxWeight))
+	}
+	return g
+}
+
+// bellmanFord returns the distances from source by repeated relaxation.
+func bellmanFord(g *Graph[int, int], source int) map[int]int {
+	dist := map[int]int{source: 0}
+	for i := 0; i < g.NodeCount(); i++ {
+		for _, e := range g.Edges() {
+			ends := [][2]int{{e.From, e.To}}
+			if !g.Directed() {
+				ends = append(ends, [2]int{e.To, e.From})
+			}
+			for _, end := range ends {
+				if d, ok := dist[end[0]]; ok {
+					if old, seen := dist[end[1]]; !seen || d+e.Payload < old {
+						dist[end[1]] = d + e.Payload
+					}
+				}
+			}
+		}
+	}
+	return dist
+}
+
+func TestShortestPaths(t *testing.T) {
+	g := NewGraph[string, int](true)
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddNode("e")
+
+	dist, pred := ShortestPaths(g, "a")
+	want := map[string]int{"a": 0, "b": 3, "c": 1, "d": 4}
+	if !reflect.DeepEqual(dist, want) {
+		t.Errorf("Expected distances %v, got %v", want, dist)
+	}
+	if path := Path(pred, "a", "d"); !reflect.DeepEqual(path, []string{"a", "c", "b", "d"}) {
+		t.Errorf("Expected path [a c b d], got %v", path)
+	}
+	if path := Path(pred, "a", "a"); !reflect.DeepEqual(path, []string{"a"}) {
+		t.Errorf("Expected path [a], got %v", path)
+	}
+	if path := Path(pred, "a", "e"); path != nil {
+		t.Errorf("Expected no path to e, got %v", path)
+	}
+
+	dist, pred = ShortestPaths(g, "missing")
+	if len(dist) != 0 || len(pred) != 0 {
+		t.Errorf("Expected empty maps for a missing source, got %v and %v", dist, pred)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewGraph[int, float64](false)
+	g.AddEdge(0, 1, 0.5)
+	g.AddEdge(1, 2, 0.25)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 3, 2)
+	g.AddNode(4)
+
+	path, d, ok := ShortestPath(g, 3, 0)
+	if !ok || d != 2.75 || !reflect.DeepEqual(path, []int{3, 2, 1, 0}) {
+		t.Errorf("Expected path [3 2 1 0] of length 2.75, got %v of length %v (ok=%v)", path, d, ok)
+	}
+	if _, _, ok := ShortestPath(g, 0, 4); ok {
+		t.Error("Expected no path to an isolated node")
+	}
+	if _, _, ok := ShortestPath(g, 9, 0); ok {
+		t.Error("Expected no path from a missing node")
+	}
+}
+
+func TestShortestPathEarlyExit(t *testing.T) {
+	// A long chain behind the target must not be explored
+	g := NewGraph[int, int](true)
+	g.AddEdge(0, 1, 1)
+	for i := 1; i < 100; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	dist, _ := dijkstra(g, 0, func(n int) bool { return n == 1 })
+	if len(dist) != 2 {
+		t.Errorf("Expected 2 settled nodes, got %v", dist)
+	}
+	if path, d, _ := ShortestPath(g, 0, 50); d != 50 || len(path) != 51 {
+		t.Errorf("Expected a path of 51 nodes and length 50, got %d nodes and length %d", len(path), d)
+	}
+}
+
+func TestShortestPathsRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		g := randomGraph(rng, trial%2 == 0, 40, 120, 20)
+		dist, pred := ShortestPaths(g, 0)
+		if want := bellmanFord(g, 0); !reflect.DeepEqual(dist, want) {
+			t.Fatalf("trial %d: expected distances %v, got %v", trial, want, dist)
+		}
+
+		// Every path must follow edges and add up to the distance
+		for n, d := range dist {
+			path := Path(pred, 0, n)
+			total := 0
+			for i := 1; i < len(path); i++ {
+				w, ok := g.Edge(path[i-1], path[i])
+				if !ok {
+					t.Fatalf("trial %d: path %v uses a missing edge", trial, path)
+				}
+				total += w
+			}
+			if total != d {
+				t.Fatalf("trial %d: path %v has length %d, want %d", trial, path, total, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements the Kruskal and Prim minimum spanning tree algorithms.
+
+package graph
+
+import (
+	"sort"
+
+	"github.com/feepwang/br/container/dsu"
+)
+
+// Kruskal returns the edges of a minimum spanning forest of g, using the
+// edge payloads as weights, and their total weight. The forest spans every
+// component, so it has NodeCount minus the number of components edges.
+// Edge directions are ignored.
+//
+// Edges are taken in order of increasing weight, skipping those whose ends
+// a DSU already reports as connected. It runs in O(E log E).
+func Kruskal[N comparable, W Weight](g *Graph[N, W]) ([]Edge[N, W], W) {
+	var total W
+	if g.NodeCount() == 0 {
+		return nil, total
+	}
+
+	edges := g.Edges()
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Payload < edges[j].Payload
+	})
+
+	components := dsu.NewDSU(g.NodeCount())
+	var tree []Edge[N, W]
+	for _, e := range edges {
+		if components.Union(g.index[e.From], g.index[e.To]) {
+			tree = append(tree, e)
+			total += e.Payload
+		}
+	}
+	return tree, total
+}
+
+// Prim returns the edges of a minimum spanning forest of g, using the edge
+// payloads as weights, and their total weight, like Kruskal. Each tree is
+// grown from its first node in insertion order, and every edge leads From
+// a node already in the tree To the node it adds. Edge directions are
+// ignored.
+//
+// The cheapest edge into each node outside the tree is kept in an indexed
+// priority queue, so it runs in O((V + E) log V), which beats Kruskal on
+// dense graphs.
+func Prim[N comparable, W Weight](g *Graph[N, W]) ([]Edge[N, W], W) {
+	var total W
+	var tree []Edge[N, W]
+	inTree := make(map[N]bool, g.NodeCount())
+	best := make(map[N]Edge[N, W]) // cheapest known edge into each queued node
+
+	for _, root := range g.nodes {
+		if inTree[root] {
+			continue
+		}
+		queue := newIndexedHeap[N, W]()
+		queue.push(root, 0)
+		for queue.Len() > 0 {
+			n, _ := queue.pop()
+			inTree[n] = true
+			if e, ok := best[n]; ok {
+				tree = append(tree, e)
+				total += e.Payload
+				delete(best, n)
+			}
+
+			relax := func(m N, w W) bool {
+				if inTree[m] {
+					return true
+				}
+				if e, seen := best[m]; !seen || w < e.Payload {
+					best[m] = Edge[N, W]{From: n, To: m, Payload: w}
+					queue.push(m, w)
+				}
+				return true
+			}
+			g.RangeNeighbors(n, relax)
+			if g.directed {
+				g.RangePredecessors(n, relax)
+			}
+		}
+	}
+	return tree, total
+}
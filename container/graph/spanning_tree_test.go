@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/dsu"
+)
+
+// checkForest verifies that edges form a spanning forest of g with the given total.
+func checkForest(t *testing.T, name string, g *Graph[int, int], edges []Edge[int, int], total int) {
+	t.Helper()
+	forest := dsu.NewGenericDSU[int]()
+	sum := 0
+	for _, e := range edges {
+		w, ok := g.Edge(e.From, e.To)
+		if (!ok || w != e.Payload) && g.Directed() {
+			w, ok = g.Edge(e.To, e.From)
+		}
+		if !ok || w != e.Payload {
+			t.Fatalf("%s: edge %v is not in the graph", name, e)
+		}
+		if !forest.Union(e.From, e.To) {
+			t.Fatalf("%s: edge %v closes a cycle", name, e)
+		}
+		sum += e.Payload
+	}
+	if sum != total {
+		t.Errorf("%s: edges add up to %d, reported total %d", name, sum, total)
+	}
+
+	// The forest must connect exactly what the graph connects
+	components := dsu.NewGenericDSU[int]()
+	for _, n := range g.Nodes() {
+		components.MakeSet(n)
+		forest.MakeSet(n)
+	}
+	for _, e := range g.Edges() {
+		components.Union(e.From, e.To)
+	}
+	if forest.ComponentCount() != components.ComponentCount() {
+		t.Errorf("%s: forest has %d components, graph has %d", name, forest.ComponentCount(), components.ComponentCount())
+	}
+}
+
+func TestSpanningTree(t *testing.T) {
+	g := NewGraph[int, int](false)
+	for _, e := range [][3]int{{0, 1, 4}, {0, 2, 1}, {1, 2, 2}, {1, 3, 5}, {2, 3, 8}, {3, 4, 3}, {5, 6, 7}} {
+		g.AddEdge(e[0], e[1], e[2])
+	}
+
+	for name, mst := range map[string]func(*Graph[int, int]) ([]Edge[int, int], int){
+		"Kruskal": Kruskal[int, int],
+		"Prim":    Prim[int, int],
+	} {
+		edges, total := mst(g)
+		if total != 18 || len(edges) != 5 {
+			t.Errorf("%s: expected 5 edges of total weight 18, got %v with total %d", name, edges, total)
+		}
+		checkForest(t, name, g, edges, total)
+	}
+
+	edges, _ := Prim(g)
+	want := []Edge[int, int]{{0, 2, 1}, {2, 1, 2}, {1, 3, 5}, {3, 4, 3}, {5, 6, 7}}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("Prim: expected edges %v, got %v", want, edges)
+	}
+
+	empty := NewGraph[int, int](false)
+	if edges, total := Kruskal(empty); edges != nil || total != 0 {
+		t.Errorf("Kruskal: expected no edges for an empty graph, got %v", edges)
+	}
+}
+
+func TestSpanningTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 30; trial++ {
+		g := randomGraph(rng, trial%3 == 0, 30, 20+rng.Intn(100), 50)
+		kEdges, kTotal := Kruskal(g)
+		pEdges, pTotal := Prim(g)
+		checkForest(t, "Kruskal", g, kEdges, kTotal)
+		checkForest(t, "Prim", g, pEdges, pTotal)
+		if kTotal != pTotal {
+			t.Fatalf("trial %d: Kruskal total %d differs from Prim total %d", trial, kTotal, pTotal)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements breadth-first and depth-first traversal.
+
+package graph
+
+// Visit describes a node reached by a traversal.
+type Visit[N comparable] struct {
+	Node   N
+	Parent N   // node the traversal came from; the zero value for the start node
+	Depth  int // number of edges from the start node along the traversal tree
+}
+
+// BFS visits the nodes reachable from start in breadth-first order, calling
+// fn for each with the node it was reached from and its distance in edges
+// from start. Neighbors are explored in the order their edges were added,
+// and every node is visited once, so cycles are safe. If fn returns false,
+// the traversal stops. It does nothing if start is not in the graph.
+// fn must not modify the graph.
+func (g *Graph[N, E]) BFS(start N, fn func(v Visit[N]) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	visited := map[N]bool{start: true}
+	queue := []Visit[N]{{Node: start}}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if !fn(v) {
+			return
+		}
+		for _, m := range g.out[v.Node].order {
+			if !visited[m] {
+				visited[m] = true
+				queue = append(queue, Visit[N]{Node: m, Parent: v.Node, Depth: v.Depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first preorder,
+// calling fn for each with the node it was reached from and its depth in
+// the traversal tree. Neighbors are explored in the order their edges were
+// added, and every node is visited once, so cycles are safe. If fn returns
+// false, the traversal stops. It does nothing if start is not in the graph.
+// fn must not modify the graph.
+func (g *Graph[N, E]) DFS(start N, fn func(v Visit[N]) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	// frame is a node on the current path and its next neighbor to explore
+	type frame struct {
+		visit Visit[N]
+		next  int
+	}
+	visited := map[N]bool{start: true}
+	if !fn(Visit[N]{Node: start}) {
+		return
+	}
+	stack := []frame{{visit: Visit[N]{Node: start}}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		neighbors := g.out[top.visit.Node].order
+		if top.next == len(neighbors) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		m := neighbors[top.next]
+		top.next++
+		if visited[m] {
+			continue
+		}
+		visited[m] = true
+		v := Visit[N]{Node: m, Parent: top.visit.Node, Depth: top.visit.Depth + 1}
+		if !fn(v) {
+			return
+		}
+		stack = append(stack, frame{visit: v})
+	}
+}
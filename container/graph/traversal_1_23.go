@@ -0,0 +1,32 @@
+//go:build go1.23
+// +build go1.23
+
+// Package graph provides generic graphs stored as adjacency lists, and
+// algorithms over them.
+// This file implements the traversal iterators (go1.23).
+
+package graph
+
+import (
+	"iter"
+)
+
+// BFSFrom returns an iterator over the nodes reachable from start in
+// breadth-first order. See BFS.
+func (g *Graph[N, E]) BFSFrom(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		g.BFS(start, func(v Visit[N]) bool {
+			return yield(v.Node)
+		})
+	}
+}
+
+// DFSFrom returns an iterator over the nodes reachable from start in
+// depth-first preorder. See DFS.
+func (g *Graph[N, E]) DFSFrom(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		g.DFS(start, func(v Visit[N]) bool {
+			return yield(v.Node)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build go1.23
+// +build go1.23
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTraversalIterators(t *testing.T) {
+	g := treeGraph()
+
+	var bfs, dfs []int
+	for n := range g.BFSFrom(1) {
+		bfs = append(bfs, n)
+	}
+	for n := range g.DFSFrom(1) {
+		dfs = append(dfs, n)
+		if n == 4 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(bfs, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected BFS order [1 2 3 4 5], got %v", bfs)
+	}
+	if !reflect.DeepEqual(dfs, []int{1, 2, 4}) {
+		t.Errorf("Expected DFS order [1 2 4] up to the break, got %v", dfs)
+	}
+}
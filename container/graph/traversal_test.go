@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// treeGraph returns the directed graph
+//
+//	1 -> 2 -> 4
+//	1 -> 3 -> 4 -> 1
+//	3 -> 5
+func treeGraph() *Graph[int, struct{}] {
+	g := NewGraph[int, struct{}](true)
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}, {3, 5}, {4, 1}} {
+		g.AddEdge(e[0], e[1], struct{}{})
+	}
+	g.AddNode(6) // unreachable
+	return g
+}
+
+// collect runs a traversal and returns its visits.
+func collect(traverse func(start int, fn func(Visit[int]) bool), start int) []Visit[int] {
+	var visits []Visit[int]
+	traverse(start, func(v Visit[int]) bool {
+		visits = append(visits, v)
+		return true
+	})
+	return visits
+}
+
+func TestBFS(t *testing.T) {
+	g := treeGraph()
+	want := []Visit[int]{
+		{Node: 1},
+		{Node: 2, Parent: 1, Depth: 1},
+		{Node: 3, Parent: 1, Depth: 1},
+		{Node: 4, Parent: 2, Depth: 2},
+		{Node: 5, Parent: 3, Depth: 2},
+	}
+	if got := collect(g.BFS, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected visits %v, got %v", want, got)
+	}
+	if got := collect(g.BFS, 6); !reflect.DeepEqual(got, []Visit[int]{{Node: 6}}) {
+		t.Errorf("Expected only the start node, got %v", got)
+	}
+	if got := collect(g.BFS, 7); got != nil {
+		t.Errorf("Expected no visits from a missing node, got %v", got)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g := treeGraph()
+	want := []Visit[int]{
+		{Node: 1},
+		{Node: 2, Parent: 1, Depth: 1},
+		{Node: 4, Parent: 2, Depth: 2},
+		{Node: 3, Parent: 1, Depth: 1},
+		{Node: 5, Parent: 3, Depth: 2},
+	}
+	if got := collect(g.DFS, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected visits %v, got %v", want, got)
+	}
+	if got := collect(g.DFS, 7); got != nil {
+		t.Errorf("Expected no visits from a missing node, got %v", got)
+	}
+}
+
+func TestTraversalUndirected(t *testing.T) {
+	// A cycle 0-1-2-3-0; each node must be visited once
+	g := NewGraph[int, struct{}](false)
+	for i := 0; i < 4; i++ {
+		g.AddEdge(i, (i+1)%4, struct{}{})
+	}
+	for name, traverse := range map[string]func(int, func(Visit[int]) bool){"BFS": g.BFS, "DFS": g.DFS} {
+		if got := collect(traverse, 0); len(got) != 4 {
+			t.Errorf("%s: expected 4 visits, got %v", name, got)
+		}
+	}
+
+	var depths []int
+	g.BFS(0, func(v Visit[int]) bool {
+		depths = append(depths, v.Depth)
+		return true
+	})
+	if !reflect.DeepEqual(depths, []int{0, 1, 1, 2}) {
+		t.Errorf("Expected BFS depths [0 1 1 2], got %v", depths)
+	}
+}
+
+func TestTraversalStops(t *testing.T) {
+	g := treeGraph()
+	for name, traverse := range map[string]func(int, func(Visit[int]) bool){"BFS": g.BFS, "DFS": g.DFS} {
+		for stop := 1; stop <= 3; stop++ {
+			count := 0
+			traverse(1, func(Visit[int]) bool {
+				count++
+				return count < stop
+			})
+			if count != stop {
+				t.Errorf("%s: expected to stop after %d visits, got %d", name, stop, count)
+			}
+		}
+	}
+}
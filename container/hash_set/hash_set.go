@@ -0,0 +1,230 @@
+// Package hash_set provides a hash set for element types that need not be
+// comparable, such as slices or structs containing maps.
+
+package hash_set
+
+import (
+	"github.com/feepwang/br/container/hashing"
+)
+
+// Set is the read-only view of a set accepted by the relationship methods
+// of HashSet, so they compare by value against any implementation.
+type Set[T any] interface {
+	// Len returns the number of elements in the set.
+	Len() int
+
+	// Contains returns true if an element equal to v is in the set.
+	Contains(v T) bool
+
+	// Range calls fn for each element until fn returns false.
+	Range(fn func(v T) bool)
+}
+
+// Compile-time check that HashSet implements Set.
+var _ Set[int] = (*HashSet[int])(nil)
+
+// HashSet is an unordered set whose elements are hashed and compared by a
+// hashing.Hasher rather than by ==, so it can hold any type T. Elements
+// with equal hashes share a bucket and are told apart by Hasher.Equal, so
+// operations take O(1) expected time with a well-distributed hash.
+//
+// Elements must not be modified while in the set, since their hash would
+// no longer match their bucket.
+type HashSet[T any] struct {
+	hasher  hashing.Hasher[T]
+	buckets map[uint64][]T // elements by hash
+	size    int            // number of elements
+}
+
+// NewHashSet creates an empty set using hasher, or the Hasher returned by
+// hashing.For[T] if hasher is nil.
+func NewHashSet[T any](hasher hashing.Hasher[T], opts ...Option) *HashSet[T] {
+	if hasher == nil {
+		hasher = hashing.For[T]()
+	}
+	o := newOptions(opts)
+	return &HashSet[T]{
+		hasher:  hasher,
+		buckets: make(map[uint64][]T, o.capacity),
+	}
+}
+
+// find returns the hash of v and its position in its bucket, or -1 if v is
+// not in the set.
+func (s *HashSet[T]) find(v T) (uint64, int) {
+	h := s.hasher.Hash(v)
+	for i, e := range s.buckets[h] {
+		if s.hasher.Equal(e, v) {
+			return h, i
+		}
+	}
+	return h, -1
+}
+
+// Add adds v to the set.
+// Returns true if v was added, false if an equal element was already present.
+func (s *HashSet[T]) Add(v T) bool {
+	h, i := s.find(v)
+	if i >= 0 {
+		return false
+	}
+	s.buckets[h] = append(s.buckets[h], v)
+	s.size++
+	return true
+}
+
+// Remove removes the element equal to v.
+// Returns true if it was found and removed, false otherwise.
+func (s *HashSet[T]) Remove(v T) bool {
+	h, i := s.find(v)
+	if i < 0 {
+		return false
+	}
+	s.removeAt(h, i)
+	return true
+}
+
+// removeAt removes the element at position i of the bucket for hash h.
+func (s *HashSet[T]) removeAt(h uint64, i int) {
+	bucket := s.buckets[h]
+	if len(bucket) == 1 {
+		delete(s.buckets, h)
+	} else {
+		last := len(bucket) - 1
+		bucket[i] = bucket[last]
+		var zero T
+		bucket[last] = zero // release the reference held by the spare slot
+		s.buckets[h] = bucket[:last]
+	}
+	s.size--
+}
+
+// GetAny returns an arbitrary element without removing it.
+// Returns false if the set is empty.
+func (s *HashSet[T]) GetAny() (T, bool) {
+	for _, bucket := range s.buckets {
+		return bucket[0], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Pop removes and returns an arbitrary element, as needed by worklist
+// algorithms that drain a set. Returns false if the set is empty.
+func (s *HashSet[T]) Pop() (T, bool) {
+	for h, bucket := range s.buckets {
+		v := bucket[len(bucket)-1]
+		s.removeAt(h, len(bucket)-1)
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Contains returns true if an element equal to v is in the set.
+func (s *HashSet[T]) Contains(v T) bool {
+	_, i := s.find(v)
+	return i >= 0
+}
+
+// IsDisjoint returns true if s and other have no element in common. It
+// iterates the smaller set and stops at the first shared element. Both sets
+// should agree on equality, as sets using equivalent Hashers do.
+func (s *HashSet[T]) IsDisjoint(other Set[T]) bool {
+	var small, large Set[T] = s, other
+	if s.size > other.Len() {
+		small, large = other, s
+	}
+	disjoint := true
+	small.Range(func(v T) bool {
+		disjoint = !large.Contains(v)
+		return disjoint
+	})
+	return disjoint
+}
+
+// IsSubset returns true if every element of s is in other. It stops at the
+// first element missing from other, and returns at once if s is larger.
+// Both sets should agree on equality, as sets using equivalent Hashers do.
+func (s *HashSet[T]) IsSubset(other Set[T]) bool {
+	if s.size > other.Len() {
+		return false
+	}
+	subset := true
+	s.Range(func(v T) bool {
+		subset = other.Contains(v)
+		return subset
+	})
+	return subset
+}
+
+// Equal returns true if s and other hold the same elements, whatever the
+// implementation of other. Both sets should agree on equality, as sets
+// using equivalent Hashers do.
+func (s *HashSet[T]) Equal(other Set[T]) bool {
+	return s.size == other.Len() && s.IsSubset(other)
+}
+
+// Clone returns an independent copy of s using the same Hasher. The
+// elements themselves are copied shallowly.
+func (s *HashSet[T]) Clone() *HashSet[T] {
+	buckets := make(map[uint64][]T, len(s.buckets))
+	for h, bucket := range s.buckets {
+		buckets[h] = append([]T(nil), bucket...)
+	}
+	return &HashSet[T]{hasher: s.hasher, buckets: buckets, size: s.size}
+}
+
+// Len returns the number of elements in the set.
+func (s *HashSet[T]) Len() int {
+	return s.size
+}
+
+// Reserve makes room for n more elements, so adding them does not grow the
+// set step by step. It takes O(Len()) time to move the existing elements,
+// so call it before a bulk insertion rather than in a loop.
+func (s *HashSet[T]) Reserve(n int) {
+	if n <= 0 {
+		return
+	}
+	buckets := make(map[uint64][]T, len(s.buckets)+n)
+	for h, bucket := range s.buckets {
+		buckets[h] = bucket
+	}
+	s.buckets = buckets
+}
+
+// Clear removes all elements. The set keeps the memory it has grown, so
+// refilling it to a similar size does not allocate; use Reset to release
+// that memory instead.
+func (s *HashSet[T]) Clear() {
+	clear(s.buckets)
+	s.size = 0
+}
+
+// Reset removes all elements and releases the memory held by the set.
+func (s *HashSet[T]) Reset() {
+	s.buckets = make(map[uint64][]T)
+	s.size = 0
+}
+
+// Range calls fn for each element in no particular order until fn returns
+// false. fn must not modify the set.
+func (s *HashSet[T]) Range(fn func(v T) bool) {
+	for _, bucket := range s.buckets {
+		for _, v := range bucket {
+			if !fn(v) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements in no particular order.
+func (s *HashSet[T]) Slice() []T {
+	elements := make([]T, 0, s.size)
+	for _, bucket := range s.buckets {
+		elements = append(elements, bucket...)
+	}
+	return elements
+}
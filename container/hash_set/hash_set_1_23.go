@@ -0,0 +1,50 @@
+//go:build go1.23
+// +build go1.23
+
+// Package hash_set provides a hash set for element types that need not be
+// comparable, such as slices or structs containing maps.
+// This file implements the iterator-based methods of HashSet (go1.23).
+
+package hash_set
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/feepwang/br/container/hashing"
+)
+
+// All returns an iterator over the elements in no particular order.
+// The set must not be modified during iteration.
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.Range(yield)
+	}
+}
+
+// AddSeq adds every element of seq to the set.
+func (s *HashSet[T]) AddSeq(seq iter.Seq[T]) {
+	for v := range seq {
+		s.Add(v)
+	}
+}
+
+// AllSorted returns an iterator over the elements of s in ascending order.
+// It iterates a sorted snapshot, so s may be modified during iteration.
+func AllSorted[T cmp.Ordered](s *HashSet[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range Sorted(s) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// NewHashSetFromSeq creates a set of the values of seq using hasher, or the
+// Hasher returned by hashing.For[T] if hasher is nil.
+func NewHashSetFromSeq[T any](seq iter.Seq[T], hasher hashing.Hasher[T], opts ...Option) *HashSet[T] {
+	s := NewHashSet(hasher, opts...)
+	s.AddSeq(seq)
+	return s
+}
@@ -0,0 +1,61 @@
+//go:build go1.23
+// +build go1.23
+
+package hash_set
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/feepwang/br/container/hashing"
+)
+
+func TestHashSetSeq(t *testing.T) {
+	s := NewHashSet[[]string](nil)
+	s.AddSeq(slices.Values([][]string{{"a"}, {"a", "b"}, {"a"}}))
+	if s.Len() != 2 {
+		t.Errorf("Expected 2 elements, got %d", s.Len())
+	}
+
+	count := 0
+	for v := range s.All() {
+		if !s.Contains(v) {
+			t.Errorf("All yielded %v, which is not in the set", v)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected All to yield 2 elements, got %d", count)
+	}
+}
+
+func TestAllSorted(t *testing.T) {
+	s := NewHashSet[string](nil)
+	s.AddSeq(slices.Values([]string{"c", "a", "b"}))
+
+	var got []string
+	for v := range AllSorted(s) {
+		got = append(got, v)
+		s.Remove(v) // iteration is over a snapshot
+		if v == "b" {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"a", "b"}) || s.Len() != 1 {
+		t.Errorf("Expected [a b] with 1 element left, got %v and %d", got, s.Len())
+	}
+}
+
+func TestNewHashSetFromSeq(t *testing.T) {
+	s := NewHashSetFromSeq(slices.Values([]string{"a", "B", "b"}), hashing.Func(func(v string) uint64 {
+		return uint64(len(v))
+	}, strings.EqualFold), WithCapacity(3))
+	if s.Len() != 2 || !s.Contains("A") || !s.Contains("b") {
+		t.Errorf("Expected {a, B} under case folding, got %v", s.Slice())
+	}
+
+	if s := NewHashSetFromSeq(slices.Values([][]int{{1}, {1}, {2}}), nil); s.Len() != 2 {
+		t.Errorf("Expected 2 elements with the default hasher, got %d", s.Len())
+	}
+}
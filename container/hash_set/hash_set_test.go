@@ -0,0 +1,242 @@
+package hash_set
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/feepwang/br/container/hashing"
+)
+
+func TestHashSetSlices(t *testing.T) {
+	s := NewHashSet[[]int](nil)
+	if !s.Add([]int{1, 2}) || !s.Add([]int{2, 1}) || !s.Add(nil) {
+		t.Error("Add returned false for new elements")
+	}
+	if s.Add([]int{1, 2}) {
+		t.Error("Add returned true for an equal element")
+	}
+	if s.Len() != 3 {
+		t.Errorf("Expected 3 elements, got %d", s.Len())
+	}
+	if !s.Contains([]int{2, 1}) || s.Contains([]int{1}) {
+		t.Error("Contains returned unexpected results")
+	}
+	if !s.Remove([]int{1, 2}) || s.Remove([]int{1, 2}) {
+		t.Error("Remove returned unexpected results")
+	}
+	if s.Len() != 2 || s.Contains([]int{1, 2}) {
+		t.Errorf("Expected 2 elements without [1 2], got %v", s.Slice())
+	}
+
+	s.Clear()
+	if s.Len() != 0 || len(s.Slice()) != 0 || s.Contains(nil) {
+		t.Error("Expected an empty set after Clear")
+	}
+}
+
+func TestHashSetCollisions(t *testing.T) {
+	// Every element shares one bucket, so only Equal tells them apart
+	s := NewHashSet(hashing.Func(func(string) uint64 { return 0 }, strings.EqualFold))
+	for _, v := range []string{"a", "B", "c", "A", "b"} {
+		s.Add(v)
+	}
+	got := s.Slice()
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, []string{"B", "a", "c"}) {
+		t.Errorf("Expected [B a c], got %v", got)
+	}
+	if !s.Remove("C") || !s.Contains("A") || s.Contains("c") {
+		t.Error("Remove or Contains returned unexpected results")
+	}
+
+	count := 0
+	s.Range(func(string) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Expected Range to stop after 1 element, got %d", count)
+	}
+}
+
+func TestHashSetRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	// A weak hash forces collisions between distinct keys
+	s := NewHashSet(hashing.Func(func(v [2]int) uint64 { return uint64(v[0] % 4) }, func(a, b [2]int) bool { return a == b }))
+	ref := make(map[[2]int]bool)
+	for i := 0; i < 5000; i++ {
+		v := [2]int{rng.Intn(20), rng.Intn(5)}
+		switch rng.Intn(3) {
+		case 0, 1:
+			if got := s.Add(v); got != !ref[v] {
+				t.Fatalf("Add(%v) = %v, want %v", v, got, !ref[v])
+			}
+			ref[v] = true
+		case 2:
+			if got := s.Remove(v); got != ref[v] {
+				t.Fatalf("Remove(%v) = %v, want %v", v, got, ref[v])
+			}
+			delete(ref, v)
+		}
+		if s.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", s.Len(), len(ref))
+		}
+	}
+	for _, v := range s.Slice() {
+		if !ref[v] {
+			t.Fatalf("Unexpected element %v", v)
+		}
+	}
+}
+
+func TestHashSetCapacity(t *testing.T) {
+	s := NewHashSet[int](nil, WithCapacity(100), WithCapacity(-1))
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	s.Reserve(1000)
+	s.Reserve(-5)
+	if s.Len() != 10 || !s.Contains(9) {
+		t.Errorf("Expected Reserve to keep the 10 elements, got %v", s.Slice())
+	}
+
+	s.Reset()
+	if s.Len() != 0 || s.Contains(0) {
+		t.Error("Expected an empty set after Reset")
+	}
+	if !s.Add(0) || s.Len() != 1 {
+		t.Error("Expected the set to be usable after Reset")
+	}
+}
+
+func BenchmarkHashSetAdd(b *testing.B) {
+	const n = 1 << 16
+	b.Run("grow", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := NewHashSet(hashing.Comparable[int]())
+			for j := 0; j < n; j++ {
+				s.Add(j)
+			}
+		}
+	})
+	b.Run("capacity", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := NewHashSet(hashing.Comparable[int](), WithCapacity(n))
+			for j := 0; j < n; j++ {
+				s.Add(j)
+			}
+		}
+	})
+}
+
+func TestHashSetPop(t *testing.T) {
+	s := NewHashSet(hashing.Func(func(v int) uint64 { return uint64(v % 3) }, func(a, b int) bool { return a == b }))
+	if _, ok := s.Pop(); ok {
+		t.Error("Expected Pop on an empty set to return false")
+	}
+	if _, ok := s.GetAny(); ok {
+		t.Error("Expected GetAny on an empty set to return false")
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	if v, ok := s.GetAny(); !ok || !s.Contains(v) || s.Len() != 10 {
+		t.Errorf("GetAny() = %d, %v, want an element left in the set", v, ok)
+	}
+
+	seen := make(map[int]bool)
+	for s.Len() > 0 {
+		v, ok := s.Pop()
+		if !ok || seen[v] || s.Contains(v) {
+			t.Fatalf("Pop() = %d, %v, want a new element removed from the set", v, ok)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("Expected Pop to drain 10 elements, got %d", len(seen))
+	}
+}
+
+func TestHashSetRelations(t *testing.T) {
+	newSet := func(values ...string) *HashSet[[]byte] {
+		s := NewHashSet[[]byte](nil)
+		for _, v := range values {
+			s.Add([]byte(v))
+		}
+		return s
+	}
+	abc, ab, de, empty := newSet("a", "b", "c"), newSet("b", "a"), newSet("d", "e"), newSet()
+
+	tests := []struct {
+		name     string
+		s, other *HashSet[[]byte]
+		disjoint bool
+		subset   bool
+		equal    bool
+	}{
+		{"subset", ab, abc, false, true, false},
+		{"superset", abc, ab, false, false, false},
+		{"disjoint", abc, de, true, false, false},
+		{"equal", ab, newSet("a", "b"), false, true, true},
+		{"empty", empty, abc, true, true, false},
+		{"both empty", empty, newSet(), true, true, true},
+	}
+	for _, tt := range tests {
+		if got := tt.s.IsDisjoint(tt.other); got != tt.disjoint {
+			t.Errorf("%s: IsDisjoint() = %v, want %v", tt.name, got, tt.disjoint)
+		}
+		if got := tt.s.IsSubset(tt.other); got != tt.subset {
+			t.Errorf("%s: IsSubset() = %v, want %v", tt.name, got, tt.subset)
+		}
+		if got := tt.s.Equal(tt.other); got != tt.equal {
+			t.Errorf("%s: Equal() = %v, want %v", tt.name, got, tt.equal)
+		}
+	}
+}
+
+// mapSet is a Set backed by a map, standing in for another implementation.
+type mapSet map[string]bool
+
+func (m mapSet) Len() int               { return len(m) }
+func (m mapSet) Contains(v string) bool { return m[v] }
+func (m mapSet) Range(fn func(v string) bool) {
+	for v := range m {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+func TestHashSetOtherImplementation(t *testing.T) {
+	s := NewHashSet[string](nil)
+	s.Add("a")
+	s.Add("b")
+	if !s.Equal(mapSet{"a": true, "b": true}) || s.Equal(mapSet{"a": true, "c": true}) {
+		t.Error("Equal returned unexpected results against a map-backed set")
+	}
+	if !s.IsSubset(mapSet{"a": true, "b": true, "c": true}) || !s.IsDisjoint(mapSet{"c": true}) {
+		t.Error("IsSubset or IsDisjoint returned unexpected results against a map-backed set")
+	}
+}
+
+func TestHashSetClone(t *testing.T) {
+	s := NewHashSet(hashing.Func(func(int) uint64 { return 0 }, func(a, b int) bool { return a == b }))
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+	c := s.Clone()
+	if !c.Equal(s) {
+		t.Errorf("Expected the clone to equal the set, got %v", c.Slice())
+	}
+
+	// Both share one bucket, which must not be aliased
+	c.Remove(0)
+	c.Add(10)
+	if !s.Contains(0) || s.Contains(10) || s.Len() != 5 {
+		t.Errorf("Expected the set to be unaffected by the clone, got %v", s.Slice())
+	}
+}
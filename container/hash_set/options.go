@@ -0,0 +1,29 @@
+// Package hash_set provides a hash set for element types that need not be
+// comparable, such as slices or structs containing maps.
+// This file implements the options accepted by NewHashSet.
+
+package hash_set
+
+// Option configures a set created by NewHashSet.
+type Option func(*options)
+
+type options struct {
+	capacity int // number of elements to allocate room for
+}
+
+// newOptions returns the defaults updated by opts.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCapacity allocates room for n elements up front, so building a set of
+// known size does not repeatedly grow it. Negative values are ignored.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = max(n, 0)
+	}
+}
@@ -0,0 +1,27 @@
+// Package hash_set provides a hash set for element types that need not be
+// comparable, such as slices or structs containing maps.
+// This file implements sorted snapshots of HashSet.
+
+package hash_set
+
+import (
+	"cmp"
+	"sort"
+)
+
+// SortedFunc returns the elements ordered by compare, which returns a
+// negative number, zero or a positive number when a sorts before, with or
+// after b, as slices.Compare does for slices of ordered types.
+func (s *HashSet[T]) SortedFunc(compare func(a, b T) int) []T {
+	elements := s.Slice()
+	sort.Slice(elements, func(i, j int) bool {
+		return compare(elements[i], elements[j]) < 0
+	})
+	return elements
+}
+
+// Sorted returns the elements of s in ascending order, for deterministic
+// output. NaNs sort first, as with cmp.Compare.
+func Sorted[T cmp.Ordered](s *HashSet[T]) []T {
+	return s.SortedFunc(cmp.Compare[T])
+}
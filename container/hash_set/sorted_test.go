@@ -0,0 +1,36 @@
+package hash_set
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSorted(t *testing.T) {
+	s := NewHashSet[float64](nil)
+	for _, v := range []float64{3, -1, math.NaN(), 2, 0} {
+		s.Add(v)
+	}
+	got := Sorted(s)
+	if len(got) != 5 || !math.IsNaN(got[0]) || !reflect.DeepEqual(got[1:], []float64{-1, 0, 2, 3}) {
+		t.Errorf("Expected [NaN -1 0 2 3], got %v", got)
+	}
+}
+
+func TestSortedFunc(t *testing.T) {
+	s := NewHashSet[[]int](nil)
+	for _, v := range [][]int{{2}, {1, 5}, {1}, {}} {
+		s.Add(v)
+	}
+	// Shorter slices first, then by first element
+	got := s.SortedFunc(func(a, b []int) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return a[0] - b[0]
+	})
+	want := [][]int{{}, {1}, {2}, {1, 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !go1.24
+// +build !go1.24
+
+package hashing
+
+import (
+	"hash/maphash"
+	"reflect"
+)
+
+// comparableHashFunc returns a hash function for comparable values.
+// maphash.Comparable is not available before go1.24, so values are walked
+// with reflection using == semantics.
+func comparableHashFunc[T comparable](seed maphash.Seed) func(T) uint64 {
+	return func(v T) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		w := &valueWriter{h: &h}
+		w.write(reflect.ValueOf(&v).Elem())
+		return h.Sum64()
+	}
+}
@@ -0,0 +1,16 @@
+//go:build go1.24
+// +build go1.24
+
+package hashing
+
+import (
+	"hash/maphash"
+)
+
+// comparableHashFunc returns a hash function for comparable values (go1.24),
+// backed by maphash.Comparable.
+func comparableHashFunc[T comparable](seed maphash.Seed) func(T) uint64 {
+	return func(v T) uint64 {
+		return maphash.Comparable(seed, v)
+	}
+}
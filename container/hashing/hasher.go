@@ -0,0 +1,97 @@
+// Package hashing provides Hasher and Eq abstractions so hash-based
+// containers can store user-defined types without stringifying them.
+//
+// Hashers are seeded with hash/maphash. String, Bytes, Comparable and
+// Reflect make a new seed on every call, so two Hashers they return disagree
+// on the hash of the same value even within one process; share a single
+// Hasher, or use For, whose default Hasher is created once per type. Hash
+// values always differ between processes and must not be persisted. Built-in hashers cover strings,
+// byte slices and comparable types; Reflect handles arbitrary types
+// (slices, maps, nested structs) by walking them with reflection. Custom
+// hashers can be registered per type and looked up with For.
+package hashing
+
+import (
+	"hash/maphash"
+	"reflect"
+)
+
+// Eq reports whether a and b are equal.
+type Eq[T any] func(a, b T) bool
+
+// Hasher hashes values of type T and decides equality between them.
+// Implementations must guarantee that Equal(a, b) implies Hash(a) == Hash(b).
+type Hasher[T any] interface {
+	// Hash returns the hash of v.
+	Hash(v T) uint64
+
+	// Equal reports whether a and b are equal.
+	Equal(a, b T) bool
+}
+
+// funcHasher adapts a pair of functions to the Hasher interface.
+type funcHasher[T any] struct {
+	hash  func(T) uint64
+	equal Eq[T]
+}
+
+func (h funcHasher[T]) Hash(v T) uint64 {
+	return h.hash(v)
+}
+
+func (h funcHasher[T]) Equal(a, b T) bool {
+	return h.equal(a, b)
+}
+
+// Func builds a Hasher from a hash function and an equality function.
+func Func[T any](hash func(T) uint64, equal Eq[T]) Hasher[T] {
+	return funcHasher[T]{hash: hash, equal: equal}
+}
+
+// String returns a maphash-seeded Hasher for strings.
+// Each call uses a new seed.
+func String() Hasher[string] {
+	seed := maphash.MakeSeed()
+	return Func(func(s string) uint64 {
+		return maphash.String(seed, s)
+	}, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Bytes returns a maphash-seeded Hasher for byte slices, comparing by content.
+// Each call uses a new seed.
+func Bytes() Hasher[[]byte] {
+	seed := maphash.MakeSeed()
+	return Func(func(b []byte) uint64 {
+		return maphash.Bytes(seed, b)
+	}, func(a, b []byte) bool {
+		return string(a) == string(b)
+	})
+}
+
+// Comparable returns a maphash-seeded Hasher for comparable types, with
+// equality defined by ==. Pointers, channels and interfaces holding them are
+// hashed by identity, exactly as == compares them. Each call uses a new seed.
+func Comparable[T comparable]() Hasher[T] {
+	return Func(comparableHashFunc[T](maphash.MakeSeed()), func(a, b T) bool {
+		return a == b
+	})
+}
+
+// Reflect returns a Hasher for any type, using deep equality as defined by
+// reflect.DeepEqual. Slices, arrays, maps, structs and pointers are hashed
+// by content. It is slower than a hand-written Hasher and is intended as a
+// fallback for types without one. Each call uses a new seed.
+func Reflect[T any]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return Func(func(v T) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		w := &valueWriter{h: &h, deep: true}
+		w.write(reflect.ValueOf(&v).Elem())
+		return h.Sum64()
+	}, func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
@@ -0,0 +1,110 @@
+package hashing
+
+import (
+	"math"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+type document struct {
+	Title string
+	Tags  []string
+	Meta  map[string]int
+	Next  *document
+}
+
+func TestStringAndBytes(t *testing.T) {
+	s := String()
+	if s.Hash("hello") != s.Hash("hello") {
+		t.Error("Expected equal strings to hash equally")
+	}
+	if !s.Equal("a", "a") || s.Equal("a", "b") {
+		t.Error("String Equal returned unexpected result")
+	}
+
+	b := Bytes()
+	if b.Hash([]byte("hello")) != b.Hash([]byte("hello")) {
+		t.Error("Expected equal byte slices to hash equally")
+	}
+	if !b.Equal([]byte("x"), []byte("x")) || b.Equal([]byte("x"), []byte("y")) {
+		t.Error("Bytes Equal returned unexpected result")
+	}
+}
+
+func TestComparable(t *testing.T) {
+	h := Comparable[point]()
+	if h.Hash(point{1, 2}) != h.Hash(point{1, 2}) {
+		t.Error("Expected equal structs to hash equally")
+	}
+	if h.Hash(point{1, 2}) == h.Hash(point{2, 1}) {
+		t.Error("Expected different structs to hash differently")
+	}
+	if !h.Equal(point{1, 2}, point{1, 2}) || h.Equal(point{1, 2}, point{2, 1}) {
+		t.Error("Comparable Equal returned unexpected result")
+	}
+
+	// +0 and -0 compare equal, so they must hash equally
+	f := Comparable[float64]()
+	if f.Hash(0) != f.Hash(math.Copysign(0, -1)) {
+		t.Error("Expected +0 and -0 to hash equally")
+	}
+
+	// Interfaces holding equal dynamic values
+	a := Comparable[any]()
+	if a.Hash(any(42)) != a.Hash(any(42)) {
+		t.Error("Expected equal interface values to hash equally")
+	}
+}
+
+func TestReflect(t *testing.T) {
+	h := Reflect[document]()
+	d1 := document{Title: "a", Tags: []string{"x", "y"}, Meta: map[string]int{"k1": 1, "k2": 2}}
+	d2 := document{Title: "a", Tags: []string{"x", "y"}, Meta: map[string]int{"k2": 2, "k1": 1}}
+	d3 := document{Title: "a", Tags: []string{"y", "x"}, Meta: map[string]int{"k1": 1, "k2": 2}}
+
+	if !h.Equal(d1, d2) {
+		t.Error("Expected deeply equal documents to be equal")
+	}
+	if h.Hash(d1) != h.Hash(d2) {
+		t.Error("Expected deeply equal documents to hash equally")
+	}
+	if h.Equal(d1, d3) || h.Hash(d1) == h.Hash(d3) {
+		t.Error("Expected documents with different tag order to differ")
+	}
+
+	// Pointers are followed, and cycles terminate
+	d1.Next = &document{Title: "next"}
+	d2.Next = &document{Title: "next"}
+	if h.Hash(d1) != h.Hash(d2) {
+		t.Error("Expected documents with equal pointees to hash equally")
+	}
+	cyclic := &document{Title: "loop"}
+	cyclic.Next = cyclic
+	_ = h.Hash(*cyclic)
+}
+
+func TestFunc(t *testing.T) {
+	// Case-insensitive ASCII hasher built from functions
+	lower := func(s string) string {
+		b := []byte(s)
+		for i, c := range b {
+			if 'A' <= c && c <= 'Z' {
+				b[i] = c + 'a' - 'A'
+			}
+		}
+		return string(b)
+	}
+	base := String()
+	h := Func(func(s string) uint64 {
+		return base.Hash(lower(s))
+	}, func(a, b string) bool {
+		return lower(a) == lower(b)
+	})
+
+	if !h.Equal("Go", "gO") || h.Hash("Go") != h.Hash("gO") {
+		t.Error("Expected custom hasher to ignore case")
+	}
+}
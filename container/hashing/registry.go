@@ -0,0 +1,74 @@
+// Package hashing provides Hasher and Eq abstractions.
+// This file implements the per-type Hasher registry.
+
+package hashing
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[reflect.Type]any) // reflect.Type -> Hasher[T]
+
+	defaultsMu sync.Mutex
+	defaults   = make(map[reflect.Type]any) // reflect.Type -> default Hasher[T]
+)
+
+// typeOf returns the reflect.Type of T, including interface types.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Register installs h as the Hasher returned by For[T]. Registering a type
+// again replaces the previous Hasher. It is typically called from init.
+func Register[T any](h Hasher[T]) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[typeOf[T]()] = h
+}
+
+// Unregister removes the Hasher registered for T, if any.
+func Unregister[T any]() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, typeOf[T]())
+}
+
+// For returns the Hasher registered for T, or a default one:
+// String for string, Bytes for []byte, and Reflect otherwise.
+//
+// The default Hasher is created once per type and shared by every call, so
+// hashes from For[T] agree within a process even when T has no registered
+// Hasher. They still differ between processes.
+func For[T any]() Hasher[T] {
+	t := typeOf[T]()
+	registryMu.RLock()
+	h, ok := registry[t]
+	registryMu.RUnlock()
+	if ok {
+		return h.(Hasher[T])
+	}
+
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	if h, ok := defaults[t]; ok {
+		return h.(Hasher[T])
+	}
+	d := defaultFor[T]()
+	defaults[t] = d
+	return d
+}
+
+// defaultFor returns a newly seeded default Hasher for T.
+func defaultFor[T any]() Hasher[T] {
+	var zero T
+	switch any(zero).(type) {
+	case string:
+		return any(String()).(Hasher[T])
+	case []byte:
+		return any(Bytes()).(Hasher[T])
+	}
+	return Reflect[T]()
+}
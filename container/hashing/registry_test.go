@@ -0,0 +1,76 @@
+package hashing
+
+import (
+	"testing"
+)
+
+type userID struct {
+	Tenant string
+	ID     int
+}
+
+func TestRegistry(t *testing.T) {
+	calls := 0
+	custom := Func(func(u userID) uint64 {
+		calls++
+		return uint64(u.ID)
+	}, func(a, b userID) bool {
+		return a == b
+	})
+
+	Register[userID](custom)
+	defer Unregister[userID]()
+
+	h := For[userID]()
+	if got := h.Hash(userID{"t", 7}); got != 7 {
+		t.Errorf("Expected registered hasher to return 7, got %d", got)
+	}
+	if calls != 1 {
+		t.Errorf("Expected registered hasher to be called once, got %d", calls)
+	}
+
+	Unregister[userID]()
+	h = For[userID]()
+	h.Hash(userID{"t", 7})
+	if calls != 1 {
+		t.Error("Expected default hasher after Unregister")
+	}
+	if h.Hash(userID{"t", 7}) != h.Hash(userID{"t", 7}) {
+		t.Error("Expected default hasher to be deterministic")
+	}
+}
+
+func TestForDefaults(t *testing.T) {
+	if _, ok := For[string]().(funcHasher[string]); !ok {
+		t.Error("Expected a function hasher for string")
+	}
+	b := For[[]byte]()
+	if !b.Equal([]byte("a"), []byte("a")) {
+		t.Error("Expected default []byte hasher to compare contents")
+	}
+	s := For[[]int]()
+	if s.Hash([]int{1, 2}) != s.Hash([]int{1, 2}) || !s.Equal([]int{1, 2}, []int{1, 2}) {
+		t.Error("Expected reflection fallback for []int")
+	}
+}
+
+func TestForSharesDefault(t *testing.T) {
+	a, b := For[string](), For[string]()
+	if a.Hash("x") != b.Hash("x") {
+		t.Error("Expected default string hashers from For to agree")
+	}
+	p, q := For[userID](), For[userID]()
+	if p.Hash(userID{"t", 7}) != q.Hash(userID{"t", 7}) {
+		t.Error("Expected default reflect hashers from For to agree")
+	}
+
+	custom := Func(func(u userID) uint64 { return 1 }, func(a, b userID) bool { return a == b })
+	Register[userID](custom)
+	if For[userID]().Hash(userID{"t", 7}) != 1 {
+		t.Error("Expected registered hasher to take precedence over cached default")
+	}
+	Unregister[userID]()
+	if For[userID]().Hash(userID{"t", 7}) != p.Hash(userID{"t", 7}) {
+		t.Error("Expected cached default hasher after Unregister")
+	}
+}
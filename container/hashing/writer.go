@@ -0,0 +1,125 @@
+// Package hashing provides Hasher and Eq abstractions.
+// This file implements the reflection-based value walker used by the
+// fallback hashers.
+
+package hashing
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// valueWriter feeds the contents of a reflect.Value into a maphash.Hash.
+//
+// With deep set, pointers are followed and maps are hashed by content,
+// matching reflect.DeepEqual. Without it, pointers and channels are hashed
+// by address, matching ==.
+type valueWriter struct {
+	h       *maphash.Hash
+	deep    bool
+	visited map[uintptr]struct{} // pointers on the current path, to stop at cycles
+	buf     [8]byte
+}
+
+func (w *valueWriter) writeUint(u uint64) {
+	binary.LittleEndian.PutUint64(w.buf[:], u)
+	w.h.Write(w.buf[:])
+}
+
+func (w *valueWriter) writeFloat(f float64) {
+	if f == 0 {
+		f = 0 // -0 == +0, so both must hash the same
+	}
+	w.writeUint(math.Float64bits(f))
+}
+
+func (w *valueWriter) write(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		w.writeUint(0)
+	case reflect.Bool:
+		if v.Bool() {
+			w.writeUint(1)
+		} else {
+			w.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		w.writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		w.writeFloat(real(c))
+		w.writeFloat(imag(c))
+	case reflect.String:
+		w.writeUint(uint64(v.Len()))
+		w.h.WriteString(v.String())
+	case reflect.Array, reflect.Slice:
+		w.writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			w.write(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			w.write(v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			w.writeUint(0)
+			return
+		}
+		w.h.WriteString(v.Elem().Type().String())
+		w.write(v.Elem())
+	case reflect.Pointer:
+		if !w.deep || v.IsNil() {
+			w.writeUint(uint64(v.Pointer()))
+			return
+		}
+		w.writeIndirect(v.Pointer(), func() { w.write(v.Elem()) })
+	case reflect.Map:
+		if v.IsNil() {
+			w.writeUint(0)
+			return
+		}
+		w.writeUint(uint64(v.Len()))
+		w.writeIndirect(v.Pointer(), func() { w.writeMap(v) })
+	default:
+		// Channels, funcs and unsafe pointers: identity only
+		w.writeUint(uint64(v.Pointer()))
+	}
+}
+
+// writeIndirect runs fn unless ptr is already on the current path, which
+// happens only for cyclic structures.
+func (w *valueWriter) writeIndirect(ptr uintptr, fn func()) {
+	if w.visited == nil {
+		w.visited = make(map[uintptr]struct{})
+	}
+	if _, ok := w.visited[ptr]; ok {
+		w.writeUint(math.MaxUint64)
+		return
+	}
+	w.visited[ptr] = struct{}{}
+	fn()
+	delete(w.visited, ptr)
+}
+
+// writeMap hashes map entries independently of iteration order by summing
+// per-entry hashes computed with the same seed.
+func (w *valueWriter) writeMap(v reflect.Value) {
+	var sum uint64
+	iter := v.MapRange()
+	for iter.Next() {
+		var h maphash.Hash
+		h.SetSeed(w.h.Seed())
+		entry := &valueWriter{h: &h, deep: w.deep, visited: w.visited}
+		entry.write(iter.Key())
+		entry.write(iter.Value())
+		sum += h.Sum64()
+	}
+	w.writeUint(sum)
+}
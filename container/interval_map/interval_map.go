@@ -0,0 +1,164 @@
+// Package interval_map provides a map from half-open key ranges to values
+// that keeps adjacent ranges with equal values coalesced.
+
+package interval_map
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/ordered_map"
+)
+
+// Interval is a half-open key range [Lo, Hi) mapped to Value.
+type Interval[K cmp.Ordered, V comparable] struct {
+	Lo    K
+	Hi    K
+	Value V
+}
+
+// segment is the tree payload for the interval starting at its tree key.
+type segment[K cmp.Ordered, V comparable] struct {
+	hi    K
+	value V
+}
+
+// IntervalMap assigns values to half-open key ranges [lo, hi).
+// Stored intervals never overlap, and two intervals that touch and carry
+// equal values are always merged into one, so the map holds the minimal
+// set of intervals describing the assignment.
+//
+// Intervals are kept in a Red-Black Tree keyed by their lower bound, so
+// point lookups take O(log n) and range updates O((k+1) log n) where k is
+// the number of intervals overlapped.
+type IntervalMap[K cmp.Ordered, V comparable] struct {
+	tree *ordered_map.RedBlackTree[K, segment[K, V]]
+}
+
+// NewIntervalMap creates a new empty IntervalMap.
+func NewIntervalMap[K cmp.Ordered, V comparable]() *IntervalMap[K, V] {
+	return &IntervalMap[K, V]{
+		tree: ordered_map.NewRedBlackTree[K, segment[K, V]](),
+	}
+}
+
+// Len returns the number of stored intervals after coalescing.
+func (m *IntervalMap[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// Clear removes all intervals.
+func (m *IntervalMap[K, V]) Clear() {
+	m.tree.Clear()
+}
+
+// Get returns the value assigned to key.
+// Returns the zero value and false if no interval contains key.
+func (m *IntervalMap[K, V]) Get(key K) (V, bool) {
+	if _, seg, ok := m.tree.Floor(key); ok && cmp.Less(key, seg.hi) {
+		return seg.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Assign maps every key in [lo, hi) to value, overwriting any previous
+// assignment in that range and merging with neighbours holding the same
+// value. Does nothing if lo >= hi.
+func (m *IntervalMap[K, V]) Assign(lo, hi K, value V) {
+	if !cmp.Less(lo, hi) {
+		return
+	}
+	m.carve(lo, hi)
+
+	// Coalesce with the interval ending at lo and the one starting at hi
+	if start, seg, ok := m.tree.Lower(lo); ok && seg.hi == lo && seg.value == value {
+		m.tree.Delete(start)
+		lo = start
+	}
+	if seg, ok := m.tree.Get(hi); ok && seg.value == value {
+		m.tree.Delete(hi)
+		hi = seg.hi
+	}
+	m.tree.Set(lo, segment[K, V]{hi: hi, value: value})
+}
+
+// Remove clears any assignment in [lo, hi), splitting intervals that
+// extend past either bound. Does nothing if lo >= hi.
+func (m *IntervalMap[K, V]) Remove(lo, hi K) {
+	if !cmp.Less(lo, hi) {
+		return
+	}
+	m.carve(lo, hi)
+}
+
+// carve removes all coverage of [lo, hi), keeping the parts of overlapping
+// intervals that lie outside it.
+func (m *IntervalMap[K, V]) carve(lo, hi K) {
+	// An interval starting before lo may reach into, or past, the range
+	if start, seg, ok := m.tree.Lower(lo); ok && cmp.Less(lo, seg.hi) {
+		m.tree.Set(start, segment[K, V]{hi: lo, value: seg.value})
+		if cmp.Less(hi, seg.hi) {
+			m.tree.Set(hi, seg)
+			return
+		}
+	}
+
+	// Intervals starting inside the range are removed; the last one may
+	// extend past hi and leaves a tail behind
+	var starts []K
+	var tail segment[K, V]
+	hasTail := false
+	m.tree.RangeFrom(lo, func(start K, seg segment[K, V]) bool {
+		if !cmp.Less(start, hi) {
+			return false
+		}
+		starts = append(starts, start)
+		if cmp.Less(hi, seg.hi) {
+			tail, hasTail = seg, true
+		}
+		return true
+	})
+	for _, start := range starts {
+		m.tree.Delete(start)
+	}
+	if hasTail {
+		m.tree.Set(hi, tail)
+	}
+}
+
+// Range calls fn for every interval in ascending order.
+// If fn returns false, the iteration stops.
+func (m *IntervalMap[K, V]) Range(fn func(lo, hi K, value V) bool) {
+	m.tree.Range(func(lo K, seg segment[K, V]) bool {
+		return fn(lo, seg.hi, seg.value)
+	})
+}
+
+// RangeOverlapping calls fn for every interval intersecting [lo, hi) in
+// ascending order, with bounds clipped to [lo, hi).
+// If fn returns false, the iteration stops.
+func (m *IntervalMap[K, V]) RangeOverlapping(lo, hi K, fn func(lo, hi K, value V) bool) {
+	if !cmp.Less(lo, hi) {
+		return
+	}
+	from := lo
+	if start, seg, ok := m.tree.Lower(lo); ok && cmp.Less(lo, seg.hi) {
+		from = start
+	}
+	m.tree.RangeFrom(from, func(start K, seg segment[K, V]) bool {
+		if !cmp.Less(start, hi) {
+			return false
+		}
+		return fn(max(start, lo), min(seg.hi, hi), seg.value)
+	})
+}
+
+// Intervals returns all intervals in ascending order.
+func (m *IntervalMap[K, V]) Intervals() []Interval[K, V] {
+	intervals := make([]Interval[K, V], 0, m.tree.Len())
+	m.Range(func(lo, hi K, value V) bool {
+		intervals = append(intervals, Interval[K, V]{Lo: lo, Hi: hi, Value: value})
+		return true
+	})
+	return intervals
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+// +build go1.23
+
+package interval_map
+
+import (
+	"iter"
+)
+
+// All returns an iterator over all intervals in ascending order.
+func (m *IntervalMap[K, V]) All() iter.Seq[Interval[K, V]] {
+	return func(yield func(Interval[K, V]) bool) {
+		m.Range(func(lo, hi K, value V) bool {
+			return yield(Interval[K, V]{Lo: lo, Hi: hi, Value: value})
+		})
+	}
+}
+
+// All returns an iterator over all ranges of the set in ascending order,
+// yielding the bounds of each half-open range.
+func (s *RangeSet[T]) All() iter.Seq2[T, T] {
+	return func(yield func(lo, hi T) bool) {
+		s.Range(yield)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+// +build go1.23
+
+package interval_map
+
+import (
+	"testing"
+)
+
+func TestIntervalMapAll(t *testing.T) {
+	m := NewIntervalMap[int, string]()
+	m.Assign(0, 5, "a")
+	m.Assign(7, 9, "b")
+
+	var got []Interval[int, string]
+	for iv := range m.All() {
+		got = append(got, iv)
+		break
+	}
+	if len(got) != 1 || got[0] != (Interval[int, string]{0, 5, "a"}) {
+		t.Errorf("Expected first interval [0, 5)=a, got %v", got)
+	}
+}
+
+func TestRangeSetAll(t *testing.T) {
+	s := NewRangeSet[int]()
+	s.AddRange(0, 2)
+	s.AddRange(4, 6)
+
+	var got []Span[int]
+	for lo, hi := range s.All() {
+		got = append(got, Span[int]{lo, hi})
+	}
+	if len(got) != 2 || got[1] != (Span[int]{4, 6}) {
+		t.Errorf("Expected [{0 2} {4 6}], got %v", got)
+	}
+}
@@ -0,0 +1,132 @@
+package interval_map
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIntervalMapAssign(t *testing.T) {
+	m := NewIntervalMap[int, string]()
+	m.Assign(0, 10, "a")
+	m.Assign(20, 30, "b")
+	m.Assign(5, 25, "c")
+
+	want := []Interval[int, string]{{0, 5, "a"}, {5, 25, "c"}, {25, 30, "b"}}
+	if got := m.Intervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	tests := []struct {
+		key  int
+		want string
+		ok   bool
+	}{
+		{-1, "", false}, {0, "a", true}, {4, "a", true}, {5, "c", true},
+		{24, "c", true}, {25, "b", true}, {29, "b", true}, {30, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := m.Get(tt.key); got != tt.want || ok != tt.ok {
+			t.Errorf("Get(%d) = %q, %v, want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Empty and inverted ranges are ignored
+	m.Assign(3, 3, "x")
+	m.Assign(8, 2, "x")
+	if m.Len() != 3 {
+		t.Errorf("Expected 3 intervals, got %d", m.Len())
+	}
+}
+
+func TestIntervalMapCoalesce(t *testing.T) {
+	m := NewIntervalMap[int, string]()
+	m.Assign(0, 10, "a")
+	m.Assign(10, 20, "a")
+	m.Assign(30, 40, "a")
+	if m.Len() != 2 {
+		t.Errorf("Expected touching intervals to merge, got %v", m.Intervals())
+	}
+
+	// Filling the gap merges all three
+	m.Assign(20, 30, "a")
+	want := []Interval[int, string]{{0, 40, "a"}}
+	if got := m.Intervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Overwriting the middle with a different value splits, restoring merges
+	m.Assign(15, 25, "b")
+	if m.Len() != 3 {
+		t.Errorf("Expected 3 intervals, got %v", m.Intervals())
+	}
+	m.Assign(15, 25, "a")
+	if got := m.Intervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestIntervalMapRemove(t *testing.T) {
+	m := NewIntervalMap[int, int]()
+	m.Assign(0, 100, 1)
+	m.Remove(10, 20)
+	m.Remove(90, 200)
+	m.Remove(-5, 1)
+
+	want := []Interval[int, int]{{1, 10, 1}, {20, 90, 1}}
+	if got := m.Intervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	var clipped []Interval[int, int]
+	m.RangeOverlapping(5, 25, func(lo, hi, v int) bool {
+		clipped = append(clipped, Interval[int, int]{lo, hi, v})
+		return true
+	})
+	if want := []Interval[int, int]{{5, 10, 1}, {20, 25, 1}}; !reflect.DeepEqual(clipped, want) {
+		t.Errorf("Expected %v, got %v", want, clipped)
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Expected 0 intervals after Clear, got %d", m.Len())
+	}
+}
+
+func TestIntervalMapRandom(t *testing.T) {
+	const domain = 64
+	m := NewIntervalMap[int, int]()
+	var ref [domain]int // 0 means unassigned
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 3000; i++ {
+		lo, hi := rng.Intn(domain), rng.Intn(domain+1)
+		if rng.Intn(4) == 0 {
+			m.Remove(lo, hi)
+			for k := lo; k < hi; k++ {
+				ref[k] = 0
+			}
+		} else {
+			v := rng.Intn(3) + 1
+			m.Assign(lo, hi, v)
+			for k := lo; k < hi; k++ {
+				ref[k] = v
+			}
+		}
+
+		for k := 0; k < domain; k++ {
+			got, ok := m.Get(k)
+			if ok != (ref[k] != 0) || got != ref[k] {
+				t.Fatalf("step %d: Get(%d) = %d, %v, want %d", i, k, got, ok, ref[k])
+			}
+		}
+		prev := Interval[int, int]{Lo: -1, Hi: -1}
+		m.Range(func(lo, hi, v int) bool {
+			if lo >= hi || lo < prev.Hi || (lo == prev.Hi && v == prev.Value) {
+				t.Fatalf("step %d: interval [%d, %d)=%d not minimal after %v", i, lo, hi, v, prev)
+			}
+			prev = Interval[int, int]{lo, hi, v}
+			return true
+		})
+	}
+}
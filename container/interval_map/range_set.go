@@ -0,0 +1,107 @@
+// Package interval_map provides a map from half-open key ranges to values
+// that keeps adjacent ranges with equal values coalesced.
+// This file implements RangeSet, a set of integers stored as disjoint ranges.
+
+package interval_map
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/constraints"
+)
+
+// Span is a half-open integer range [Lo, Hi).
+type Span[T constraints.Integer] struct {
+	Lo T
+	Hi T
+}
+
+// RangeSet is a set of integers stored as disjoint, non-touching half-open
+// ranges in an ordered tree, so runs of consecutive members cost O(1) space.
+// It is useful for tracking processed offsets and finding sequence gaps.
+type RangeSet[T constraints.Integer] struct {
+	m *IntervalMap[T, struct{}]
+}
+
+// NewRangeSet creates a new empty RangeSet.
+func NewRangeSet[T constraints.Integer]() *RangeSet[T] {
+	return &RangeSet[T]{m: NewIntervalMap[T, struct{}]()}
+}
+
+// Len returns the number of disjoint ranges in the set.
+func (s *RangeSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// Clear removes all members.
+func (s *RangeSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// AddRange adds every integer in [lo, hi). Does nothing if lo >= hi.
+func (s *RangeSet[T]) AddRange(lo, hi T) {
+	s.m.Assign(lo, hi, struct{}{})
+}
+
+// RemoveRange removes every integer in [lo, hi). Does nothing if lo >= hi.
+func (s *RangeSet[T]) RemoveRange(lo, hi T) {
+	s.m.Remove(lo, hi)
+}
+
+// Add adds x to the set. x must be less than the maximum value of T.
+func (s *RangeSet[T]) Add(x T) {
+	s.AddRange(x, x+1)
+}
+
+// Remove removes x from the set. x must be less than the maximum value of T.
+func (s *RangeSet[T]) Remove(x T) {
+	s.RemoveRange(x, x+1)
+}
+
+// Contains returns true if x is in the set.
+func (s *RangeSet[T]) Contains(x T) bool {
+	_, ok := s.m.Get(x)
+	return ok
+}
+
+// ContainsRange returns true if every integer in [lo, hi) is in the set.
+// An empty range is always contained.
+func (s *RangeSet[T]) ContainsRange(lo, hi T) bool {
+	if !cmp.Less(lo, hi) {
+		return true
+	}
+	_, seg, ok := s.m.tree.Floor(lo)
+	return ok && !cmp.Less(seg.hi, hi)
+}
+
+// Complement returns the integers in [lo, hi) that are not in the set,
+// such as the gaps in a run of sequence numbers.
+func (s *RangeSet[T]) Complement(lo, hi T) *RangeSet[T] {
+	c := NewRangeSet[T]()
+	next := lo
+	s.m.RangeOverlapping(lo, hi, func(start, end T, _ struct{}) bool {
+		c.AddRange(next, start)
+		next = end
+		return true
+	})
+	c.AddRange(next, hi)
+	return c
+}
+
+// Range calls fn for every range [lo, hi) in ascending order.
+// If fn returns false, the iteration stops.
+func (s *RangeSet[T]) Range(fn func(lo, hi T) bool) {
+	s.m.Range(func(lo, hi T, _ struct{}) bool {
+		return fn(lo, hi)
+	})
+}
+
+// Spans returns all ranges in ascending order.
+func (s *RangeSet[T]) Spans() []Span[T] {
+	spans := make([]Span[T], 0, s.Len())
+	s.Range(func(lo, hi T) bool {
+		spans = append(spans, Span[T]{Lo: lo, Hi: hi})
+		return true
+	})
+	return spans
+}
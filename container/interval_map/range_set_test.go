@@ -0,0 +1,63 @@
+package interval_map
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRangeSetBasic(t *testing.T) {
+	s := NewRangeSet[uint64]()
+	for _, x := range []uint64{1, 2, 3, 7, 5, 6} {
+		s.Add(x)
+	}
+	want := []Span[uint64]{{1, 4}, {5, 8}}
+	if got := s.Spans(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	s.Add(4)
+	if s.Len() != 1 || !s.ContainsRange(1, 8) {
+		t.Errorf("Expected a single range [1, 8), got %v", s.Spans())
+	}
+	if s.Contains(0) || !s.Contains(1) || !s.Contains(7) || s.Contains(8) {
+		t.Error("Unexpected Contains result at range bounds")
+	}
+
+	s.Remove(4)
+	s.RemoveRange(6, 100)
+	want = []Span[uint64]{{1, 4}, {5, 6}}
+	if got := s.Spans(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if s.ContainsRange(1, 6) || !s.ContainsRange(2, 4) || !s.ContainsRange(9, 9) {
+		t.Error("Unexpected ContainsRange result")
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Contains(1) {
+		t.Error("Expected empty set after Clear")
+	}
+}
+
+func TestRangeSetComplement(t *testing.T) {
+	s := NewRangeSet[int]()
+	s.AddRange(-5, 0)
+	s.AddRange(3, 6)
+	s.AddRange(10, 20)
+
+	tests := []struct {
+		lo, hi int
+		want   []Span[int]
+	}{
+		{-10, 25, []Span[int]{{-10, -5}, {0, 3}, {6, 10}, {20, 25}}},
+		{-3, 12, []Span[int]{{0, 3}, {6, 10}}},
+		{11, 15, []Span[int]{}},
+		{21, 23, []Span[int]{{21, 23}}},
+		{5, 5, []Span[int]{}},
+	}
+	for _, tt := range tests {
+		if got := s.Complement(tt.lo, tt.hi).Spans(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complement(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package iterx provides combinators over iter.Seq and iter.Seq2, such as
+// Map, Filter and Take, for composing the iterators exposed by the other
+// containers without manual loops. It requires go1.23.
+package iterx
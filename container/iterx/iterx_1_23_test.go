@@ -0,0 +1,111 @@
+//go:build go1.23
+// +build go1.23
+
+package iterx
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// naturals returns an infinite iterator over 0, 1, 2, ...
+func naturals() func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	odd := func(v int) bool { return v%2 == 1 }
+	square := func(v int) int { return v * v }
+	got := slices.Collect(Map(Filter(slices.Values([]int{1, 2, 3, 4, 5}), odd), square))
+	if !slices.Equal(got, []int{1, 9, 25}) {
+		t.Errorf("Expected [1 9 25], got %v", got)
+	}
+}
+
+func TestTakeDrop(t *testing.T) {
+	if got := slices.Collect(Take(naturals(), 3)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Take: expected [0 1 2], got %v", got)
+	}
+	if got := slices.Collect(Take(naturals(), 0)); len(got) != 0 {
+		t.Errorf("Take 0: expected nothing, got %v", got)
+	}
+	if got := slices.Collect(Take(Drop(naturals(), 5), 2)); !slices.Equal(got, []int{5, 6}) {
+		t.Errorf("Drop: expected [5 6], got %v", got)
+	}
+	if got := slices.Collect(Drop(slices.Values([]int{1, 2}), -1)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Drop -1: expected [1 2], got %v", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	got := slices.Collect(Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	if !reflect.DeepEqual(got, [][]int{{1, 2}, {3, 4}, {5}}) {
+		t.Errorf("Expected [[1 2] [3 4] [5]], got %v", got)
+	}
+	got = slices.Collect(Chunk(slices.Values([]int{1, 2}), 0))
+	if !reflect.DeepEqual(got, [][]int{{1}, {2}}) {
+		t.Errorf("Expected n to be clamped to 1, got %v", got)
+	}
+	if got := slices.Collect(Chunk(slices.Values([]int(nil)), 3)); len(got) != 0 {
+		t.Errorf("Expected no chunks, got %v", got)
+	}
+	for chunk := range Chunk(naturals(), 4) {
+		if !slices.Equal(chunk, []int{0, 1, 2, 3}) {
+			t.Errorf("Expected the first chunk [0 1 2 3], got %v", chunk)
+		}
+		break
+	}
+}
+
+func TestConcatEnumerate(t *testing.T) {
+	seq := Concat(slices.Values([]string{"a"}), slices.Values([]string(nil)), slices.Values([]string{"b", "c"}))
+	var got []string
+	for i, v := range Enumerate(seq) {
+		got = append(got, string(rune('0'+i))+v)
+		if i == 1 {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"0a", "1b"}) {
+		t.Errorf("Expected [0a 1b], got %v", got)
+	}
+}
+
+func TestZip(t *testing.T) {
+	got := maps.Collect(Zip(slices.Values([]string{"a", "b", "c"}), naturals()))
+	if !reflect.DeepEqual(got, map[string]int{"a": 0, "b": 1, "c": 2}) {
+		t.Errorf("Expected map[a:0 b:1 c:2], got %v", got)
+	}
+	n := 0
+	for range Zip(naturals(), slices.Values([]int{7, 8})) {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("Expected Zip to stop with the shorter iterator after 2, got %d", n)
+	}
+}
+
+func TestKeysValuesReduce(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	keys := slices.Collect(Keys(maps.All(m)))
+	sort.Strings(keys)
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("Expected keys [a b c], got %v", keys)
+	}
+	sum := Reduce(Values(maps.All(m)), 0, func(acc, v int) int { return acc + v })
+	if sum != 6 {
+		t.Errorf("Expected the values to sum to 6, got %d", sum)
+	}
+	joined := Reduce(slices.Values([]int{1, 2, 3}), "", func(acc string, v int) string {
+		return acc + string(rune('0'+v))
+	})
+	if joined != "123" {
+		t.Errorf("Expected 123, got %q", joined)
+	}
+}
@@ -0,0 +1,185 @@
+// Package min_hash provides MinHash signatures for estimating the Jaccard
+// similarity of sets.
+package min_hash
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/feepwang/br/container/hashing"
+)
+
+// mersenne61 is the prime 2^61-1, the modulus of the permutations.
+const mersenne61 = 1<<61 - 1
+
+// defaultSeed seeds the permutations unless WithSeed is given, so that
+// signatures built independently are comparable by default.
+const defaultSeed = 0x5eed
+
+// ErrIncompatible is returned when combining MinHashes built with different
+// permutations.
+var ErrIncompatible = errors.New("min_hash: incompatible signatures")
+
+// Option configures a MinHash.
+type Option func(*options)
+
+type options struct {
+	seed uint64
+}
+
+// WithSeed sets the seed of the permutations. MinHashes are only comparable
+// when they were created with the same seed and number of permutations.
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// permutation is the hash function x -> (a·x + b) mod 2^61-1.
+type permutation struct {
+	a, b uint64
+}
+
+// MinHash summarizes a set by the smallest hash of its items under each of
+// k random permutations. For two sets A and B, a permutation yields the same
+// minimum with probability |A∩B| / |A∪B|, their Jaccard similarity, so the
+// fraction of matching minima estimates it with a standard error of about
+// 1/√k.
+//
+// The signature has a fixed size of k values however many items are added,
+// which suits near-duplicate detection over large collections of documents.
+type MinHash[T any] struct {
+	hash  func(T) uint64
+	seed  uint64
+	perms []permutation
+	mins  []uint64 // smallest permuted hash per permutation, MaxUint64 if empty
+}
+
+// NewMinHash creates an empty MinHash with k permutations. k is raised to at
+// least 1.
+//
+// hash maps items to 64-bit hashes; if nil, hashing.For[T] is used. Two
+// MinHashes are only comparable when they hash items identically.
+// hashing.For[T] returns the same Hasher for every MinHash in a process, so
+// default-hashed MinHashes can be compared with each other, but its seed
+// changes between processes; signatures kept or compared across processes
+// need a deterministic hash such as one built on hash/fnv.
+func NewMinHash[T any](k int, hash func(T) uint64, opts ...Option) *MinHash[T] {
+	if hash == nil {
+		hash = hashing.For[T]().Hash
+	}
+	o := options{seed: defaultSeed}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	k = max(k, 1)
+	m := &MinHash[T]{
+		hash:  hash,
+		seed:  o.seed,
+		perms: make([]permutation, k),
+		mins:  make([]uint64, k),
+	}
+	state := o.seed
+	for i := range m.perms {
+		m.perms[i] = permutation{
+			a: splitMix(&state)%(mersenne61-1) + 1,
+			b: splitMix(&state) % mersenne61,
+		}
+	}
+	m.Clear()
+	return m
+}
+
+// splitMix advances state and returns the next SplitMix64 output.
+func splitMix(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	x := *state
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// apply returns (a·x + b) mod 2^61-1 for x < 2^61.
+func (p permutation) apply(x uint64) uint64 {
+	hi, lo := bits.Mul64(p.a, x)
+	lo, carry := bits.Add64(lo, p.b, 0)
+	hi += carry
+	// 2^64 ≡ 2^3 (mod 2^61-1), so fold the high bits down
+	r := (lo & mersenne61) + (lo >> 61) + (hi << 3)
+	r = (r & mersenne61) + (r >> 61)
+	if r >= mersenne61 {
+		r -= mersenne61
+	}
+	return r
+}
+
+// K returns the number of permutations.
+func (m *MinHash[T]) K() int {
+	return len(m.perms)
+}
+
+// Add adds item to the summarized set. Adding an item again has no effect.
+func (m *MinHash[T]) Add(item T) {
+	x := m.hash(item) % mersenne61
+	for i, p := range m.perms {
+		if h := p.apply(x); h < m.mins[i] {
+			m.mins[i] = h
+		}
+	}
+}
+
+// Empty reports whether no item has been added.
+func (m *MinHash[T]) Empty() bool {
+	return m.mins[0] == math.MaxUint64
+}
+
+// Signature returns a copy of the signature: the smallest permuted hash of
+// the items under each permutation, or math.MaxUint64 for all of them if no
+// item has been added.
+func (m *MinHash[T]) Signature() []uint64 {
+	return append([]uint64(nil), m.mins...)
+}
+
+// compatible reports whether m and other use the same permutations.
+func (m *MinHash[T]) compatible(other *MinHash[T]) bool {
+	return m.seed == other.seed && len(m.perms) == len(other.perms)
+}
+
+// Merge makes m summarize the union of its set and the set of other. It
+// returns ErrIncompatible, leaving m unchanged, if the two use different
+// permutations.
+func (m *MinHash[T]) Merge(other *MinHash[T]) error {
+	if !m.compatible(other) {
+		return ErrIncompatible
+	}
+	for i, h := range other.mins {
+		m.mins[i] = min(m.mins[i], h)
+	}
+	return nil
+}
+
+// Similarity returns an estimate of the Jaccard similarity between the sets
+// summarized by m and other, from 0 for disjoint sets to 1 for equal ones.
+// Two empty sets are considered equal. It returns ErrIncompatible if the two
+// use different permutations.
+func (m *MinHash[T]) Similarity(other *MinHash[T]) (float64, error) {
+	if !m.compatible(other) {
+		return 0, ErrIncompatible
+	}
+	matches := 0
+	for i, h := range m.mins {
+		if h == other.mins[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(m.mins)), nil
+}
+
+// Clear resets the MinHash to the empty set.
+func (m *MinHash[T]) Clear() {
+	for i := range m.mins {
+		m.mins[i] = math.MaxUint64
+	}
+}
@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+// Package min_hash provides MinHash signatures for estimating the Jaccard
+// similarity of sets.
+// This file implements the iterator-based methods of MinHash (go1.23).
+
+package min_hash
+
+import (
+	"iter"
+)
+
+// AddSeq adds every item of seq, such as the keys of a map or the items of
+// a set, to the summarized set.
+func (m *MinHash[T]) AddSeq(seq iter.Seq[T]) {
+	for item := range seq {
+		m.Add(item)
+	}
+}
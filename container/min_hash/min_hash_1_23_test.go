@@ -0,0 +1,23 @@
+//go:build go1.23
+// +build go1.23
+
+package min_hash
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMinHashAddSeq(t *testing.T) {
+	set := map[string]bool{"a": true, "b": true, "c": true}
+	fromSeq := NewMinHash[string](32, fnvHash)
+	fromSeq.AddSeq(maps.Keys(set))
+
+	fromAdd := NewMinHash[string](32, fnvHash)
+	for _, item := range []string{"c", "a", "b"} {
+		fromAdd.Add(item)
+	}
+	if s, _ := fromSeq.Similarity(fromAdd); s != 1 {
+		t.Errorf("Expected similarity 1, got %v", s)
+	}
+}
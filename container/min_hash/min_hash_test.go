@@ -0,0 +1,148 @@
+package min_hash
+
+import (
+	"hash/fnv"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// fnvHash is a deterministic hash for string items.
+func fnvHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// rangeSet returns a MinHash of the items "0" to "n-1" offset by start.
+func rangeSet(k, start, n int) *MinHash[string] {
+	m := NewMinHash[string](k, fnvHash)
+	for i := start; i < start+n; i++ {
+		m.Add(strconv.Itoa(i))
+	}
+	return m
+}
+
+func TestMinHashPermutation(t *testing.T) {
+	// apply must agree with arithmetic modulo 2^61-1
+	p := permutation{a: mersenne61 - 2, b: mersenne61 - 1}
+	for _, x := range []uint64{0, 1, 2, 12345, mersenne61 - 1} {
+		// (p-2)·x + (p-1) ≡ -2x - 1 (mod p)
+		want := (mersenne61 - (2*x+1)%mersenne61) % mersenne61
+		if got := p.apply(x); got != want {
+			t.Errorf("apply(%d): expected %d, got %d", x, want, got)
+		}
+	}
+}
+
+func TestMinHashSimilarity(t *testing.T) {
+	const k = 512
+	cases := []struct {
+		overlap int // items shared by two sets of 1000
+		want    float64
+	}{
+		{1000, 1},
+		{800, 800.0 / 1200},
+		{500, 500.0 / 1500},
+		{100, 100.0 / 1900},
+		{0, 0},
+	}
+	for _, c := range cases {
+		a := rangeSet(k, 0, 1000)
+		b := rangeSet(k, 1000-c.overlap, 1000)
+		got, err := a.Similarity(b)
+		if err != nil {
+			t.Fatalf("Similarity: %v", err)
+		}
+		// Allow four standard errors
+		if tolerance := 4 / math.Sqrt(k); math.Abs(got-c.want) > tolerance {
+			t.Errorf("overlap %d: expected similarity %.3f within %.3f, got %.3f", c.overlap, c.want, tolerance, got)
+		}
+	}
+}
+
+func TestMinHashDefaultHash(t *testing.T) {
+	a := NewMinHash[string](64, nil)
+	b := NewMinHash[string](64, nil)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		a.Add(s)
+		b.Add(s)
+	}
+
+	got, err := a.Similarity(b)
+	if err != nil {
+		t.Fatalf("Similarity: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("Expected similarity 1 for identical sets, got %.3f", got)
+	}
+}
+
+func TestMinHashDuplicatesAndEmpty(t *testing.T) {
+	a := NewMinHash[string](64, fnvHash)
+	b := NewMinHash[string](64, fnvHash)
+	if !a.Empty() {
+		t.Error("Expected new MinHash to be empty")
+	}
+	if s, _ := a.Similarity(b); s != 1 {
+		t.Errorf("Expected empty sets to have similarity 1, got %v", s)
+	}
+
+	a.Add("x")
+	a.Add("y")
+	b.Add("y")
+	b.Add("x")
+	b.Add("x")
+	if a.Empty() {
+		t.Error("Expected MinHash not to be empty after Add")
+	}
+	if s, _ := a.Similarity(b); s != 1 {
+		t.Errorf("Expected similarity 1 regardless of order and duplicates, got %v", s)
+	}
+
+	a.Clear()
+	if !a.Empty() {
+		t.Error("Expected MinHash to be empty after Clear")
+	}
+}
+
+func TestMinHashMerge(t *testing.T) {
+	left := rangeSet(128, 0, 500)
+	right := rangeSet(128, 500, 500)
+	if err := left.Merge(right); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	whole := rangeSet(128, 0, 1000)
+	want, got := whole.Signature(), left.Signature()
+	for i := range want {
+		if want[i] != got[i] {
+			t.Fatalf("Expected merged signature to equal the signature of the union at %d", i)
+		}
+	}
+
+	// Signature returns a copy
+	got[0] = 0
+	if left.Signature()[0] == 0 {
+		t.Error("Expected Signature to return a copy")
+	}
+}
+
+func TestMinHashIncompatible(t *testing.T) {
+	a := NewMinHash[string](16, fnvHash)
+	for _, b := range []*MinHash[string]{
+		NewMinHash[string](32, fnvHash),
+		NewMinHash[string](16, fnvHash, WithSeed(1)),
+	} {
+		if err := a.Merge(b); err != ErrIncompatible {
+			t.Errorf("Merge: expected ErrIncompatible, got %v", err)
+		}
+		if _, err := a.Similarity(b); err != ErrIncompatible {
+			t.Errorf("Similarity: expected ErrIncompatible, got %v", err)
+		}
+	}
+
+	if NewMinHash[int](0, nil).K() != 1 {
+		t.Error("Expected k to be raised to 1")
+	}
+}
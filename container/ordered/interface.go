@@ -0,0 +1,62 @@
+//go:build !go1.23
+// +build !go1.23
+
+// Package ordered defines Interface, the operations shared by the ordered
+// map implementations in this module, so code can swap one implementation
+// for another without changes.
+package ordered
+
+import (
+	"github.com/feepwang/br/container/pair"
+)
+
+// Interface is a map that keeps its keys sorted. It is implemented by
+// ordered_map.RedBlackTree and skip_list.SkipList; use the constructors in
+// this package to obtain either behind Interface.
+type Interface[K comparable, V any] interface {
+	// Len returns the number of key-value pairs.
+	Len() int
+
+	// Get retrieves the value associated with the given key.
+	// Returns the value and true if the key exists, zero value and false otherwise.
+	Get(key K) (V, bool)
+
+	// GetMutable returns a pointer to the value associated with the given key,
+	// or nil and false if the key does not exist.
+	GetMutable(key K) (*V, bool)
+
+	// Set inserts or updates a key-value pair.
+	Set(key K, value V)
+
+	// Delete removes the key-value pair with the given key.
+	// Returns true if the key was found and removed, false otherwise.
+	Delete(key K) bool
+
+	// Has checks whether the given key exists.
+	Has(key K) bool
+
+	// Clear removes all key-value pairs.
+	Clear()
+
+	// Keys returns a slice of all keys in sorted order.
+	Keys() []K
+
+	// Values returns a slice of all values in the order of their keys.
+	Values() []V
+
+	// Pairs returns a slice of all key-value pairs in sorted order by key.
+	Pairs() []pair.Pair[K, V]
+
+	// Range calls fn for each key-value pair in sorted order by key.
+	// If fn returns false, the iteration stops.
+	Range(fn func(key K, value V) bool)
+
+	// RangeFrom calls fn for each key-value pair starting from the given key
+	// (inclusive) in sorted order by key. If fn returns false, the iteration stops.
+	RangeFrom(start K, fn func(key K, value V) bool)
+
+	// RangeBetween calls fn for each key-value pair within the given key range
+	// [start, end] (both inclusive) in sorted order by key.
+	// If fn returns false, the iteration stops.
+	RangeBetween(start, end K, fn func(key K, value V) bool)
+}
@@ -0,0 +1,20 @@
+// Package ordered defines Interface, the operations shared by the ordered
+// map implementations in this module.
+// This file implements the constructors returning Interface.
+
+package ordered
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/ordered_map"
+)
+
+// Compile-time checks that the implementations satisfy Interface.
+var _ Interface[int, int] = (*ordered_map.RedBlackTree[int, int])(nil)
+
+// NewRedBlackTree returns an empty ordered_map.RedBlackTree as an Interface.
+// It favors predictable O(log n) worst-case operations.
+func NewRedBlackTree[K cmp.Ordered, V any](opts ...ordered_map.Option) Interface[K, V] {
+	return ordered_map.NewRedBlackTree[K, V](opts...)
+}
@@ -0,0 +1,61 @@
+//go:build go1.23
+// +build go1.23
+
+package ordered
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterfaceIterators(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			for k := 0; k < 10; k += 2 {
+				m.Set(k, k)
+			}
+
+			var keys []int
+			for k := range m.All() {
+				keys = append(keys, k)
+			}
+			if !reflect.DeepEqual(keys, []int{0, 2, 4, 6, 8}) {
+				t.Errorf("All: expected [0 2 4 6 8], got %v", keys)
+			}
+
+			keys = nil
+			for k := range m.AllFrom(5) {
+				keys = append(keys, k)
+			}
+			if !reflect.DeepEqual(keys, []int{6, 8}) {
+				t.Errorf("AllFrom: expected [6 8], got %v", keys)
+			}
+
+			keys = nil
+			for k := range m.AllBetween(1, 4) {
+				keys = append(keys, k)
+			}
+			if !reflect.DeepEqual(keys, []int{2, 4}) {
+				t.Errorf("AllBetween: expected [2 4], got %v", keys)
+			}
+		})
+	}
+}
+
+func TestNewSkipListFunc(t *testing.T) {
+	m := NewSkipListFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Set("b", 1)
+	m.Set("A", 2)
+	m.Set("B", 3)
+
+	if !reflect.DeepEqual(m.Keys(), []string{"A", "b"}) {
+		t.Errorf("Expected keys [A b], got %v", m.Keys())
+	}
+	if v, _ := m.Get("b"); v != 3 {
+		t.Errorf("Expected 3, got %d", v)
+	}
+}
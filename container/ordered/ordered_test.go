@@ -0,0 +1,137 @@
+package ordered
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// implementations lists the constructors the shared tests run against.
+var implementations = []struct {
+	name string
+	new  func() Interface[int, int]
+}{
+	{"RedBlackTree", func() Interface[int, int] { return NewRedBlackTree[int, int]() }},
+	{"SkipList", func() Interface[int, int] { return NewSkipList[int, int]() }},
+}
+
+func TestInterfaceBasic(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			if m.Len() != 0 {
+				t.Errorf("Expected length 0, got %d", m.Len())
+			}
+			for _, k := range []int{5, 1, 3} {
+				m.Set(k, k*10)
+			}
+			m.Set(3, 33)
+
+			if m.Len() != 3 {
+				t.Errorf("Expected length 3, got %d", m.Len())
+			}
+			if v, ok := m.Get(3); !ok || v != 33 {
+				t.Errorf("Expected 33, got %d (ok=%v)", v, ok)
+			}
+			if p, ok := m.GetMutable(1); ok {
+				*p = 11
+			}
+			if v, _ := m.Get(1); v != 11 {
+				t.Errorf("Expected 11 after GetMutable, got %d", v)
+			}
+			if !m.Has(5) || m.Has(4) {
+				t.Error("Has returned wrong results")
+			}
+			if !reflect.DeepEqual(m.Keys(), []int{1, 3, 5}) {
+				t.Errorf("Expected keys [1 3 5], got %v", m.Keys())
+			}
+			if !reflect.DeepEqual(m.Values(), []int{11, 33, 50}) {
+				t.Errorf("Expected values [11 33 50], got %v", m.Values())
+			}
+			if pairs := m.Pairs(); len(pairs) != 3 || pairs[2].First != 5 || pairs[2].Second != 50 {
+				t.Errorf("Unexpected pairs %v", pairs)
+			}
+
+			if !m.Delete(3) || m.Delete(3) {
+				t.Error("Delete returned wrong results")
+			}
+			m.Clear()
+			if m.Len() != 0 || m.Has(1) {
+				t.Errorf("Expected empty map after Clear, got length %d", m.Len())
+			}
+		})
+	}
+}
+
+func TestInterfaceRange(t *testing.T) {
+	collect := func(rangeFn func(fn func(key, value int) bool), limit int) []int {
+		var keys []int
+		rangeFn(func(key, _ int) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		return keys
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			for k := 0; k < 10; k += 2 {
+				m.Set(k, k)
+			}
+
+			if got := collect(m.Range, 100); !reflect.DeepEqual(got, []int{0, 2, 4, 6, 8}) {
+				t.Errorf("Range: expected [0 2 4 6 8], got %v", got)
+			}
+			if got := collect(m.Range, 2); !reflect.DeepEqual(got, []int{0, 2}) {
+				t.Errorf("Range with early stop: expected [0 2], got %v", got)
+			}
+			from := func(fn func(key, value int) bool) { m.RangeFrom(3, fn) }
+			if got := collect(from, 100); !reflect.DeepEqual(got, []int{4, 6, 8}) {
+				t.Errorf("RangeFrom: expected [4 6 8], got %v", got)
+			}
+			between := func(fn func(key, value int) bool) { m.RangeBetween(2, 6, fn) }
+			if got := collect(between, 100); !reflect.DeepEqual(got, []int{2, 4, 6}) {
+				t.Errorf("RangeBetween: expected [2 4 6], got %v", got)
+			}
+		})
+	}
+}
+
+func TestInterfaceRandom(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			m := impl.new()
+			ref := make(map[int]int)
+			for i := 0; i < 2000; i++ {
+				k := rng.Intn(300)
+				if rng.Intn(3) == 0 {
+					_, exists := ref[k]
+					if m.Delete(k) != exists {
+						t.Fatalf("Delete(%d) disagrees with reference", k)
+					}
+					delete(ref, k)
+				} else {
+					m.Set(k, i)
+					ref[k] = i
+				}
+			}
+
+			keys := make([]int, 0, len(ref))
+			for k := range ref {
+				keys = append(keys, k)
+			}
+			sort.Ints(keys)
+			if !reflect.DeepEqual(m.Keys(), keys) {
+				t.Fatalf("Keys disagree with reference")
+			}
+			for _, k := range keys {
+				if v, ok := m.Get(k); !ok || v != ref[k] {
+					t.Errorf("Get(%d): expected %d, got %d (ok=%v)", k, ref[k], v, ok)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !go1.23
+// +build !go1.23
+
+// Package ordered defines Interface, the operations shared by the ordered
+// map implementations in this module.
+// This file implements the skip list constructor.
+
+package ordered
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/skip_list"
+)
+
+var _ Interface[int, int] = (*skip_list.SkipList[int, int])(nil)
+
+// NewSkipList returns an empty skip_list.SkipList as an Interface.
+func NewSkipList[K cmp.Ordered, V any](opts ...skip_list.Option) Interface[K, V] {
+	return skip_list.NewSkipList[K, V](opts...)
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+// +build go1.23
+
+// Package ordered defines Interface, the operations shared by the ordered
+// map implementations in this module.
+// This file implements the skip list constructors.
+
+package ordered
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/skip_list"
+)
+
+var _ Interface[int, int] = (*skip_list.SkipList[int, int])(nil)
+
+// NewSkipList returns an empty skip_list.SkipList as an Interface.
+func NewSkipList[K cmp.Ordered, V any](opts ...skip_list.Option) Interface[K, V] {
+	return skip_list.NewOrderedSkipList[K, V](opts...)
+}
+
+// NewSkipListFunc returns an empty skip_list.SkipList ordered by compare as
+// an Interface.
+func NewSkipListFunc[K comparable, V any](compare func(a, b K) int, opts ...skip_list.Option) Interface[K, V] {
+	return skip_list.NewSkipList[K, V](compare, opts...)
+}
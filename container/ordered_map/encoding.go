@@ -0,0 +1,131 @@
+// Package ordered_map provides an ordered map implementation using Red-Black Tree.
+// This file implements binary and JSON serialization for RedBlackTree.
+
+package ordered_map
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/feepwang/br/algo/sortx"
+	"github.com/feepwang/br/container/pair"
+)
+
+// MarshalBinary encodes the pairs of the tree in key order with
+// encoding/gob, so K and V must be types gob can encode.
+// It implements encoding.BinaryMarshaler.
+func (t *RedBlackTree[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(t.Pairs()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the tree with the pairs decoded
+// from data produced by MarshalBinary.
+// It implements encoding.BinaryUnmarshaler.
+// On error the tree is left unchanged.
+func (t *RedBlackTree[K, V]) UnmarshalBinary(data []byte) error {
+	var pairs []pair.Pair[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&pairs); err != nil {
+		return err
+	}
+	t.load(pairs)
+	return nil
+}
+
+// MarshalJSON encodes the tree as a JSON array of [key, value] arrays in key
+// order. An array is used rather than an object so that keys of any type
+// keep their JSON form and their order.
+// It implements json.Marshaler.
+func (t *RedBlackTree[K, V]) MarshalJSON() ([]byte, error) {
+	entries := make([]jsonEntry[K, V], 0, t.size)
+	t.Range(func(key K, value V) bool {
+		entries = append(entries, jsonEntry[K, V]{key, value})
+		return true
+	})
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the contents of the tree with the pairs decoded
+// from a JSON array of [key, value] arrays. Pairs may appear in any order;
+// when a key repeats, the last value wins.
+// It implements json.Unmarshaler.
+// On error the tree is left unchanged.
+func (t *RedBlackTree[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	pairs := make([]pair.Pair[K, V], len(entries))
+	for i, e := range entries {
+		pairs[i] = pair.Pair[K, V]{First: e.Key, Second: e.Value}
+	}
+	t.load(pairs)
+	return nil
+}
+
+// load replaces the contents of the tree with pairs, taking ownership of the
+// slice. Pairs sorted by strictly increasing key, as produced by the
+// encoders, are bulk loaded in O(n). Others are first sorted with
+// sortx.StableByKey, which uses radix sort for integer keys, and the last
+// value of each repeated key is kept, as one Set per pair would.
+func (t *RedBlackTree[K, V]) load(pairs []pair.Pair[K, V]) {
+	t.Clear()
+	for i := 1; i < len(pairs); i++ {
+		if cmp.Compare(pairs[i-1].First, pairs[i].First) >= 0 {
+			pairs = sortUnique(pairs)
+			break
+		}
+	}
+	t.root = buildBalanced(t, pairs, nil, 0, redDepth(len(pairs)))
+	t.size = len(pairs)
+}
+
+// sortUnique stably sorts pairs by key in place and drops every pair whose
+// key is repeated later, returning the shortened slice.
+func sortUnique[K cmp.Ordered, V any](pairs []pair.Pair[K, V]) []pair.Pair[K, V] {
+	sortx.StableByKey(pairs, func(p pair.Pair[K, V]) K {
+		return p.First
+	})
+	n := 0
+	for i, p := range pairs {
+		if i+1 < len(pairs) && cmp.Compare(p.First, pairs[i+1].First) == 0 {
+			continue
+		}
+		pairs[n] = p
+		n++
+	}
+	clear(pairs[n:])
+	return pairs[:n]
+}
+
+// jsonEntry is a key-value pair encoded as a two-element JSON array.
+type jsonEntry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e jsonEntry[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{e.Key, e.Value})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *jsonEntry[K, V]) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("ordered_map: JSON entry has %d elements, want [key, value]", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], &e.Key); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw[1], &e.Value)
+}
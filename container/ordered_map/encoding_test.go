@@ -0,0 +1,140 @@
+package ordered_map
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+func TestRedBlackTreeBinaryRoundTrip(t *testing.T) {
+	sl := NewRedBlackTree[string, []int]()
+	sl.Set("b", []int{2})
+	sl.Set("a", []int{1, 1})
+	sl.Set("c", nil)
+
+	data, err := sl.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	decoded := NewRedBlackTree[string, []int]()
+	decoded.Set("stale", nil)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Keys(), []string{"a", "b", "c"}) {
+		t.Errorf("Expected keys [a b c], got %v", decoded.Keys())
+	}
+	if v, _ := decoded.Get("a"); !reflect.DeepEqual(v, []int{1, 1}) {
+		t.Errorf("Expected [1 1], got %v", v)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+	if decoded.Len() != 3 {
+		t.Error("Expected failed decoding to leave the tree unchanged")
+	}
+}
+
+func TestRedBlackTreeJSON(t *testing.T) {
+	sl := NewRedBlackTree[int, string]()
+	sl.Set(10, "ten")
+	sl.Set(2, "two")
+
+	data, err := json.Marshal(sl)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `[[2,"two"],[10,"ten"]]` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	decoded := NewRedBlackTree[int, string]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Pairs(), sl.Pairs()) {
+		t.Errorf("Expected %v, got %v", sl.Pairs(), decoded.Pairs())
+	}
+
+	// Unsorted input and repeated keys are accepted
+	if err := json.Unmarshal([]byte(`[[3,"c"],[1,"a"],[3,"C"]]`), decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Keys(), []int{1, 3}) {
+		t.Errorf("Expected keys [1 3], got %v", decoded.Keys())
+	}
+	if v, _ := decoded.Get(3); v != "C" {
+		t.Errorf("Expected the last value to win, got %q", v)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	for _, bad := range []string{`{"1":"a"}`, `[[1]]`, `[[1,"a","junk"]]`, `[["x","a"]]`} {
+		if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+			t.Errorf("Expected error for %s", bad)
+		}
+	}
+	if decoded.Len() != 2 {
+		t.Error("Expected failed decoding to leave the tree unchanged")
+	}
+}
+
+func TestRedBlackTreeBulkLoad(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Set(i*7%1000, i)
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	decoded := NewRedBlackTree[int, int]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	checkRedBlack(t, decoded)
+	if !reflect.DeepEqual(decoded.Pairs(), tree.Pairs()) {
+		t.Error("Expected the decoded tree to hold the same pairs")
+	}
+}
+
+func TestRedBlackTreeLoadUnsorted(t *testing.T) {
+	const n = 500
+	rng := rand.New(rand.NewSource(1))
+	pairs := make([]pair.Pair[int, int], 0, 2*n)
+	for _, k := range rng.Perm(n) {
+		pairs = append(pairs, pair.Pair[int, int]{First: k - n/2, Second: 0})
+	}
+	// Repeat every key; the later value must win
+	for _, k := range rng.Perm(n) {
+		pairs = append(pairs, pair.Pair[int, int]{First: k - n/2, Second: k - n/2})
+	}
+
+	tree := NewRedBlackTree[int, int]()
+	tree.load(pairs)
+	checkRedBlack(t, tree)
+	if tree.Len() != n {
+		t.Fatalf("Expected %d keys, got %d", n, tree.Len())
+	}
+	tree.Range(func(k, v int) bool {
+		if v != k {
+			t.Errorf("Expected %d=%d, got %d", k, k, v)
+		}
+		return true
+	})
+
+	strs := NewRedBlackTree[string, int]()
+	strs.load([]pair.Pair[string, int]{{First: "b", Second: 1}, {First: "a", Second: 2}, {First: "b", Second: 3}})
+	if v, _ := strs.Get("b"); v != 3 || !reflect.DeepEqual(strs.Keys(), []string{"a", "b"}) {
+		t.Errorf("Expected keys [a b] with b=3, got %v with b=%d", strs.Keys(), v)
+	}
+}
@@ -0,0 +1,47 @@
+package ordered_map
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// implementations constructs each Interface implementation, for tests
+// shared between them.
+var implementations = []struct {
+	name string
+	new  func() Interface[float64, int]
+}{
+	{"RedBlackTree", func() Interface[float64, int] { return NewRedBlackTree[float64, int]() }},
+	{"SplayTree", func() Interface[float64, int] { return NewSplayTree[float64, int]() }},
+	{"SplayTree without splaying", func() Interface[float64, int] {
+		tree := NewSplayTree[float64, int]()
+		tree.SetSplaying(false)
+		return tree
+	}},
+}
+
+func TestInterfaceNaNKeys(t *testing.T) {
+	nan := math.NaN()
+	for _, impl := range implementations {
+		m := impl.new()
+		m.Set(nan, 1)
+		m.Set(1, 2)
+		m.Set(nan, 3)
+		if m.Len() != 2 {
+			t.Errorf("%s: expected 2 keys after setting NaN twice, got %d", impl.name, m.Len())
+		}
+		if v, ok := m.Get(nan); !ok || v != 3 {
+			t.Errorf("%s: Get(NaN) = %d, %v, want 3, true", impl.name, v, ok)
+		}
+		if !m.Has(nan) {
+			t.Errorf("%s: expected Has(NaN) to be true", impl.name)
+		}
+		if !m.Delete(nan) || m.Has(nan) || m.Len() != 1 {
+			t.Errorf("%s: expected Delete(NaN) to remove the NaN key", impl.name)
+		}
+		if !reflect.DeepEqual(m.Keys(), []float64{1}) {
+			t.Errorf("%s: expected keys [1], got %v", impl.name, m.Keys())
+		}
+	}
+}
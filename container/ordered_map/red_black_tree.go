@@ -6,6 +6,9 @@ package ordered_map
 
 import (
 	"cmp"
+	"fmt"
+	"math/bits"
+	"sync"
 
 	"github.com/feepwang/br/container/pair"
 )
@@ -25,18 +28,104 @@ type rbNode[K cmp.Ordered, V any] struct {
 	left   *rbNode[K, V]
 	right  *rbNode[K, V]
 	parent *rbNode[K, V]
-	color  color
+	// size is the number of nodes in the subtree rooted here, for order statistics.
+	// Kept as uint32 so that it packs with color and the node stays small.
+	size  uint32
+	color color
+}
+
+// subtreeSize returns the size of the subtree rooted at n, treating nil as empty.
+func subtreeSize[K cmp.Ordered, V any](n *rbNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return int(n.size)
 }
 
 // RedBlackTree implements the ordered_map.Interface using a Red-Black Tree.
 type RedBlackTree[K cmp.Ordered, V any] struct {
 	root *rbNode[K, V]
 	size int
+	pool *sync.Pool // recycles nodes when created with WithNodePool, nil otherwise
+}
+
+// Option configures a RedBlackTree created by NewRedBlackTree.
+type Option func(*treeOptions)
+
+type treeOptions struct {
+	nodePool bool
+}
+
+// WithNodePool makes the tree recycle nodes released by Delete, Clear and
+// Merge through a sync.Pool, reducing GC pressure under heavy Set/Delete
+// churn. Trees returned by Clone and Split share the pool.
+//
+// With pooling enabled, a pointer returned by GetMutable must not be used
+// after any Delete, Clear or Merge on the tree, since its node may be reused.
+func WithNodePool() Option {
+	return func(o *treeOptions) {
+		o.nodePool = true
+	}
 }
 
 // NewRedBlackTree creates a new RedBlackTree.
-func NewRedBlackTree[K cmp.Ordered, V any]() *RedBlackTree[K, V] {
-	return &RedBlackTree[K, V]{}
+func NewRedBlackTree[K cmp.Ordered, V any](opts ...Option) *RedBlackTree[K, V] {
+	var o treeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t := &RedBlackTree[K, V]{}
+	if o.nodePool {
+		t.pool = &sync.Pool{New: func() any { return new(rbNode[K, V]) }}
+	}
+	return t
+}
+
+// newNode returns a detached node holding key and value, taken from the
+// pool when one is configured.
+func (t *RedBlackTree[K, V]) newNode(key K, value V, parent *rbNode[K, V], c color) *rbNode[K, V] {
+	if t.pool == nil {
+		return &rbNode[K, V]{key: key, value: value, parent: parent, color: c, size: 1}
+	}
+	n := t.pool.Get().(*rbNode[K, V])
+	n.key, n.value, n.parent, n.color, n.size = key, value, parent, c, 1
+	return n
+}
+
+// freeNode returns n to the pool, if any. n is zeroed first so the pool
+// does not keep keys and values reachable.
+func (t *RedBlackTree[K, V]) freeNode(n *rbNode[K, V]) {
+	if t.pool == nil {
+		return
+	}
+	*n = rbNode[K, V]{}
+	t.pool.Put(n)
+}
+
+// freeSubtree returns every node of the subtree rooted at n to the pool.
+func (t *RedBlackTree[K, V]) freeSubtree(n *rbNode[K, V]) {
+	if t.pool == nil || n == nil {
+		return
+	}
+	stack := []*rbNode[K, V]{n}
+	for len(stack) > 0 {
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.left != nil {
+			stack = append(stack, n.left)
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+		t.freeNode(n)
+	}
+}
+
+// Clear removes all elements from the map.
+func (t *RedBlackTree[K, V]) Clear() {
+	t.freeSubtree(t.root)
+	t.root = nil
+	t.size = 0
 }
 
 // Len returns the number of elements in the map.
@@ -83,76 +172,97 @@ func (t *RedBlackTree[K, V]) GetMutable(key K) (*V, bool) {
 // Set inserts or updates a key-value pair.
 func (t *RedBlackTree[K, V]) Set(key K, value V) {
 	// Standard BST insert, then fixup for Red-Black properties
-	var inserted *rbNode[K, V]
 	if t.root == nil {
 		// Tree is empty, insert root
-		inserted = &rbNode[K, V]{key: key, value: value, color: black}
-		t.root = inserted
+		t.root = t.newNode(key, value, nil, black)
 		t.size++
 		return
 	}
+
+	// Descend once, remembering the side to attach on so the parent
+	// is not compared again. Subtree sizes are bumped on the way down
+	// while the nodes are still hot in cache, and undone on update.
 	n := t.root
 	var parent *rbNode[K, V]
+	var c int
 	for n != nil {
+		c = cmp.Compare(key, n.key)
+		if c == 0 {
+			// Key exists, update value and revert the size bumps
+			n.value = value
+			for p := n.parent; p != nil; p = p.parent {
+				p.size--
+			}
+			return
+		}
+		n.size++
 		parent = n
-		if cmp.Less(key, n.key) {
+		if c < 0 {
 			n = n.left
-		} else if cmp.Less(n.key, key) {
-			n = n.right
 		} else {
-			// Key exists, update value
-			n.value = value
-			return
+			n = n.right
 		}
 	}
-	inserted = &rbNode[K, V]{key: key, value: value, parent: parent, color: red}
-	if cmp.Less(key, parent.key) {
+
+	inserted := t.newNode(key, value, parent, red)
+	if c < 0 {
 		parent.left = inserted
 	} else {
 		parent.right = inserted
 	}
 	t.size++
+
 	// Fix Red-Black Tree properties after insert
-	fixInsert(t, inserted)
+	if parent.color == red {
+		fixInsert(t, inserted)
+	}
 }
 
 // fixInsert restores Red-Black Tree properties after insertion.
 func fixInsert[K cmp.Ordered, V any](t *RedBlackTree[K, V], n *rbNode[K, V]) {
 	// Key place: Red-Black Tree balancing after insert
-	for n != t.root && n.parent.color == red {
-		if n.parent == n.parent.parent.left {
-			uncle := n.parent.parent.right
+	for {
+		parent := n.parent
+		if parent == nil || parent.color == black {
+			break
+		}
+		// A red parent is never the root, so the grandparent exists
+		grand := parent.parent
+		if parent == grand.left {
+			uncle := grand.right
 			if uncle != nil && uncle.color == red {
-				n.parent.color = black
+				parent.color = black
 				uncle.color = black
-				n.parent.parent.color = red
-				n = n.parent.parent
-			} else {
-				if n == n.parent.right {
-					n = n.parent
-					rotateLeft(t, n)
-				}
-				n.parent.color = black
-				n.parent.parent.color = red
-				rotateRight(t, n.parent.parent)
+				grand.color = red
+				n = grand
+				continue
 			}
-		} else {
-			uncle := n.parent.parent.left
-			if uncle != nil && uncle.color == red {
-				n.parent.color = black
-				uncle.color = black
-				n.parent.parent.color = red
-				n = n.parent.parent
-			} else {
-				if n == n.parent.left {
-					n = n.parent
-					rotateRight(t, n)
-				}
-				n.parent.color = black
-				n.parent.parent.color = red
-				rotateLeft(t, n.parent.parent)
+			if n == parent.right {
+				rotateLeft(t, parent)
+				parent = n
 			}
+			parent.color = black
+			grand.color = red
+			rotateRight(t, grand)
+			break
+		}
+
+		uncle := grand.left
+		if uncle != nil && uncle.color == red {
+			parent.color = black
+			uncle.color = black
+			grand.color = red
+			n = grand
+			continue
+		}
+		if n == parent.left {
+			rotateRight(t, parent)
+			parent = n
 		}
+		parent.color = black
+		grand.color = red
+		rotateLeft(t, grand)
+		break
 	}
 	t.root.color = black
 }
@@ -174,6 +284,8 @@ func rotateLeft[K cmp.Ordered, V any](t *RedBlackTree[K, V], x *rbNode[K, V]) {
 	}
 	y.left = x
 	x.parent = y
+	y.size = x.size
+	x.size = uint32(subtreeSize(x.left) + subtreeSize(x.right) + 1)
 }
 
 // rotateRight performs a right rotation.
@@ -193,6 +305,8 @@ func rotateRight[K cmp.Ordered, V any](t *RedBlackTree[K, V], x *rbNode[K, V]) {
 	}
 	y.right = x
 	x.parent = y
+	y.size = x.size
+	x.size = uint32(subtreeSize(x.left) + subtreeSize(x.right) + 1)
 }
 
 // Has checks if a key exists in the map.
@@ -244,7 +358,13 @@ func deleteNode[K cmp.Ordered, V any](t *RedBlackTree[K, V], z *rbNode[K, V]) {
 		x = y.right
 	}
 
+	// Every ancestor of y loses one node from its subtree
+	for p := y.parent; p != nil; p = p.parent {
+		p.size--
+	}
+
 	// Link x to y's parent
+	xParent := y.parent
 	if x != nil {
 		x.parent = y.parent
 	}
@@ -264,123 +384,490 @@ func deleteNode[K cmp.Ordered, V any](t *RedBlackTree[K, V], z *rbNode[K, V]) {
 	}
 
 	// Fix Red-Black properties if a black node was deleted
-	if y.color == black && x != nil {
-		fixDelete(t, x)
+	if y.color == black {
+		fixDelete(t, x, xParent)
 	}
+	t.freeNode(y)
+}
+
+// isBlack reports whether n is black. Nil leaves count as black.
+func isBlack[K cmp.Ordered, V any](n *rbNode[K, V]) bool {
+	return n == nil || n.color == black
 }
 
 // fixDelete restores Red-Black Tree properties after deletion.
-func fixDelete[K cmp.Ordered, V any](t *RedBlackTree[K, V], x *rbNode[K, V]) {
-	for x != t.root && x.color == black {
-		if x == x.parent.left {
-			w := x.parent.right // sibling
+// x may be nil (a black leaf), so its parent is passed explicitly.
+func fixDelete[K cmp.Ordered, V any](t *RedBlackTree[K, V], x, parent *rbNode[K, V]) {
+	for x != t.root && isBlack(x) {
+		if x == parent.left {
+			w := parent.right // sibling
 			if w.color == red {
 				w.color = black
-				x.parent.color = red
-				rotateLeft(t, x.parent)
-				w = x.parent.right
+				parent.color = red
+				rotateLeft(t, parent)
+				w = parent.right
 			}
-			if (w.left == nil || w.left.color == black) &&
-				(w.right == nil || w.right.color == black) {
+			if isBlack(w.left) && isBlack(w.right) {
 				w.color = red
-				x = x.parent
+				x = parent
+				parent = x.parent
 			} else {
-				if w.right == nil || w.right.color == black {
-					if w.left != nil {
-						w.left.color = black
-					}
+				if isBlack(w.right) {
+					w.left.color = black
 					w.color = red
 					rotateRight(t, w)
-					w = x.parent.right
+					w = parent.right
 				}
-				w.color = x.parent.color
-				x.parent.color = black
+				w.color = parent.color
+				parent.color = black
 				if w.right != nil {
 					w.right.color = black
 				}
-				rotateLeft(t, x.parent)
+				rotateLeft(t, parent)
 				x = t.root
+				parent = nil
 			}
 		} else {
-			w := x.parent.left // sibling
+			w := parent.left // sibling
 			if w.color == red {
 				w.color = black
-				x.parent.color = red
-				rotateRight(t, x.parent)
-				w = x.parent.left
+				parent.color = red
+				rotateRight(t, parent)
+				w = parent.left
 			}
-			if (w.right == nil || w.right.color == black) &&
-				(w.left == nil || w.left.color == black) {
+			if isBlack(w.right) && isBlack(w.left) {
 				w.color = red
-				x = x.parent
+				x = parent
+				parent = x.parent
 			} else {
-				if w.left == nil || w.left.color == black {
-					if w.right != nil {
-						w.right.color = black
-					}
+				if isBlack(w.left) {
+					w.right.color = black
 					w.color = red
 					rotateLeft(t, w)
-					w = x.parent.left
+					w = parent.left
 				}
-				w.color = x.parent.color
-				x.parent.color = black
+				w.color = parent.color
+				parent.color = black
 				if w.left != nil {
 					w.left.color = black
 				}
-				rotateRight(t, x.parent)
+				rotateRight(t, parent)
 				x = t.root
+				parent = nil
 			}
 		}
 	}
-	x.color = black
+	if x != nil {
+		x.color = black
+	}
 }
 
-// Keys returns all keys in order.
-func (t *RedBlackTree[K, V]) Keys() []K {
-	var keys []K
-	inOrderKeys(t.root, &keys)
-	return keys
+// Rank returns the number of keys strictly less than key.
+// The key itself does not need to be present in the map.
+func (t *RedBlackTree[K, V]) Rank(key K) int {
+	rank := 0
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			rank += subtreeSize(n.left) + 1
+			n = n.right
+		} else {
+			return rank + subtreeSize(n.left)
+		}
+	}
+	return rank
+}
+
+// Kth returns the i-th smallest key (0-based) and its value.
+// Returns zero values and false if i is out of range.
+func (t *RedBlackTree[K, V]) Kth(i int) (K, V, bool) {
+	if i < 0 || i >= t.size {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	n := t.root
+	for {
+		leftSize := subtreeSize(n.left)
+		if i < leftSize {
+			n = n.left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			n = n.right
+		} else {
+			return n.key, n.value, true
+		}
+	}
 }
 
-func inOrderKeys[K cmp.Ordered, V any](n *rbNode[K, V], keys *[]K) {
+// Min returns the smallest key and its value.
+// Returns zero values and false if the map is empty.
+func (t *RedBlackTree[K, V]) Min() (K, V, bool) {
+	n := t.root
 	if n == nil {
-		return
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.left != nil {
+		n = n.left
 	}
-	inOrderKeys(n.left, keys)
-	*keys = append(*keys, n.key)
-	inOrderKeys(n.right, keys)
+	return n.key, n.value, true
 }
 
-// Values returns all values in order.
-func (t *RedBlackTree[K, V]) Values() []V {
-	var values []V
-	inOrderValues(t.root, &values)
-	return values
+// Max returns the largest key and its value.
+// Returns zero values and false if the map is empty.
+func (t *RedBlackTree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *RedBlackTree[K, V]) Floor(key K) (K, V, bool) {
+	var found *rbNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			found = n
+			n = n.right
+		} else {
+			return n.key, n.value, true
+		}
+	}
+	return rbNodeEntry(found)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *RedBlackTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return rbNodeEntry(t.ceilingNode(key))
+}
+
+// ceilingNode returns the node with the smallest key >= key, or nil.
+func (t *RedBlackTree[K, V]) ceilingNode(key K) *rbNode[K, V] {
+	var found *rbNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			found = n
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			n = n.right
+		} else {
+			return n
+		}
+	}
+	return found
+}
+
+// Lower returns the largest key strictly less than key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *RedBlackTree[K, V]) Lower(key K) (K, V, bool) {
+	var found *rbNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(n.key, key) {
+			found = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rbNodeEntry(found)
+}
+
+// Higher returns the smallest key strictly greater than key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *RedBlackTree[K, V]) Higher(key K) (K, V, bool) {
+	var found *rbNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return rbNodeEntry(found)
 }
 
-func inOrderValues[K cmp.Ordered, V any](n *rbNode[K, V], values *[]V) {
+// rbNodeEntry unpacks a node into key, value and existence.
+func rbNodeEntry[K cmp.Ordered, V any](n *rbNode[K, V]) (K, V, bool) {
 	if n == nil {
-		return
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// minNode returns the leftmost node of the subtree rooted at n, or nil.
+func minNode[K cmp.Ordered, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// successor returns the in-order successor of n, or nil if n is the last node.
+// It follows parent pointers, so in-order walks need neither recursion nor a stack.
+func successor[K cmp.Ordered, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	if n.right != nil {
+		return minNode(n.right)
 	}
-	inOrderValues(n.left, values)
-	*values = append(*values, n.value)
-	inOrderValues(n.right, values)
+	p := n.parent
+	for p != nil && n == p.right {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// Keys returns all keys in order.
+func (t *RedBlackTree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	for n := minNode(t.root); n != nil; n = successor(n) {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// Values returns all values in order.
+func (t *RedBlackTree[K, V]) Values() []V {
+	values := make([]V, 0, t.size)
+	for n := minNode(t.root); n != nil; n = successor(n) {
+		values = append(values, n.value)
+	}
+	return values
 }
 
 // Pairs returns all key-value pairs in order.
 func (t *RedBlackTree[K, V]) Pairs() []pair.Pair[K, V] {
-	var pairs []pair.Pair[K, V]
-	inOrderPairs(t.root, &pairs)
+	pairs := make([]pair.Pair[K, V], 0, t.size)
+	for n := minNode(t.root); n != nil; n = successor(n) {
+		pairs = append(pairs, pair.Pair[K, V]{First: n.key, Second: n.value})
+	}
 	return pairs
 }
 
-func inOrderPairs[K cmp.Ordered, V any](n *rbNode[K, V], pairs *[]pair.Pair[K, V]) {
+// Range calls the provided function for each key-value pair in sorted order by key.
+// If the function returns false, the iteration stops.
+func (t *RedBlackTree[K, V]) Range(fn func(key K, value V) bool) {
+	for n := minNode(t.root); n != nil; n = successor(n) {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// RangeFrom calls the provided function for key-value pairs starting from the given key
+// (inclusive) in sorted order by key. If the function returns false, the iteration stops.
+func (t *RedBlackTree[K, V]) RangeFrom(start K, fn func(key K, value V) bool) {
+	for n := t.ceilingNode(start); n != nil; n = successor(n) {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// RangeBetween calls the provided function for key-value pairs within the given range
+// [start, end] (both inclusive) in sorted order by key. If start > end, the bounds
+// are swapped, as SkipList.RangeBetween does on every toolchain. If the function
+// returns false, the iteration stops.
+func (t *RedBlackTree[K, V]) RangeBetween(start, end K, fn func(key K, value V) bool) {
+	if cmp.Less(end, start) {
+		start, end = end, start
+	}
+	for n := t.ceilingNode(start); n != nil && !cmp.Less(end, n.key); n = successor(n) {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// Clone returns an independent copy of the tree in O(n).
+// Values are copied shallowly; use CloneFunc to deep-copy them.
+func (t *RedBlackTree[K, V]) Clone() *RedBlackTree[K, V] {
+	return t.CloneFunc(nil)
+}
+
+// CloneFunc returns an independent copy of the tree in O(n), passing every
+// value through cloneValue. A nil cloneValue copies values shallowly.
+// The copy has the same shape and colors, so no rebalancing is needed.
+func (t *RedBlackTree[K, V]) CloneFunc(cloneValue func(V) V) *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{
+		root: cloneSubtree(t, t.root, nil, cloneValue),
+		size: t.size,
+		pool: t.pool,
+	}
+}
+
+// cloneSubtree copies the subtree rooted at n, attaching it to parent.
+// Nodes are allocated through t so the copy draws from the same pool.
+func cloneSubtree[K cmp.Ordered, V any](t *RedBlackTree[K, V], n, parent *rbNode[K, V], cloneValue func(V) V) *rbNode[K, V] {
 	if n == nil {
+		return nil
+	}
+	value := n.value
+	if cloneValue != nil {
+		value = cloneValue(n.value)
+	}
+	c := t.newNode(n.key, value, parent, n.color)
+	c.size = n.size
+	c.left = cloneSubtree(t, n.left, c, cloneValue)
+	c.right = cloneSubtree(t, n.right, c, cloneValue)
+	return c
+}
+
+// Merge copies every key-value pair of other into t in O(n + m).
+// When a key exists in both maps, resolve(key, current, incoming) decides the
+// resulting value; a nil resolve keeps the incoming value. other is not modified.
+// The merged tree is rebuilt, so pointers obtained from GetMutable are invalidated.
+func (t *RedBlackTree[K, V]) Merge(other *RedBlackTree[K, V], resolve func(key K, current, incoming V) V) {
+	if other == nil || other.size == 0 {
 		return
 	}
-	inOrderPairs(n.left, pairs)
-	*pairs = append(*pairs, pair.Pair[K, V]{First: n.key, Second: n.value})
-	inOrderPairs(n.right, pairs)
+
+	merged := make([]pair.Pair[K, V], 0, t.size+other.size)
+	a, b := minNode(t.root), minNode(other.root)
+	for a != nil || b != nil {
+		switch {
+		case b == nil || (a != nil && cmp.Less(a.key, b.key)):
+			merged = append(merged, pair.Pair[K, V]{First: a.key, Second: a.value})
+			a = successor(a)
+		case a == nil || cmp.Less(b.key, a.key):
+			merged = append(merged, pair.Pair[K, V]{First: b.key, Second: b.value})
+			b = successor(b)
+		default:
+			value := b.value
+			if resolve != nil {
+				value = resolve(a.key, a.value, b.value)
+			}
+			merged = append(merged, pair.Pair[K, V]{First: a.key, Second: value})
+			a, b = successor(a), successor(b)
+		}
+	}
+
+	t.freeSubtree(t.root)
+	t.root = buildBalanced(t, merged, nil, 0, redDepth(len(merged)))
+	t.size = len(merged)
+}
+
+// Split partitions t around key in O(n), returning a map with the keys less
+// than key and a map with the keys greater than or equal to key.
+// t is not modified and values are copied shallowly.
+func (t *RedBlackTree[K, V]) Split(key K) (*RedBlackTree[K, V], *RedBlackTree[K, V]) {
+	pairs := t.Pairs()
+	cut := t.Rank(key)
+	return t.newBalancedTree(pairs[:cut]), t.newBalancedTree(pairs[cut:])
+}
+
+// newBalancedTree builds a tree sharing t's pool from pairs sorted by
+// strictly increasing key.
+func (t *RedBlackTree[K, V]) newBalancedTree(pairs []pair.Pair[K, V]) *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{
+		root: buildBalanced(t, pairs, nil, 0, redDepth(len(pairs))),
+		size: len(pairs),
+		pool: t.pool,
+	}
+}
+
+// redDepth returns the depth of the deepest level of a perfectly balanced
+// tree of n nodes. Coloring only that level red satisfies the red-black
+// rules, since every path to a leaf crosses the same number of full levels.
+func redDepth(n int) int {
+	return bits.Len(uint(n)) - 1
+}
+
+// buildBalanced builds a balanced subtree from sorted pairs in O(n) by
+// recursively taking the middle element as the root. Nodes are allocated
+// through t.
+func buildBalanced[K cmp.Ordered, V any](t *RedBlackTree[K, V], pairs []pair.Pair[K, V], parent *rbNode[K, V], depth, redLevel int) *rbNode[K, V] {
+	if len(pairs) == 0 {
+		return nil
+	}
+	mid := len(pairs) / 2
+	c := black
+	if depth == redLevel && depth > 0 {
+		c = red
+	}
+	n := t.newNode(pairs[mid].First, pairs[mid].Second, parent, c)
+	n.size = uint32(len(pairs))
+	n.left = buildBalanced(t, pairs[:mid], n, depth+1, redLevel)
+	n.right = buildBalanced(t, pairs[mid+1:], n, depth+1, redLevel)
+	return n
+}
+
+// Validate checks the invariants of the tree: BST ordering, parent links,
+// a black root, no red node with a red child, equal black heights on every
+// path, and consistent subtree sizes and element count.
+// Returns a descriptive error for the first violation found, or nil.
+// It is intended for tests and fuzzing after sequences of random operations.
+func (t *RedBlackTree[K, V]) Validate() error {
+	if t.root != nil && t.root.color != black {
+		return fmt.Errorf("ordered_map: root %v is red", t.root.key)
+	}
+	_, size, err := validateSubtree(t.root, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if size != t.size {
+		return fmt.Errorf("ordered_map: tree size is %d, counted %d nodes", t.size, size)
+	}
+	return nil
+}
+
+// validateSubtree checks the subtree rooted at n and returns its black height
+// and node count. Every key must lie strictly between lo and hi, the keys of
+// the nearest ancestors it descends left and right of; nil means unbounded.
+func validateSubtree[K cmp.Ordered, V any](n, parent *rbNode[K, V], lo, hi *K) (blackHeight, size int, err error) {
+	if n == nil {
+		return 1, 0, nil
+	}
+	if n.parent != parent {
+		return 0, 0, fmt.Errorf("ordered_map: node %v has wrong parent", n.key)
+	}
+	if n.color == red && (!isBlack(n.left) || !isBlack(n.right)) {
+		return 0, 0, fmt.Errorf("ordered_map: red node %v has a red child", n.key)
+	}
+	if lo != nil && !cmp.Less(*lo, n.key) || hi != nil && !cmp.Less(n.key, *hi) {
+		return 0, 0, fmt.Errorf("ordered_map: node %v violates BST ordering", n.key)
+	}
+	lh, ls, err := validateSubtree(n.left, n, lo, &n.key)
+	if err != nil {
+		return 0, 0, err
+	}
+	rh, rs, err := validateSubtree(n.right, n, &n.key, hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lh != rh {
+		return 0, 0, fmt.Errorf("ordered_map: node %v has unequal black heights %d and %d", n.key, lh, rh)
+	}
+	if int(n.size) != ls+rs+1 {
+		return 0, 0, fmt.Errorf("ordered_map: node %v has size %d, want %d", n.key, n.size, ls+rs+1)
+	}
+	if n.color == black {
+		lh++
+	}
+	return lh, ls + rs + 1, nil
 }
 
 // Ensure RedBlackTree implements Interface (for non-go1.23 version)
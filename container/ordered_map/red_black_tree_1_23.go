@@ -9,118 +9,73 @@ package ordered_map
 import (
 	"cmp"
 	"iter"
+
+	"github.com/feepwang/br/container/pair"
 )
 
 // KeySeq returns an iterator for keys (go1.23).
-// Uses efficient iterative in-order traversal without pre-allocating all keys.
+// Walks successor links, so iteration allocates nothing per element.
 func (t *RedBlackTree[K, V]) KeySeq() iter.Seq[K] {
 	return func(yield func(K) bool) {
-		inOrderKeysIterative(t.root, yield)
+		for n := minNode(t.root); n != nil; n = successor(n) {
+			if !yield(n.key) {
+				return
+			}
+		}
 	}
 }
 
 // ValueSeq returns an iterator for values (go1.23).
-// Uses efficient iterative in-order traversal without pre-allocating all values.
+// Walks successor links, so iteration allocates nothing per element.
 func (t *RedBlackTree[K, V]) ValueSeq() iter.Seq[V] {
 	return func(yield func(V) bool) {
-		inOrderValuesIterative(t.root, yield)
+		for n := minNode(t.root); n != nil; n = successor(n) {
+			if !yield(n.value) {
+				return
+			}
+		}
 	}
 }
 
 // PairSeq returns an iterator for key-value pairs (go1.23).
-// Uses efficient iterative in-order traversal without pre-allocating all pairs.
+// Walks successor links, so iteration allocates nothing per element.
 func (t *RedBlackTree[K, V]) PairSeq() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
-		inOrderPairsIterative(t.root, yield)
-	}
-}
-
-// inOrderKeysIterative performs iterative in-order traversal for keys.
-func inOrderKeysIterative[K cmp.Ordered, V any](root *rbNode[K, V], yield func(K) bool) {
-	if root == nil {
-		return
-	}
-
-	stack := make([]*rbNode[K, V], 0)
-	current := root
-
-	for len(stack) > 0 || current != nil {
-		// Go to the leftmost node
-		for current != nil {
-			stack = append(stack, current)
-			current = current.left
-		}
-
-		// Current must be nil at this point
-		current = stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		// Yield the key
-		if !yield(current.key) {
-			return
+		for n := minNode(t.root); n != nil; n = successor(n) {
+			if !yield(n.key, n.value) {
+				return
+			}
 		}
-
-		// Visit the right subtree
-		current = current.right
 	}
 }
 
-// inOrderValuesIterative performs iterative in-order traversal for values.
-func inOrderValuesIterative[K cmp.Ordered, V any](root *rbNode[K, V], yield func(V) bool) {
-	if root == nil {
-		return
-	}
-
-	stack := make([]*rbNode[K, V], 0)
-	current := root
-
-	for len(stack) > 0 || current != nil {
-		// Go to the leftmost node
-		for current != nil {
-			stack = append(stack, current)
-			current = current.left
-		}
-
-		// Current must be nil at this point
-		current = stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		// Yield the value
-		if !yield(current.value) {
-			return
-		}
+// All returns an iterator over all key-value pairs in sorted order by key (go1.23).
+// It is equivalent to PairSeq and mirrors SkipList.All.
+func (t *RedBlackTree[K, V]) All() iter.Seq2[K, V] {
+	return t.PairSeq()
+}
 
-		// Visit the right subtree
-		current = current.right
+// AllFrom returns an iterator over key-value pairs starting from the given key
+// (inclusive) in sorted order by key (go1.23).
+func (t *RedBlackTree[K, V]) AllFrom(start K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.RangeFrom(start, yield)
 	}
 }
 
-// inOrderPairsIterative performs iterative in-order traversal for key-value pairs.
-func inOrderPairsIterative[K cmp.Ordered, V any](root *rbNode[K, V], yield func(K, V) bool) {
-	if root == nil {
-		return
+// AllBetween returns an iterator over key-value pairs within the given range
+// [start, end] (both inclusive) in sorted order by key (go1.23).
+func (t *RedBlackTree[K, V]) AllBetween(start, end K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.RangeBetween(start, end, yield)
 	}
+}
 
-	stack := make([]*rbNode[K, V], 0)
-	current := root
-
-	for len(stack) > 0 || current != nil {
-		// Go to the leftmost node
-		for current != nil {
-			stack = append(stack, current)
-			current = current.left
-		}
-
-		// Current must be nil at this point
-		current = stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		// Yield the key-value pair
-		if !yield(current.key, current.value) {
-			return
-		}
-
-		// Visit the right subtree
-		current = current.right
-	}
+// NewRedBlackTreeFromSeq creates a tree of the key-value pairs of seq (go1.23).
+// When a key repeats, the last value wins. The pairs are collected, sorted
+// by key and bulk loaded, which is faster than one Set per pair.
+func NewRedBlackTreeFromSeq[K cmp.Ordered, V any](seq iter.Seq2[K, V], opts ...Option) *RedBlackTree[K, V] {
+	t := NewRedBlackTree[K, V](opts...)
+	t.load(pair.Collect(seq))
+	return t
 }
@@ -4,6 +4,8 @@
 package ordered_map
 
 import (
+	"maps"
+	"reflect"
 	"testing"
 )
 
@@ -75,3 +77,59 @@ func TestRedBlackTreeIterators(t *testing.T) {
 		t.Errorf("Expected to stop at 2 iterations, got %d", count)
 	}
 }
+
+func TestRedBlackTreeAllFromAndBetween(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 1; i <= 10; i++ {
+		tree.Set(i, i*i)
+	}
+
+	var keys []int
+	for k, v := range tree.AllFrom(8) {
+		if v != k*k {
+			t.Errorf("Expected value %d for key %d, got %d", k*k, k, v)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) != 3 || keys[0] != 8 || keys[2] != 10 {
+		t.Errorf("Expected keys [8 9 10], got %v", keys)
+	}
+
+	keys = keys[:0]
+	for k := range tree.AllBetween(7, 3) {
+		keys = append(keys, k)
+		if k == 5 {
+			break
+		}
+	}
+	if len(keys) != 3 || keys[0] != 3 || keys[2] != 5 {
+		t.Errorf("Expected keys [3 4 5], got %v", keys)
+	}
+
+	count := 0
+	for range tree.All() {
+		count++
+	}
+	if count != 10 {
+		t.Errorf("Expected 10 pairs from All, got %d", count)
+	}
+}
+
+func TestNewRedBlackTreeFromSeq(t *testing.T) {
+	seq := maps.All(map[string]int{"b": 2, "a": 1, "c": 3})
+	tree := NewRedBlackTreeFromSeq(seq, WithNodePool())
+	if !reflect.DeepEqual(tree.Keys(), []string{"a", "b", "c"}) {
+		t.Errorf("Expected keys [a b c], got %v", tree.Keys())
+	}
+
+	// The last value of a repeated key wins
+	repeated := NewRedBlackTreeFromSeq(func(yield func(string, int) bool) {
+		_ = yield("x", 0) && yield("y", 1) && yield("x", 2)
+	})
+	if v, _ := repeated.Get("x"); v != 2 || repeated.Len() != 2 {
+		t.Errorf("Expected x=2 among 2 keys, got x=%d among %d", v, repeated.Len())
+	}
+	if err := repeated.Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,11 @@
 package ordered_map
 
 import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/feepwang/br/container/pair"
@@ -204,7 +209,492 @@ func TestRedBlackTreePairs(t *testing.T) {
 	}
 }
 
+func TestRedBlackTreeRankAndKth(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+		tree.Set(k, "v")
+	}
+
+	rankTests := []struct {
+		key  int
+		want int
+	}{
+		{5, 0}, {10, 0}, {15, 1}, {30, 2}, {50, 3}, {85, 6}, {100, 7},
+	}
+	for _, tt := range rankTests {
+		if got := tree.Rank(tt.key); got != tt.want {
+			t.Errorf("Rank(%d) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+
+	expected := []int{10, 20, 30, 50, 70, 80, 90}
+	for i, want := range expected {
+		if k, _, ok := tree.Kth(i); !ok || k != want {
+			t.Errorf("Kth(%d) = (%d, %t), want (%d, true)", i, k, ok, want)
+		}
+	}
+	if _, _, ok := tree.Kth(-1); ok {
+		t.Error("Expected false for negative index")
+	}
+	if _, _, ok := tree.Kth(len(expected)); ok {
+		t.Error("Expected false for index past the end")
+	}
+}
+
+func TestRedBlackTreeOrderStatisticsRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewRedBlackTree[int, int]()
+	ref := make(map[int]bool)
+
+	for i := 0; i < 3000; i++ {
+		k := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			tree.Delete(k)
+			delete(ref, k)
+		} else {
+			tree.Set(k, k)
+			ref[k] = true
+		}
+	}
+
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	for i, want := range keys {
+		if k, v, ok := tree.Kth(i); !ok || k != want || v != want {
+			t.Fatalf("Kth(%d) = (%d, %d, %t), want (%d, %d, true)", i, k, v, ok, want, want)
+		}
+		if r := tree.Rank(want); r != i {
+			t.Fatalf("Rank(%d) = %d, want %d", want, r, i)
+		}
+	}
+}
+
+func TestRedBlackTreeNavigation(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+
+	// Test empty tree
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Expected false for Min of empty tree")
+	}
+	if _, _, ok := tree.Max(); ok {
+		t.Error("Expected false for Max of empty tree")
+	}
+	if _, _, ok := tree.Floor(1); ok {
+		t.Error("Expected false for Floor of empty tree")
+	}
+
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.Set(k, "v")
+	}
+
+	if k, _, ok := tree.Min(); !ok || k != 10 {
+		t.Errorf("Expected Min 10, got (%d, %t)", k, ok)
+	}
+	if k, _, ok := tree.Max(); !ok || k != 40 {
+		t.Errorf("Expected Max 40, got (%d, %t)", k, ok)
+	}
+
+	tests := []struct {
+		name   string
+		fn     func(int) (int, string, bool)
+		key    int
+		want   int
+		wantOK bool
+	}{
+		{"Floor exact", tree.Floor, 20, 20, true},
+		{"Floor between", tree.Floor, 25, 20, true},
+		{"Floor below min", tree.Floor, 5, 0, false},
+		{"Ceiling exact", tree.Ceiling, 20, 20, true},
+		{"Ceiling between", tree.Ceiling, 25, 30, true},
+		{"Ceiling above max", tree.Ceiling, 45, 0, false},
+		{"Lower exact", tree.Lower, 20, 10, true},
+		{"Lower between", tree.Lower, 25, 20, true},
+		{"Lower at min", tree.Lower, 10, 0, false},
+		{"Higher exact", tree.Higher, 20, 30, true},
+		{"Higher between", tree.Higher, 25, 30, true},
+		{"Higher at max", tree.Higher, 40, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := tt.fn(tt.key)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRedBlackTreeRange(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+	for i := 1; i <= 10; i++ {
+		tree.Set(i*10, "v")
+	}
+
+	collect := func(rangeFn func(fn func(int, string) bool)) []int {
+		var keys []int
+		rangeFn(func(k int, _ string) bool {
+			keys = append(keys, k)
+			return true
+		})
+		return keys
+	}
+
+	if got := collect(tree.Range); len(got) != 10 || got[0] != 10 || got[9] != 100 {
+		t.Errorf("Range returned %v", got)
+	}
+
+	got := collect(func(fn func(int, string) bool) { tree.RangeFrom(75, fn) })
+	if want := []int{80, 90, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeFrom(75) = %v, want %v", got, want)
+	}
+
+	got = collect(func(fn func(int, string) bool) { tree.RangeBetween(30, 60, fn) })
+	if want := []int{30, 40, 50, 60}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeBetween(30, 60) = %v, want %v", got, want)
+	}
+
+	// Reversed bounds are swapped
+	got = collect(func(fn func(int, string) bool) { tree.RangeBetween(55, 25, fn) })
+	if want := []int{30, 40, 50}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeBetween(55, 25) = %v, want %v", got, want)
+	}
+
+	got = collect(func(fn func(int, string) bool) { tree.RangeBetween(101, 200, fn) })
+	if len(got) != 0 {
+		t.Errorf("Expected empty range, got %v", got)
+	}
+
+	// Early termination
+	count := 0
+	tree.Range(func(int, string) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Expected to stop after 3 calls, got %d", count)
+	}
+}
+
+func TestRedBlackTreeClone(t *testing.T) {
+	tree := NewRedBlackTree[int, []int]()
+	for i := 0; i < 100; i++ {
+		tree.Set(i, []int{i})
+	}
+
+	shallow := tree.Clone()
+	deep := tree.CloneFunc(func(v []int) []int {
+		return append([]int(nil), v...)
+	})
+
+	// Structural changes to the clone do not affect the original
+	shallow.Delete(0)
+	shallow.Set(1000, nil)
+	if tree.Len() != 100 || !tree.Has(0) || tree.Has(1000) {
+		t.Error("Expected original tree to be unaffected by clone mutations")
+	}
+	if shallow.Len() != 100 {
+		t.Errorf("Expected clone length 100, got %d", shallow.Len())
+	}
+	if k, _, _ := shallow.Kth(0); k != 1 {
+		t.Errorf("Expected clone Kth(0) = 1, got %d", k)
+	}
+
+	// Shallow clones share values, deep clones do not
+	v, _ := tree.GetMutable(5)
+	(*v)[0] = -5
+	if got, _ := shallow.Get(5); got[0] != -5 {
+		t.Errorf("Expected shallow clone to share value, got %v", got)
+	}
+	if got, _ := deep.Get(5); got[0] != 5 {
+		t.Errorf("Expected deep clone to keep its own value, got %v", got)
+	}
+	if !reflect.DeepEqual(deep.Keys(), tree.Keys()) {
+		t.Error("Expected deep clone to have the same keys")
+	}
+}
+
+func TestRedBlackTreeMerge(t *testing.T) {
+	a := NewRedBlackTree[int, int]()
+	b := NewRedBlackTree[int, int]()
+	for i := 0; i < 50; i += 2 {
+		a.Set(i, 1)
+	}
+	for i := 0; i < 60; i += 3 {
+		b.Set(i, 10)
+	}
+
+	a.Merge(b, func(key, current, incoming int) int {
+		return current + incoming
+	})
+	checkRedBlack(t, a)
+
+	for i := 0; i < 60; i++ {
+		v, ok := a.Get(i)
+		switch {
+		case i%6 == 0 && i < 50:
+			if v != 11 {
+				t.Errorf("Expected resolved value 11 for key %d, got %d", i, v)
+			}
+		case i%2 == 0 && i < 50:
+			if v != 1 {
+				t.Errorf("Expected value 1 for key %d, got %d", i, v)
+			}
+		case i%3 == 0:
+			if v != 10 {
+				t.Errorf("Expected value 10 for key %d, got %d", i, v)
+			}
+		default:
+			if ok {
+				t.Errorf("Expected key %d to be absent", i)
+			}
+		}
+	}
+	if b.Len() != 20 {
+		t.Errorf("Expected other map to be unchanged, got length %d", b.Len())
+	}
+
+	// nil resolve keeps the incoming value; the merged tree stays usable
+	a.Merge(b, nil)
+	if v, _ := a.Get(0); v != 10 {
+		t.Errorf("Expected incoming value 10, got %d", v)
+	}
+	a.Set(1000, 0)
+	a.Delete(0)
+	checkRedBlack(t, a)
+}
+
+func TestRedBlackTreeSplit(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < n; i++ {
+			tree.Set(i, i)
+		}
+
+		left, right := tree.Split(n / 3)
+		checkRedBlack(t, left)
+		checkRedBlack(t, right)
+		if left.Len() != n/3 || right.Len() != n-n/3 {
+			t.Errorf("n=%d: Expected sizes (%d, %d), got (%d, %d)", n, n/3, n-n/3, left.Len(), right.Len())
+		}
+		if k, _, ok := right.Min(); ok && k != n/3 {
+			t.Errorf("n=%d: Expected right minimum %d, got %d", n, n/3, k)
+		}
+		if tree.Len() != n {
+			t.Errorf("n=%d: Expected original to be unchanged", n)
+		}
+
+		// Split trees accept further mutations
+		for i := 0; i < 10; i++ {
+			left.Set(-i, i)
+			right.Delete(n/3 + i)
+		}
+		checkRedBlack(t, left)
+		checkRedBlack(t, right)
+	}
+}
+
+func TestRedBlackTreeNodePool(t *testing.T) {
+	tree := NewRedBlackTree[int, string](WithNodePool())
+	ref := make(map[int]string)
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 5000; i++ {
+		k := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			if tree.Delete(k) != (ref[k] != "") {
+				t.Fatalf("Delete(%d) disagreed with reference map", k)
+			}
+			delete(ref, k)
+		} else {
+			v := strconv.Itoa(i)
+			tree.Set(k, v)
+			ref[k] = v
+		}
+	}
+	checkRedBlack(t, tree)
+	if tree.Len() != len(ref) {
+		t.Errorf("Expected length %d, got %d", len(ref), tree.Len())
+	}
+	for k, v := range ref {
+		if got, ok := tree.Get(k); !ok || got != v {
+			t.Errorf("Expected %d -> %q, got %q, %v", k, v, got, ok)
+		}
+	}
+
+	// Clone and Split share the pool and stay independent of the original
+	clone := tree.Clone()
+	left, right := tree.Split(150)
+	tree.Clear()
+	if tree.Len() != 0 || tree.root != nil {
+		t.Error("Expected empty tree after Clear")
+	}
+	if clone.Len() != len(ref) || left.Len()+right.Len() != len(ref) {
+		t.Error("Expected Clone and Split results to survive Clear")
+	}
+	checkRedBlack(t, clone)
+	for k, v := range ref {
+		if got, _ := clone.Get(k); got != v {
+			t.Errorf("Expected clone %d -> %q, got %q", k, v, got)
+		}
+	}
+
+	// Nodes recycled into the cleared tree must start out clean
+	for i := 0; i < 100; i++ {
+		tree.Set(i, "x")
+	}
+	checkRedBlack(t, tree)
+	tree.Merge(clone, nil)
+	checkRedBlack(t, tree)
+}
+
+func TestRedBlackTreeClear(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	tree.Set(1, 1)
+	tree.Set(2, 2)
+	tree.Clear()
+	if tree.Len() != 0 || tree.Has(1) {
+		t.Error("Expected empty tree after Clear")
+	}
+	tree.Set(3, 3)
+	if tree.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", tree.Len())
+	}
+}
+
+func TestRedBlackTreeValidate(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Set(i, i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Expected valid tree, got %v", err)
+	}
+
+	// The largest key of the left subtree is ordered against its parent and
+	// children, but raising it past the root breaks the root's ordering
+	maxLeft := tree.root.left
+	for maxLeft.right != nil {
+		maxLeft = maxLeft.right
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func()
+		restore func()
+	}{
+		{"red root", func() { tree.root.color = red }, func() { tree.root.color = black }},
+		{"wrong size", func() { tree.size++ }, func() { tree.size-- }},
+		{"subtree size", func() { tree.root.left.size++ }, func() { tree.root.left.size-- }},
+		{"ordering", func() { tree.root.left.key += 1000 }, func() { tree.root.left.key -= 1000 }},
+		{"ancestor ordering", func() { maxLeft.key += 1000 }, func() { maxLeft.key -= 1000 }},
+		{"parent link", func() { tree.root.right.parent = nil }, func() { tree.root.right.parent = tree.root }},
+		{"black height", func() { tree.root.left.color = !tree.root.left.color }, func() { tree.root.left.color = !tree.root.left.color }},
+	}
+	for _, tt := range tests {
+		tt.corrupt()
+		if err := tree.Validate(); err == nil {
+			t.Errorf("Expected error for corrupted %s", tt.name)
+		}
+		tt.restore()
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Expected valid tree after restoring, got %v", err)
+	}
+}
+
 func TestRedBlackTreeInterfaceCompliance(t *testing.T) {
 	// This test ensures RedBlackTree implements Interface
 	var _ Interface[int, string] = NewRedBlackTree[int, string]()
 }
+
+// benchSize is the number of keys used by the 1M-key benchmark workloads.
+const benchSize = 1 << 20
+
+func benchKeys() []int {
+	rng := rand.New(rand.NewSource(42))
+	return rng.Perm(benchSize)
+}
+
+func BenchmarkRedBlackTreeSetRandom(b *testing.B) {
+	keys := benchKeys()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree := NewRedBlackTree[int, int]()
+		for _, k := range keys {
+			tree.Set(k, k)
+		}
+	}
+}
+
+func BenchmarkRedBlackTreeSetSequential(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tree := NewRedBlackTree[int, int]()
+		for k := 0; k < benchSize; k++ {
+			tree.Set(k, k)
+		}
+	}
+}
+
+func BenchmarkRedBlackTreeDelete(b *testing.B) {
+	keys := benchKeys()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tree := NewRedBlackTree[int, int]()
+		for _, k := range keys {
+			tree.Set(k, k)
+		}
+		b.StartTimer()
+		for _, k := range keys {
+			tree.Delete(k)
+		}
+	}
+}
+
+func benchmarkChurn(b *testing.B, opts ...Option) {
+	keys := benchKeys()[:1<<16]
+	tree := NewRedBlackTree[int, int](opts...)
+	for _, k := range keys {
+		tree.Set(k, k)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(len(keys)-1)]
+		tree.Delete(k)
+		tree.Set(k, i)
+	}
+}
+
+func BenchmarkRedBlackTreeChurn(b *testing.B) {
+	benchmarkChurn(b)
+}
+
+func BenchmarkRedBlackTreeChurnPooled(b *testing.B) {
+	benchmarkChurn(b, WithNodePool())
+}
+
+func BenchmarkRedBlackTreeKeys(b *testing.B) {
+	tree := NewRedBlackTree[int, int]()
+	for _, k := range benchKeys() {
+		tree.Set(k, k)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tree.Keys()
+	}
+}
+
+// checkRedBlack fails the test if tree violates any red-black tree invariant.
+func checkRedBlack[K cmp.Ordered, V any](t *testing.T, tree *RedBlackTree[K, V]) {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
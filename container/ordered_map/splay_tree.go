@@ -0,0 +1,411 @@
+// Package ordered_map provides an ordered map implementation using Splay Tree.
+// This file implements the Interface[K, V] using a top-down Splay Tree.
+
+package ordered_map
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+// splayNode is a node in the Splay Tree.
+type splayNode[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	left  *splayNode[K, V]
+	right *splayNode[K, V]
+}
+
+// SplayTree implements the ordered_map.Interface using a Splay Tree.
+// Every access rotates the touched key to the root, so repeated lookups of
+// recently used keys are nearly O(1), with O(log n) amortized cost overall.
+//
+// Because lookups restructure the tree, a SplayTree is not safe for
+// concurrent reads unless splaying is disabled with SetSplaying(false).
+type SplayTree[K cmp.Ordered, V any] struct {
+	root    *splayNode[K, V]
+	size    int
+	noSplay bool // when true, read operations do not restructure the tree
+}
+
+// NewSplayTree creates a new SplayTree with splaying enabled.
+func NewSplayTree[K cmp.Ordered, V any]() *SplayTree[K, V] {
+	return &SplayTree[K, V]{}
+}
+
+// SetSplaying enables or disables splaying on read operations (Get, GetMutable, Has).
+// With splaying disabled, reads never modify the tree and may run concurrently
+// as long as no writer is active. Set and Delete always splay.
+func (t *SplayTree[K, V]) SetSplaying(enabled bool) {
+	t.noSplay = !enabled
+}
+
+// Len returns the number of elements in the map.
+func (t *SplayTree[K, V]) Len() int {
+	return t.size
+}
+
+// Cap returns the capacity of the map. For Splay Tree, capacity equals size since it's dynamic.
+func (t *SplayTree[K, V]) Cap() int {
+	return t.size
+}
+
+// find returns the node holding key, splaying it to the root when enabled.
+func (t *SplayTree[K, V]) find(key K) *splayNode[K, V] {
+	if t.noSplay {
+		n := t.root
+		for n != nil {
+			if cmp.Less(key, n.key) {
+				n = n.left
+			} else if cmp.Less(n.key, key) {
+				n = n.right
+			} else {
+				return n
+			}
+		}
+		return nil
+	}
+
+	t.root = splay(t.root, key)
+	if t.root != nil && cmp.Compare(t.root.key, key) == 0 {
+		return t.root
+	}
+	return nil
+}
+
+// Get searches for a key and returns its value and existence.
+func (t *SplayTree[K, V]) Get(key K) (V, bool) {
+	if n := t.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// GetMutable returns a pointer to the value for mutation.
+func (t *SplayTree[K, V]) GetMutable(key K) (*V, bool) {
+	if n := t.find(key); n != nil {
+		return &n.value, true
+	}
+	return nil, false
+}
+
+// Has checks if a key exists in the map.
+func (t *SplayTree[K, V]) Has(key K) bool {
+	return t.find(key) != nil
+}
+
+// Set inserts or updates a key-value pair.
+func (t *SplayTree[K, V]) Set(key K, value V) {
+	if t.root == nil {
+		t.root = &splayNode[K, V]{key: key, value: value}
+		t.size++
+		return
+	}
+
+	// Splay the closest key to the root, then split around it
+	t.root = splay(t.root, key)
+	if cmp.Compare(t.root.key, key) == 0 {
+		t.root.value = value
+		return
+	}
+
+	n := &splayNode[K, V]{key: key, value: value}
+	if cmp.Less(key, t.root.key) {
+		n.left = t.root.left
+		n.right = t.root
+		t.root.left = nil
+	} else {
+		n.right = t.root.right
+		n.left = t.root
+		t.root.right = nil
+	}
+	t.root = n
+	t.size++
+}
+
+// Delete removes a key from the map.
+func (t *SplayTree[K, V]) Delete(key K) bool {
+	if t.root == nil {
+		return false
+	}
+
+	t.root = splay(t.root, key)
+	if cmp.Compare(t.root.key, key) != 0 {
+		return false
+	}
+
+	// Join the two subtrees: splay the maximum of the left subtree to its
+	// root, where it has no right child, and hang the right subtree there
+	if t.root.left == nil {
+		t.root = t.root.right
+	} else {
+		right := t.root.right
+		t.root = splay(t.root.left, key)
+		t.root.right = right
+	}
+	t.size--
+	return true
+}
+
+// splay performs a top-down splay of key on the subtree rooted at n and
+// returns the new root. If key is absent, the last node on the search path
+// becomes the root.
+func splay[K cmp.Ordered, V any](n *splayNode[K, V], key K) *splayNode[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	// header collects the left tree in header.right and the right tree in header.left
+	var header splayNode[K, V]
+	leftMax, rightMin := &header, &header
+
+	for {
+		if cmp.Less(key, n.key) {
+			if n.left == nil {
+				break
+			}
+			if cmp.Less(key, n.left.key) {
+				// Zig-zig: rotate right
+				y := n.left
+				n.left = y.right
+				y.right = n
+				n = y
+				if n.left == nil {
+					break
+				}
+			}
+			// Link right
+			rightMin.left = n
+			rightMin = n
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			if n.right == nil {
+				break
+			}
+			if cmp.Less(n.right.key, key) {
+				// Zag-zag: rotate left
+				y := n.right
+				n.right = y.left
+				y.left = n
+				n = y
+				if n.right == nil {
+					break
+				}
+			}
+			// Link left
+			leftMax.right = n
+			leftMax = n
+			n = n.right
+		} else {
+			break
+		}
+	}
+
+	// Reassemble
+	leftMax.right = n.left
+	rightMin.left = n.right
+	n.left = header.right
+	n.right = header.left
+	return n
+}
+
+// Min returns the smallest key and its value.
+// Like the other navigation methods below, it does not splay.
+// Returns zero values and false if the map is empty.
+func (t *SplayTree[K, V]) Min() (K, V, bool) {
+	n := t.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the largest key and its value.
+// Returns zero values and false if the map is empty.
+func (t *SplayTree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *SplayTree[K, V]) Floor(key K) (K, V, bool) {
+	var found *splayNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			found = n
+			n = n.right
+		} else {
+			return n.key, n.value, true
+		}
+	}
+	return splayNodeEntry(found)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *SplayTree[K, V]) Ceiling(key K) (K, V, bool) {
+	var found *splayNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			found = n
+			n = n.left
+		} else if cmp.Less(n.key, key) {
+			n = n.right
+		} else {
+			return n.key, n.value, true
+		}
+	}
+	return splayNodeEntry(found)
+}
+
+// Lower returns the largest key strictly less than key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *SplayTree[K, V]) Lower(key K) (K, V, bool) {
+	var found *splayNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(n.key, key) {
+			found = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return splayNodeEntry(found)
+}
+
+// Higher returns the smallest key strictly greater than key, and its value.
+// Returns zero values and false if no such key exists.
+func (t *SplayTree[K, V]) Higher(key K) (K, V, bool) {
+	var found *splayNode[K, V]
+	n := t.root
+	for n != nil {
+		if cmp.Less(key, n.key) {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return splayNodeEntry(found)
+}
+
+// splayNodeEntry unpacks a node into key, value and existence.
+func splayNodeEntry[K cmp.Ordered, V any](n *splayNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// Keys returns all keys in order.
+func (t *SplayTree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+		keys = append(keys, n.key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in order.
+func (t *SplayTree[K, V]) Values() []V {
+	values := make([]V, 0, t.size)
+	splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+		values = append(values, n.value)
+		return true
+	})
+	return values
+}
+
+// Pairs returns all key-value pairs in order.
+func (t *SplayTree[K, V]) Pairs() []pair.Pair[K, V] {
+	pairs := make([]pair.Pair[K, V], 0, t.size)
+	splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+		pairs = append(pairs, pair.Pair[K, V]{First: n.key, Second: n.value})
+		return true
+	})
+	return pairs
+}
+
+// splayInOrder performs an iterative in-order traversal without restructuring
+// the tree, so it is safe alongside concurrent non-splaying reads.
+// Stops early if visit returns false.
+func splayInOrder[K cmp.Ordered, V any](root *splayNode[K, V], visit func(*splayNode[K, V]) bool) {
+	var stack []*splayNode[K, V]
+	current := root
+
+	for len(stack) > 0 || current != nil {
+		// Go to the leftmost node
+		for current != nil {
+			stack = append(stack, current)
+			current = current.left
+		}
+
+		current = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !visit(current) {
+			return
+		}
+
+		// Visit the right subtree
+		current = current.right
+	}
+}
+
+// Clone returns an independent copy of the tree in O(n).
+// Values are copied shallowly; use CloneFunc to deep-copy them.
+func (t *SplayTree[K, V]) Clone() *SplayTree[K, V] {
+	return t.CloneFunc(nil)
+}
+
+// CloneFunc returns an independent copy of the tree in O(n), passing every
+// value through cloneValue. A nil cloneValue copies values shallowly.
+// The copy keeps the current shape and splaying setting.
+func (t *SplayTree[K, V]) CloneFunc(cloneValue func(V) V) *SplayTree[K, V] {
+	return &SplayTree[K, V]{
+		root:    cloneSplaySubtree(t.root, cloneValue),
+		size:    t.size,
+		noSplay: t.noSplay,
+	}
+}
+
+// cloneSplaySubtree copies the subtree rooted at n.
+func cloneSplaySubtree[K cmp.Ordered, V any](n *splayNode[K, V], cloneValue func(V) V) *splayNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := &splayNode[K, V]{key: n.key, value: n.value}
+	if cloneValue != nil {
+		c.value = cloneValue(n.value)
+	}
+	c.left = cloneSplaySubtree(n.left, cloneValue)
+	c.right = cloneSplaySubtree(n.right, cloneValue)
+	return c
+}
+
+// Ensure SplayTree implements Interface
+var _ Interface[int, int] = (*SplayTree[int, int])(nil)
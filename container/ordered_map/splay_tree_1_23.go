@@ -0,0 +1,38 @@
+//go:build go1.23
+// +build go1.23
+
+// Package ordered_map provides go1.23-specific methods for SplayTree.
+// This file adds iter.Seq related methods for Interface.
+
+package ordered_map
+
+import (
+	"iter"
+)
+
+// KeySeq returns an iterator for keys (go1.23).
+func (t *SplayTree[K, V]) KeySeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+// ValueSeq returns an iterator for values (go1.23).
+func (t *SplayTree[K, V]) ValueSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+			return yield(n.value)
+		})
+	}
+}
+
+// PairSeq returns an iterator for key-value pairs (go1.23).
+func (t *SplayTree[K, V]) PairSeq() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		splayInOrder(t.root, func(n *splayNode[K, V]) bool {
+			return yield(n.key, n.value)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+//go:build go1.23
+// +build go1.23
+
+package ordered_map
+
+import (
+	"testing"
+)
+
+func TestSplayTreeIterators(t *testing.T) {
+	tree := NewSplayTree[int, string]()
+	tree.Set(3, "three")
+	tree.Set(1, "one")
+	tree.Set(2, "two")
+
+	var keys []int
+	for k := range tree.KeySeq() {
+		keys = append(keys, k)
+	}
+	if len(keys) != 3 || keys[0] != 1 || keys[1] != 2 || keys[2] != 3 {
+		t.Errorf("Expected keys [1 2 3], got %v", keys)
+	}
+
+	var values []string
+	for v := range tree.ValueSeq() {
+		values = append(values, v)
+	}
+	if len(values) != 3 || values[0] != "one" || values[2] != "three" {
+		t.Errorf("Expected values [one two three], got %v", values)
+	}
+
+	count := 0
+	for k, v := range tree.PairSeq() {
+		count++
+		if k == 2 {
+			if v != "two" {
+				t.Errorf("Expected 'two', got '%s'", v)
+			}
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected to stop at 2 iterations, got %d", count)
+	}
+}
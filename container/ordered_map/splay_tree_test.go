@@ -0,0 +1,217 @@
+package ordered_map
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSplayTreeBasic(t *testing.T) {
+	tree := NewSplayTree[int, string]()
+
+	// Test empty tree
+	if tree.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", tree.Len())
+	}
+	if _, ok := tree.Get(1); ok {
+		t.Error("Expected false when getting from empty tree")
+	}
+	if tree.Delete(1) {
+		t.Error("Expected false when deleting from empty tree")
+	}
+
+	tree.Set(5, "five")
+	tree.Set(3, "three")
+	tree.Set(7, "seven")
+	tree.Set(3, "THREE")
+
+	if tree.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", tree.Len())
+	}
+	if val, ok := tree.Get(3); !ok || val != "THREE" {
+		t.Errorf("Expected ('THREE', true), got ('%s', %t)", val, ok)
+	}
+	if ptr, ok := tree.GetMutable(7); ok {
+		*ptr = "SEVEN"
+	}
+	if val, _ := tree.Get(7); val != "SEVEN" {
+		t.Errorf("Expected 'SEVEN', got '%s'", val)
+	}
+	if !tree.Has(5) || tree.Has(6) {
+		t.Error("Has returned unexpected result")
+	}
+
+	if !reflect.DeepEqual(tree.Keys(), []int{3, 5, 7}) {
+		t.Errorf("Expected keys [3 5 7], got %v", tree.Keys())
+	}
+	if !reflect.DeepEqual(tree.Values(), []string{"THREE", "five", "SEVEN"}) {
+		t.Errorf("Expected values [THREE five SEVEN], got %v", tree.Values())
+	}
+	if len(tree.Pairs()) != 3 || tree.Pairs()[0].First != 3 {
+		t.Errorf("Unexpected pairs %v", tree.Pairs())
+	}
+}
+
+func TestSplayTreeHotKeyAtRoot(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Set(i, i)
+	}
+
+	tree.Get(42)
+	if tree.root.key != 42 {
+		t.Errorf("Expected accessed key 42 at root, got %d", tree.root.key)
+	}
+
+	// With splaying disabled, reads leave the shape untouched
+	tree.SetSplaying(false)
+	tree.Get(7)
+	if tree.root.key != 42 {
+		t.Errorf("Expected root to stay 42 with splaying disabled, got %d", tree.root.key)
+	}
+	if val, ok := tree.Get(7); !ok || val != 7 {
+		t.Errorf("Expected (7, true), got (%d, %t)", val, ok)
+	}
+}
+
+func TestSplayTreeNavigation(t *testing.T) {
+	tree := NewSplayTree[int, string]()
+
+	// Test empty tree
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Expected false for Min of empty tree")
+	}
+	if _, _, ok := tree.Max(); ok {
+		t.Error("Expected false for Max of empty tree")
+	}
+	if _, _, ok := tree.Floor(1); ok {
+		t.Error("Expected false for Floor of empty tree")
+	}
+
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.Set(k, "v")
+	}
+
+	if k, _, ok := tree.Min(); !ok || k != 10 {
+		t.Errorf("Expected Min 10, got (%d, %t)", k, ok)
+	}
+	if k, _, ok := tree.Max(); !ok || k != 40 {
+		t.Errorf("Expected Max 40, got (%d, %t)", k, ok)
+	}
+
+	tests := []struct {
+		name   string
+		fn     func(int) (int, string, bool)
+		key    int
+		want   int
+		wantOK bool
+	}{
+		{"Floor exact", tree.Floor, 20, 20, true},
+		{"Floor between", tree.Floor, 25, 20, true},
+		{"Floor below min", tree.Floor, 5, 0, false},
+		{"Ceiling exact", tree.Ceiling, 20, 20, true},
+		{"Ceiling between", tree.Ceiling, 25, 30, true},
+		{"Ceiling above max", tree.Ceiling, 45, 0, false},
+		{"Lower exact", tree.Lower, 20, 10, true},
+		{"Lower between", tree.Lower, 25, 20, true},
+		{"Lower at min", tree.Lower, 10, 0, false},
+		{"Higher exact", tree.Higher, 20, 30, true},
+		{"Higher between", tree.Higher, 25, 30, true},
+		{"Higher at max", tree.Higher, 40, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := tt.fn(tt.key)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSplayTreeClone(t *testing.T) {
+	tree := NewSplayTree[int, string]()
+	for i := 0; i < 10; i++ {
+		tree.Set(i, "v")
+	}
+	tree.SetSplaying(false)
+
+	clone := tree.Clone()
+	clone.Delete(3)
+	clone.Set(3, "changed")
+	if val, _ := tree.Get(3); val != "v" {
+		t.Errorf("Expected original value 'v', got '%s'", val)
+	}
+	if !reflect.DeepEqual(clone.Keys(), tree.Keys()) {
+		t.Error("Expected clone to have the same keys")
+	}
+
+	// Splaying setting is preserved
+	root := clone.root
+	clone.Get(9)
+	if clone.root != root {
+		t.Error("Expected clone to keep splaying disabled")
+	}
+}
+
+func TestSplayTreeRandomOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewSplayTree[int, int]()
+	ref := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		k := rng.Intn(500)
+		switch rng.Intn(3) {
+		case 0:
+			tree.Set(k, i)
+			ref[k] = i
+		case 1:
+			_, want := ref[k]
+			if got := tree.Delete(k); got != want {
+				t.Fatalf("Delete(%d) = %t, want %t", k, got, want)
+			}
+			delete(ref, k)
+		default:
+			want, wantOK := ref[k]
+			if got, ok := tree.Get(k); ok != wantOK || got != want {
+				t.Fatalf("Get(%d) = (%d, %t), want (%d, %t)", k, got, ok, want, wantOK)
+			}
+		}
+	}
+
+	if tree.Len() != len(ref) {
+		t.Fatalf("Expected length %d, got %d", len(ref), tree.Len())
+	}
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if !reflect.DeepEqual(tree.Keys(), keys) {
+		t.Error("Keys do not match reference map")
+	}
+}
+
+func TestSplayTreeConcurrentReadsWithoutSplaying(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Set(i, i*2)
+	}
+	tree.SetSplaying(false)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < 1000; i += 4 {
+				if v, ok := tree.Get(i); !ok || v != i*2 {
+					t.Errorf("Get(%d) = (%d, %t)", i, v, ok)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
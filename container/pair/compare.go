@@ -0,0 +1,51 @@
+// Package pair provides a generic two-element tuple.
+// This file implements comparators for Pair, in the form taken by
+// slices.SortFunc and the ordered containers: negative, zero or positive
+// when the first argument sorts before, with or after the second.
+
+package pair
+
+import (
+	"cmp"
+
+	"github.com/feepwang/br/container/cmpx"
+)
+
+// CompareLex compares pairs lexicographically: by First, then by Second.
+func CompareLex[A, B cmp.Ordered](x, y Pair[A, B]) int {
+	if c := cmp.Compare(x.First, y.First); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Second, y.Second)
+}
+
+// CompareLexFunc returns a comparator ordering pairs by First using
+// compareFirst, then by Second using compareSecond, for element types that
+// are not cmp.Ordered.
+func CompareLexFunc[A, B any](compareFirst func(a, b A) int, compareSecond func(a, b B) int) func(x, y Pair[A, B]) int {
+	return func(x, y Pair[A, B]) int {
+		if c := compareFirst(x.First, y.First); c != 0 {
+			return c
+		}
+		return compareSecond(x.Second, y.Second)
+	}
+}
+
+// ByFirst compares pairs by First alone, so pairs with equal First values
+// compare equal; use it with a stable sort to keep their order.
+func ByFirst[A cmp.Ordered, B any](x, y Pair[A, B]) int {
+	return cmp.Compare(x.First, y.First)
+}
+
+// BySecond compares pairs by Second alone, so pairs with equal Second
+// values compare equal; use it with a stable sort to keep their order.
+func BySecond[A any, B cmp.Ordered](x, y Pair[A, B]) int {
+	return cmp.Compare(x.Second, y.Second)
+}
+
+// Reversed returns a comparator ordering values the opposite way to compare.
+// It is cmpx.Reverse, kept here so pair orderings compose without a second
+// import.
+func Reversed[T any](compare func(a, b T) int) func(a, b T) int {
+	return cmpx.Reverse(compare)
+}
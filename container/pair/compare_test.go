@@ -0,0 +1,59 @@
+package pair
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// sortPairs sorts a copy of pairs stably with compare.
+func sortPairs[A, B any](pairs []Pair[A, B], compare func(x, y Pair[A, B]) int) []Pair[A, B] {
+	sorted := append([]Pair[A, B](nil), pairs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
+
+func TestCompareLex(t *testing.T) {
+	pairs := []Pair[int, string]{{2, "a"}, {1, "b"}, {2, "A"}, {1, "a"}}
+
+	want := []Pair[int, string]{{1, "a"}, {1, "b"}, {2, "A"}, {2, "a"}}
+	if got := sortPairs(pairs, CompareLex[int, string]); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareLex: expected %v, got %v", want, got)
+	}
+	if CompareLex(Pair[int, string]{1, "x"}, Pair[int, string]{1, "x"}) != 0 {
+		t.Error("Expected equal pairs to compare equal")
+	}
+
+	// Case-insensitive on Second keeps {2, "a"} and {2, "A"} in input order
+	lexFold := CompareLexFunc(func(a, b int) int { return a - b }, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	want = []Pair[int, string]{{1, "a"}, {1, "b"}, {2, "a"}, {2, "A"}}
+	if got := sortPairs(pairs, lexFold); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareLexFunc with folding: expected %v, got %v", want, got)
+	}
+}
+
+func TestByFirstAndSecond(t *testing.T) {
+	pairs := []Pair[string, int]{{"b", 1}, {"a", 2}, {"b", 0}, {"a", 1}}
+
+	want := []Pair[string, int]{{"a", 2}, {"a", 1}, {"b", 1}, {"b", 0}}
+	if got := sortPairs(pairs, ByFirst[string, int]); !reflect.DeepEqual(got, want) {
+		t.Errorf("ByFirst: expected %v, got %v", want, got)
+	}
+	want = []Pair[string, int]{{"b", 0}, {"b", 1}, {"a", 1}, {"a", 2}}
+	if got := sortPairs(pairs, BySecond[string, int]); !reflect.DeepEqual(got, want) {
+		t.Errorf("BySecond: expected %v, got %v", want, got)
+	}
+}
+
+func TestReversed(t *testing.T) {
+	pairs := []Pair[int, int]{{1, 2}, {3, 0}, {1, 1}}
+	want := []Pair[int, int]{{3, 0}, {1, 2}, {1, 1}}
+	if got := sortPairs(pairs, Reversed(CompareLex[int, int])); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
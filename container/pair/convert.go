@@ -0,0 +1,43 @@
+// Package pair provides a generic two-element tuple.
+// This file implements conversions between pairs, slices and maps.
+
+package pair
+
+// Zip pairs keys[i] with values[i], stopping at the end of the shorter
+// slice.
+func Zip[K, V any](keys []K, values []V) []Pair[K, V] {
+	pairs := make([]Pair[K, V], min(len(keys), len(values)))
+	for i := range pairs {
+		pairs[i] = Pair[K, V]{First: keys[i], Second: values[i]}
+	}
+	return pairs
+}
+
+// Unzip splits pairs into their First and Second values, reversing Zip.
+func Unzip[K, V any](pairs []Pair[K, V]) ([]K, []V) {
+	keys := make([]K, len(pairs))
+	values := make([]V, len(pairs))
+	for i, p := range pairs {
+		keys[i], values[i] = p.First, p.Second
+	}
+	return keys, values
+}
+
+// FromMap returns the entries of m as pairs in no particular order.
+func FromMap[K comparable, V any](m map[K]V) []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair[K, V]{First: k, Second: v})
+	}
+	return pairs
+}
+
+// ToMap returns a map from the First to the Second value of each pair.
+// When several pairs share a First value, the last one wins.
+func ToMap[K comparable, V any](pairs []Pair[K, V]) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, p := range pairs {
+		m[p.First] = p.Second
+	}
+	return m
+}
@@ -0,0 +1,42 @@
+//go:build go1.23
+// +build go1.23
+
+// Package pair provides a generic two-element tuple.
+// This file implements conversions between pairs and iterators (go1.23).
+
+package pair
+
+import (
+	"iter"
+
+	"github.com/feepwang/br/container/iterx"
+)
+
+// All returns an iterator over the First and Second values of each pair,
+// in order, such as for feeding maps.Collect.
+func All[K, V any](pairs []Pair[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, p := range pairs {
+			if !yield(p.First, p.Second) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns the key-value pairs of seq, such as the All and PairSeq iterators
+// of the ordered containers or maps.All, as pairs in iteration order.
+func Collect[K, V any](seq iter.Seq2[K, V]) []Pair[K, V] {
+	var pairs []Pair[K, V]
+	for k, v := range seq {
+		pairs = append(pairs, Pair[K, V]{First: k, Second: v})
+	}
+	return pairs
+}
+
+// ZipSeq returns an iterator pairing the values of keys and values,
+// stopping when either is exhausted. It is the iterator counterpart of Zip
+// and calls iterx.Zip.
+func ZipSeq[K, V any](keys iter.Seq[K], values iter.Seq[V]) iter.Seq2[K, V] {
+	return iterx.Zip(keys, values)
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+// +build go1.23
+
+package pair
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSeqConversions(t *testing.T) {
+	pairs := []Pair[string, int]{{"a", 1}, {"b", 2}, {"a", 3}}
+	if got := Collect(All(pairs)); !reflect.DeepEqual(got, pairs) {
+		t.Errorf("Expected %v, got %v", pairs, got)
+	}
+	if got := maps.Collect(All(pairs)); !reflect.DeepEqual(got, map[string]int{"a": 3, "b": 2}) {
+		t.Errorf("Expected map[a:3 b:2], got %v", got)
+	}
+
+	for range All(pairs) {
+		break // stopping early must not panic
+	}
+	if got := Collect(maps.All(map[string]int{})); len(got) != 0 {
+		t.Errorf("Expected no pairs, got %v", got)
+	}
+}
+
+func TestZipSeq(t *testing.T) {
+	got := Collect(ZipSeq(slices.Values([]string{"a", "b", "c"}), slices.Values([]int{1, 2})))
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got = Collect(ZipSeq(slices.Values([]string{"a"}), slices.Values([]int{1, 2})))
+	if !reflect.DeepEqual(got, []Pair[string, int]{{"a", 1}}) {
+		t.Errorf("Expected [{a 1}], got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package pair
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestZipUnzip(t *testing.T) {
+	pairs := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("Expected %v, got %v", want, pairs)
+	}
+
+	keys, values := Unzip(pairs)
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) || !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("Expected [a b] and [1 2], got %v and %v", keys, values)
+	}
+	if len(Zip[int, int](nil, []int{1})) != 0 {
+		t.Error("Expected zipping with an empty slice to be empty")
+	}
+}
+
+func TestMapConversions(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	pairs := FromMap(m)
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].First < pairs[j].First })
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("Expected %v, got %v", want, pairs)
+	}
+	if !reflect.DeepEqual(ToMap(pairs), m) {
+		t.Errorf("Expected %v, got %v", m, ToMap(pairs))
+	}
+
+	// The last pair with a given First value wins
+	if got := ToMap([]Pair[string, int]{{"a", 1}, {"a", 2}}); got["a"] != 2 || len(got) != 1 {
+		t.Errorf("Expected map[a:2], got %v", got)
+	}
+}
@@ -0,0 +1,45 @@
+// Package pair provides a generic two-element tuple.
+// This file implements KV, a Pair encoding to JSON as a key and a value.
+
+package pair
+
+import (
+	"encoding/json"
+)
+
+// KV is a Pair that encodes to JSON as {"key": ..., "value": ...}, for
+// serializing entries of the ordered containers in API responses. Convert
+// a single Pair with KV[K, V](p) and a slice with KVs.
+type KV[K, V any] Pair[K, V]
+
+// kvJSON is the JSON form of KV.
+type kvJSON[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// KVs converts pairs, such as those returned by the Pairs methods of the
+// ordered containers, to KVs.
+func KVs[K, V any](pairs []Pair[K, V]) []KV[K, V] {
+	kvs := make([]KV[K, V], len(pairs))
+	for i, p := range pairs {
+		kvs[i] = KV[K, V](p)
+	}
+	return kvs
+}
+
+// MarshalJSON implements json.Marshaler.
+func (kv KV[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(kvJSON[K, V]{Key: kv.First, Value: kv.Second})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Fields missing from data keep
+// their current values, as with struct decoding.
+func (kv *KV[K, V]) UnmarshalJSON(data []byte) error {
+	v := kvJSON[K, V]{Key: kv.First, Value: kv.Second}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	kv.First, kv.Second = v.Key, v.Value
+	return nil
+}
@@ -0,0 +1,59 @@
+package pair
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPairJSON(t *testing.T) {
+	data, err := json.Marshal(Pair[string, []int]{First: "a", Second: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"first":"a","second":[1,2]}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var p Pair[string, []int]
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if p.First != "a" || !reflect.DeepEqual(p.Second, []int{1, 2}) {
+		t.Errorf("Expected (a, [1 2]), got %v", p)
+	}
+}
+
+func TestKVJSON(t *testing.T) {
+	kvs := KVs([]Pair[string, int]{{"a", 1}, {"b", 2}})
+	data, err := json.Marshal(kvs)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `[{"key":"a","value":1},{"key":"b","value":2}]`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded []KV[string, int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, kvs) {
+		t.Errorf("Expected %v, got %v", kvs, decoded)
+	}
+
+	// Missing fields keep their values; mistyped ones are errors
+	kv := KV[string, int]{First: "x", Second: 7}
+	if err := json.Unmarshal([]byte(`{"value":8}`), &kv); err != nil || kv != (KV[string, int]{"x", 8}) {
+		t.Errorf("Expected (x, 8), got %v (err=%v)", kv, err)
+	}
+	if err := json.Unmarshal([]byte(`{"key":1}`), &kv); err == nil {
+		t.Error("Expected an error for a mistyped key")
+	}
+
+	// A KV nested in a struct uses its own encoding
+	wrapped, _ := json.Marshal(struct{ Entry KV[int, bool] }{KV[int, bool]{1, true}})
+	if want := `{"Entry":{"key":1,"value":true}}`; string(wrapped) != want {
+		t.Errorf("Expected %s, got %s", want, wrapped)
+	}
+}
@@ -1,6 +1,9 @@
+// Package pair provides a generic two-element tuple.
 package pair
 
+// Pair holds two values of possibly different types, such as a key and its
+// value. It encodes to JSON as {"first": ..., "second": ...}.
 type Pair[A, B any] struct {
-	First  A
-	Second B
+	First  A `json:"first"`
+	Second B `json:"second"`
 }
@@ -0,0 +1,56 @@
+// Package reservoir provides samplers that keep a fixed-size random sample
+// of a stream of unknown length.
+// This file implements the options accepted by the sampler constructors.
+
+package reservoir
+
+import (
+	"math/rand"
+)
+
+// Option configures a sampler created by NewReservoirSampler or
+// NewWeightedReservoirSampler.
+type Option func(*options)
+
+type options struct {
+	rng *rand.Rand // source of randomness, nil for the shared global source
+}
+
+// newOptions returns the defaults updated by opts.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// random returns a pseudo-random number in [0, 1).
+func (o *options) random() float64 {
+	if o.rng == nil {
+		return rand.Float64()
+	}
+	return o.rng.Float64()
+}
+
+// intn returns a pseudo-random number in [0, n).
+func (o *options) intn(n int) int {
+	if o.rng == nil {
+		return rand.Intn(n)
+	}
+	return o.rng.Intn(n)
+}
+
+// WithRandSource makes the sampler draw from src instead of the shared
+// global source, so the sample is reproducible for a given stream. Like the
+// sampler itself, a rand.Source is not safe for concurrent use.
+func WithRandSource(src rand.Source) Option {
+	return func(o *options) {
+		o.rng = rand.New(src)
+	}
+}
+
+// WithSeed is shorthand for WithRandSource(rand.NewSource(seed)).
+func WithSeed(seed int64) Option {
+	return WithRandSource(rand.NewSource(seed))
+}
@@ -0,0 +1,70 @@
+// Package reservoir provides samplers that keep a fixed-size random sample
+// of a stream of unknown length.
+package reservoir
+
+// ReservoirSampler keeps a uniform random sample of up to k items from a
+// stream: after n items have been offered, each of them is in the sample
+// with probability min(k, n)/n. It uses Algorithm R, holding only the k
+// sampled items in memory, which suits sampling events for diagnostics
+// without storing the stream.
+type ReservoirSampler[T any] struct {
+	k      int
+	sample []T
+	seen   int // number of items offered
+	opts   options
+}
+
+// NewReservoirSampler creates a sampler keeping up to k items. k is raised
+// to at least 1.
+func NewReservoirSampler[T any](k int, opts ...Option) *ReservoirSampler[T] {
+	k = max(k, 1)
+	return &ReservoirSampler[T]{
+		k:      k,
+		sample: make([]T, 0, k),
+		opts:   newOptions(opts),
+	}
+}
+
+// K returns the maximum size of the sample.
+func (s *ReservoirSampler[T]) K() int {
+	return s.k
+}
+
+// Len returns the number of items in the sample, min(k, Seen()).
+func (s *ReservoirSampler[T]) Len() int {
+	return len(s.sample)
+}
+
+// Seen returns the number of items offered since the sampler was created
+// or reset.
+func (s *ReservoirSampler[T]) Seen() int {
+	return s.seen
+}
+
+// Offer presents the next item of the stream to the sampler. Returns true
+// if the item was placed in the sample, possibly evicting an earlier one.
+func (s *ReservoirSampler[T]) Offer(item T) bool {
+	s.seen++
+	if len(s.sample) < s.k {
+		s.sample = append(s.sample, item)
+		return true
+	}
+	if i := s.opts.intn(s.seen); i < s.k {
+		s.sample[i] = item
+		return true
+	}
+	return false
+}
+
+// Sample returns a copy of the current sample. The order of the items is
+// unspecified.
+func (s *ReservoirSampler[T]) Sample() []T {
+	return append([]T(nil), s.sample...)
+}
+
+// Reset empties the sample and the count of items seen.
+func (s *ReservoirSampler[T]) Reset() {
+	clear(s.sample) // release references held by the items
+	s.sample = s.sample[:0]
+	s.seen = 0
+}
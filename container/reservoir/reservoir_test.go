@@ -0,0 +1,94 @@
+package reservoir
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReservoirSamplerShortStream(t *testing.T) {
+	s := NewReservoirSampler[int](5, WithSeed(1))
+	for i := 0; i < 3; i++ {
+		if !s.Offer(i) {
+			t.Errorf("Expected item %d to be sampled while the reservoir is not full", i)
+		}
+	}
+	if s.Len() != 3 || s.Seen() != 3 || s.K() != 5 {
+		t.Errorf("Expected Len 3, Seen 3, K 5, got %d, %d, %d", s.Len(), s.Seen(), s.K())
+	}
+	if !reflect.DeepEqual(s.Sample(), []int{0, 1, 2}) {
+		t.Errorf("Expected sample [0 1 2], got %v", s.Sample())
+	}
+
+	s.Reset()
+	if s.Len() != 0 || s.Seen() != 0 {
+		t.Errorf("Expected empty sampler after Reset, got Len %d, Seen %d", s.Len(), s.Seen())
+	}
+	if NewReservoirSampler[int](0).K() != 1 {
+		t.Error("Expected k to be raised to 1")
+	}
+}
+
+func TestReservoirSamplerUniform(t *testing.T) {
+	const n, k, trials = 20, 5, 20000
+	counts := make([]int, n)
+	s := NewReservoirSampler[int](k, WithSeed(1))
+	for trial := 0; trial < trials; trial++ {
+		s.Reset()
+		for i := 0; i < n; i++ {
+			s.Offer(i)
+		}
+		if s.Len() != k {
+			t.Fatalf("Expected sample of %d items, got %d", k, s.Len())
+		}
+		for _, item := range s.Sample() {
+			counts[item]++
+		}
+	}
+
+	// Each item is sampled with probability k/n
+	want := float64(trials) * k / n
+	for item, c := range counts {
+		if math.Abs(float64(c)-want) > 0.05*want {
+			t.Errorf("Item %d sampled %d times, want about %.0f", item, c, want)
+		}
+	}
+}
+
+func TestReservoirSamplerDistinct(t *testing.T) {
+	s := NewReservoirSampler[int](10, WithSeed(2))
+	for i := 0; i < 1000; i++ {
+		s.Offer(i)
+	}
+	sample := s.Sample()
+	sort.Ints(sample)
+	for i := 1; i < len(sample); i++ {
+		if sample[i] == sample[i-1] {
+			t.Fatalf("Expected distinct items, got %v", sample)
+		}
+	}
+
+	// The snapshot is not affected by later offers
+	snapshot := s.Sample()
+	for i := 1000; i < 2000; i++ {
+		s.Offer(i)
+	}
+	for _, item := range snapshot {
+		if item >= 1000 {
+			t.Fatalf("Snapshot changed after later offers: %v", snapshot)
+		}
+	}
+}
+
+func TestReservoirSamplerSeed(t *testing.T) {
+	a := NewReservoirSampler[int](3, WithSeed(7))
+	b := NewReservoirSampler[int](3, WithSeed(7))
+	for i := 0; i < 100; i++ {
+		a.Offer(i)
+		b.Offer(i)
+	}
+	if !reflect.DeepEqual(a.Sample(), b.Sample()) {
+		t.Errorf("Expected equal samples with equal seeds, got %v and %v", a.Sample(), b.Sample())
+	}
+}
@@ -0,0 +1,116 @@
+// Package reservoir provides samplers that keep a fixed-size random sample
+// of a stream of unknown length.
+// This file implements WeightedReservoirSampler.
+
+package reservoir
+
+import (
+	"container/heap"
+	"math"
+)
+
+// weightedItem is an item of a weighted sample with its A-ES key.
+type weightedItem[T any] struct {
+	item T
+	key  float64
+}
+
+// keyHeap is a min-heap of sampled items by key, so the item to evict is
+// at the root.
+type keyHeap[T any] []weightedItem[T]
+
+func (h keyHeap[T]) Len() int           { return len(h) }
+func (h keyHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *keyHeap[T]) Push(x any)        { *h = append(*h, x.(weightedItem[T])) }
+func (h *keyHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = weightedItem[T]{} // release references held by the item
+	*h = old[:n-1]
+	return x
+}
+
+// WeightedReservoirSampler keeps a weighted random sample of up to k items
+// from a stream, without replacement. Items are drawn with probability
+// proportional to their weight: heavier items are more likely to be in the
+// sample, and an item of weight 2 is picked first twice as often as one of
+// weight 1.
+//
+// It uses the A-ES algorithm of Efraimidis and Spirakis: each item gets the
+// key u^(1/w) for a uniform random u, and the k items with the largest keys
+// form the sample. Keys are kept in a heap, so Offer runs in O(log k).
+type WeightedReservoirSampler[T any] struct {
+	k      int
+	sample keyHeap[T]
+	seen   int // number of items offered
+	opts   options
+}
+
+// NewWeightedReservoirSampler creates a weighted sampler keeping up to k
+// items. k is raised to at least 1.
+func NewWeightedReservoirSampler[T any](k int, opts ...Option) *WeightedReservoirSampler[T] {
+	k = max(k, 1)
+	return &WeightedReservoirSampler[T]{
+		k:      k,
+		sample: make(keyHeap[T], 0, k),
+		opts:   newOptions(opts),
+	}
+}
+
+// K returns the maximum size of the sample.
+func (s *WeightedReservoirSampler[T]) K() int {
+	return s.k
+}
+
+// Len returns the number of items in the sample.
+func (s *WeightedReservoirSampler[T]) Len() int {
+	return len(s.sample)
+}
+
+// Seen returns the number of items offered with a positive weight since the
+// sampler was created or reset.
+func (s *WeightedReservoirSampler[T]) Seen() int {
+	return s.seen
+}
+
+// Offer presents the next item of the stream with the given weight. Items
+// with a weight that is not positive and finite are ignored. Returns true if
+// the item was placed in the sample, possibly evicting an earlier one.
+func (s *WeightedReservoirSampler[T]) Offer(item T, weight float64) bool {
+	if !(weight > 0) || math.IsInf(weight, 1) {
+		return false
+	}
+	s.seen++
+
+	// Compare log(u)/w rather than u^(1/w), which underflows for small weights
+	key := math.Log(1-s.opts.random()) / weight
+	if len(s.sample) < s.k {
+		heap.Push(&s.sample, weightedItem[T]{item: item, key: key})
+		return true
+	}
+	if key <= s.sample[0].key {
+		return false
+	}
+	s.sample[0] = weightedItem[T]{item: item, key: key}
+	heap.Fix(&s.sample, 0)
+	return true
+}
+
+// Sample returns a copy of the current sample. The order of the items is
+// unspecified.
+func (s *WeightedReservoirSampler[T]) Sample() []T {
+	items := make([]T, len(s.sample))
+	for i, w := range s.sample {
+		items[i] = w.item
+	}
+	return items
+}
+
+// Reset empties the sample and the count of items seen.
+func (s *WeightedReservoirSampler[T]) Reset() {
+	clear(s.sample)
+	s.sample = s.sample[:0]
+	s.seen = 0
+}
@@ -0,0 +1,89 @@
+package reservoir
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestWeightedReservoirSamplerShortStream(t *testing.T) {
+	s := NewWeightedReservoirSampler[string](3, WithSeed(1))
+	if s.Offer("zero", 0) || s.Offer("negative", -1) || s.Offer("nan", math.NaN()) || s.Offer("inf", math.Inf(1)) {
+		t.Error("Expected invalid weights to be ignored")
+	}
+	s.Offer("a", 1)
+	s.Offer("b", 2)
+	if s.Len() != 2 || s.Seen() != 2 || s.K() != 3 {
+		t.Errorf("Expected Len 2, Seen 2, K 3, got %d, %d, %d", s.Len(), s.Seen(), s.K())
+	}
+
+	s.Reset()
+	if s.Len() != 0 || s.Seen() != 0 {
+		t.Errorf("Expected empty sampler after Reset, got Len %d, Seen %d", s.Len(), s.Seen())
+	}
+}
+
+func TestWeightedReservoirSamplerSingle(t *testing.T) {
+	// With k = 1, an item is picked with probability proportional to its weight
+	weights := []float64{1, 2, 3, 4}
+	counts := make([]int, len(weights))
+	const trials = 40000
+	s := NewWeightedReservoirSampler[int](1, WithSeed(1))
+	for trial := 0; trial < trials; trial++ {
+		s.Reset()
+		for i, w := range weights {
+			s.Offer(i, w)
+		}
+		counts[s.Sample()[0]]++
+	}
+
+	for i, w := range weights {
+		want := trials * w / 10
+		if math.Abs(float64(counts[i])-want) > 0.05*want {
+			t.Errorf("Item %d with weight %v picked %d times, want about %.0f", i, w, counts[i], want)
+		}
+	}
+}
+
+func TestWeightedReservoirSamplerEqualWeights(t *testing.T) {
+	// Equal weights reduce to uniform sampling
+	const n, k, trials = 10, 3, 20000
+	counts := make([]int, n)
+	s := NewWeightedReservoirSampler[int](k, WithSeed(2))
+	for trial := 0; trial < trials; trial++ {
+		s.Reset()
+		for i := 0; i < n; i++ {
+			s.Offer(i, 5)
+		}
+		for _, item := range s.Sample() {
+			counts[item]++
+		}
+	}
+
+	want := float64(trials) * k / n
+	for item, c := range counts {
+		if math.Abs(float64(c)-want) > 0.05*want {
+			t.Errorf("Item %d sampled %d times, want about %.0f", item, c, want)
+		}
+	}
+}
+
+func TestWeightedReservoirSamplerHeavyItems(t *testing.T) {
+	s := NewWeightedReservoirSampler[int](5, WithSeed(3))
+	for i := 0; i < 10000; i++ {
+		w := 1e-6
+		if i%2000 == 0 {
+			w = 1e6
+		}
+		s.Offer(i, w)
+	}
+
+	got := make(map[int]bool)
+	for _, item := range s.Sample() {
+		got[item] = true
+	}
+	want := map[int]bool{0: true, 2000: true, 4000: true, 6000: true, 8000: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the heavy items to be sampled, got %v", s.Sample())
+	}
+}
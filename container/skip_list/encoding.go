@@ -0,0 +1,114 @@
+// Package skip_list provides a Skip List data structure implementation.
+// This file implements binary and JSON serialization for SkipList.
+
+package skip_list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+// MarshalBinary encodes the pairs of the skip list in key order with
+// encoding/gob, so K and V must be types gob can encode.
+// It implements encoding.BinaryMarshaler.
+func (sl *SkipList[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sl.Pairs()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the skip list with the pairs
+// decoded from data produced by MarshalBinary.
+// It implements encoding.BinaryUnmarshaler.
+// On error the skip list is left unchanged.
+func (sl *SkipList[K, V]) UnmarshalBinary(data []byte) error {
+	var pairs []pair.Pair[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&pairs); err != nil {
+		return err
+	}
+	sl.load(pairs)
+	return nil
+}
+
+// MarshalJSON encodes the skip list as a JSON array of [key, value] arrays
+// in key order. An array is used rather than an object so that keys of any type
+// keep their JSON form and their order.
+// It implements json.Marshaler.
+func (sl *SkipList[K, V]) MarshalJSON() ([]byte, error) {
+	entries := make([]jsonEntry[K, V], 0, sl.length)
+	sl.Range(func(key K, value V) bool {
+		entries = append(entries, jsonEntry[K, V]{key, value})
+		return true
+	})
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the contents of the skip list with the pairs
+// decoded from a JSON array of [key, value] arrays. Pairs may appear in any order;
+// when a key repeats, the last value wins.
+// It implements json.Unmarshaler.
+// On error the skip list is left unchanged.
+func (sl *SkipList[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	pairs := make([]pair.Pair[K, V], len(entries))
+	for i, e := range entries {
+		pairs[i] = pair.Pair[K, V]{First: e.Key, Second: e.Value}
+	}
+	sl.load(pairs)
+	return nil
+}
+
+// load replaces the contents of the skip list with pairs. Pairs sorted by
+// strictly increasing key, as produced by the encoders, are appended in
+// O(n) without searching; others are inserted one by one.
+func (sl *SkipList[K, V]) load(pairs []pair.Pair[K, V]) {
+	sl.Clear()
+	for i := 1; i < len(pairs); i++ {
+		if sl.compareKeys(pairs[i-1].First, pairs[i].First) >= 0 {
+			for _, p := range pairs {
+				sl.Set(p.First, p.Second)
+			}
+			return
+		}
+	}
+	tails := sl.tails()
+	for _, p := range pairs {
+		sl.appendSorted(&tails, p.First, p.Second)
+	}
+	sl.rebuildSpans()
+}
+
+// jsonEntry is a key-value pair encoded as a two-element JSON array.
+type jsonEntry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e jsonEntry[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{e.Key, e.Value})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *jsonEntry[K, V]) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("skip_list: JSON entry has %d elements, want [key, value]", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], &e.Key); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw[1], &e.Value)
+}
@@ -0,0 +1,87 @@
+package skip_list
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSkipListBinaryRoundTrip(t *testing.T) {
+	sl := NewOrderedSkipList[string, []int]()
+	sl.Set("b", []int{2})
+	sl.Set("a", []int{1, 1})
+	sl.Set("c", nil)
+
+	data, err := sl.(*SkipList[string, []int]).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	decoded := NewOrderedSkipList[string, []int]()
+	decoded.Set("stale", nil)
+	if err := decoded.(*SkipList[string, []int]).UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Keys(), []string{"a", "b", "c"}) {
+		t.Errorf("Expected keys [a b c], got %v", decoded.Keys())
+	}
+	if v, _ := decoded.Get("a"); !reflect.DeepEqual(v, []int{1, 1}) {
+		t.Errorf("Expected [1 1], got %v", v)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if err := decoded.(*SkipList[string, []int]).UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+	if decoded.Len() != 3 {
+		t.Error("Expected failed decoding to leave the skip list unchanged")
+	}
+}
+
+func TestSkipListJSON(t *testing.T) {
+	sl := NewOrderedSkipList[int, string]()
+	sl.Set(10, "ten")
+	sl.Set(2, "two")
+
+	// The dynamic type implements json.Marshaler
+	data, err := json.Marshal(sl)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `[[2,"two"],[10,"ten"]]` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	decoded := NewOrderedSkipList[int, string]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Pairs(), sl.Pairs()) {
+		t.Errorf("Expected %v, got %v", sl.Pairs(), decoded.Pairs())
+	}
+
+	// Unsorted input and repeated keys are accepted
+	if err := json.Unmarshal([]byte(`[[3,"c"],[1,"a"],[3,"C"]]`), decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Keys(), []int{1, 3}) {
+		t.Errorf("Expected keys [1 3], got %v", decoded.Keys())
+	}
+	if v, _ := decoded.Get(3); v != "C" {
+		t.Errorf("Expected the last value to win, got %q", v)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	for _, bad := range []string{`{"1":"a"}`, `[[1]]`, `[[1,"a","junk"]]`, `[["x","a"]]`} {
+		if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+			t.Errorf("Expected error for %s", bad)
+		}
+	}
+	if decoded.Len() != 2 {
+		t.Error("Expected failed decoding to leave the skip list unchanged")
+	}
+}
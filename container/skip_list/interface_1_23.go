@@ -37,12 +37,63 @@ type Interface[K comparable, V any] interface {
 	// Returns true if the key was found and removed, false otherwise.
 	Delete(key K) bool
 
+	// DeleteBetween removes every key-value pair within the given key range
+	// [start, end] (both inclusive) in one pass and returns the number removed.
+	DeleteBetween(start, end K) int
+
 	// Has checks whether the given key exists in the skip list.
 	Has(key K) bool
 
+	// GetAt returns the key-value pair at index i (0-based) in sorted order by key
+	// in O(log n). Returns zero values and false if i is out of range.
+	GetAt(i int) (K, V, bool)
+
+	// IndexOf returns the index (0-based) of key in sorted order by key in O(log n),
+	// or -1 if the key does not exist.
+	IndexOf(key K) int
+
+	// DeleteAt removes the key-value pair at index i (0-based) in sorted order by key
+	// in O(log n) and returns it. Returns zero values and false if i is out of range.
+	DeleteAt(i int) (K, V, bool)
+
+	// Min returns the smallest key and its value.
+	// Returns zero values and false if the skip list is empty.
+	Min() (K, V, bool)
+
+	// Max returns the largest key and its value.
+	// Returns zero values and false if the skip list is empty.
+	Max() (K, V, bool)
+
+	// PopMin removes the smallest key and returns it with its value.
+	// Returns zero values and false if the skip list is empty.
+	PopMin() (K, V, bool)
+
+	// PopMax removes the largest key and returns it with its value.
+	// Returns zero values and false if the skip list is empty.
+	PopMax() (K, V, bool)
+
 	// Clear removes all key-value pairs from the skip list.
 	Clear()
 
+	// Clone returns an independent copy of the skip list in O(n).
+	// Values are copied shallowly.
+	Clone() Interface[K, V]
+
+	// CloneFunc returns an independent copy of the skip list in O(n),
+	// passing every value through cloneValue.
+	CloneFunc(cloneValue func(V) V) Interface[K, V]
+
+	// Merge copies every key-value pair of other into the skip list.
+	// When a key exists in both, resolve(key, current, incoming) decides the
+	// resulting value; a nil resolve keeps the incoming value.
+	// other is not modified.
+	Merge(other Interface[K, V], resolve func(key K, current, incoming V) V)
+
+	// Split partitions the skip list around key in O(n), returning a skip list
+	// with the keys less than key and one with the keys greater than or equal
+	// to key. The receiver is not modified.
+	Split(key K) (Interface[K, V], Interface[K, V])
+
 	// Keys returns a slice of all keys in the skip list in sorted order.
 	Keys() []K
 
@@ -66,6 +117,16 @@ type Interface[K comparable, V any] interface {
 	// If the function returns false, the iteration stops.
 	RangeBetween(start, end K, fn func(key K, value V) bool)
 
+	// RangeDescending calls the provided function for each key-value pair in the
+	// skip list in descending order by key. If the function returns false, the
+	// iteration stops.
+	RangeDescending(fn func(key K, value V) bool)
+
+	// RangeBetweenDesc calls the provided function for each key-value pair in the
+	// skip list within the given key range [start, end] (both inclusive) in
+	// descending order by key. If the function returns false, the iteration stops.
+	RangeBetweenDesc(start, end K, fn func(key K, value V) bool)
+
 	// All returns an iterator over all key-value pairs in sorted order by key.
 	All() iter.Seq2[K, V]
 
@@ -76,4 +137,17 @@ type Interface[K comparable, V any] interface {
 	// AllBetween returns an iterator over key-value pairs within the given key range
 	// [start, end] (both inclusive) in sorted order by key.
 	AllBetween(start, end K) iter.Seq2[K, V]
+
+	// AllDescending returns an iterator over all key-value pairs in descending
+	// order by key.
+	AllDescending() iter.Seq2[K, V]
+
+	// AllBetweenDesc returns an iterator over key-value pairs within the given key
+	// range [start, end] (both inclusive) in descending order by key.
+	AllBetweenDesc(start, end K) iter.Seq2[K, V]
+
+	// Validate checks the internal invariants of the skip list and returns a
+	// descriptive error for the first violation found, or nil. It is intended
+	// for tests and fuzzing after sequences of random operations.
+	Validate() error
 }
@@ -0,0 +1,121 @@
+//go:build !go1.23
+// +build !go1.23
+
+package skip_list
+
+import (
+	"cmp"
+)
+
+// MultiSkipList is a skip list that allows duplicate keys. Values sharing a
+// key are kept in insertion order, which suits time series whose points can
+// share a timestamp. It stores the values of each key in one skip list entry.
+type MultiSkipList[K cmp.Ordered, V any] struct {
+	list Interface[K, []V]
+	size int // total number of values
+}
+
+// NewMultiSkipList creates and returns a new empty multi-value skip list.
+func NewMultiSkipList[K cmp.Ordered, V any](opts ...Option) *MultiSkipList[K, V] {
+	return &MultiSkipList[K, V]{list: NewSkipList[K, []V](opts...)}
+}
+
+// Len returns the number of values stored, counting duplicate keys.
+func (m *MultiSkipList[K, V]) Len() int {
+	return m.size
+}
+
+// KeyCount returns the number of distinct keys.
+func (m *MultiSkipList[K, V]) KeyCount() int {
+	return m.list.Len()
+}
+
+// Add inserts value under key after any values already stored for it.
+func (m *MultiSkipList[K, V]) Add(key K, value V) {
+	if values, ok := m.list.GetMutable(key); ok {
+		*values = append(*values, value)
+	} else {
+		m.list.Set(key, []V{value})
+	}
+	m.size++
+}
+
+// GetAll returns a copy of the values stored under key in insertion order,
+// or nil if the key does not exist.
+func (m *MultiSkipList[K, V]) GetAll(key K) []V {
+	values, _ := m.list.Get(key)
+	return append([]V(nil), values...)
+}
+
+// Count returns the number of values stored under key.
+func (m *MultiSkipList[K, V]) Count(key K) int {
+	values, _ := m.list.Get(key)
+	return len(values)
+}
+
+// Has checks whether any value is stored under key.
+func (m *MultiSkipList[K, V]) Has(key K) bool {
+	return m.list.Has(key)
+}
+
+// DeleteOne removes the earliest inserted value stored under key and returns
+// it. Returns the zero value and false if the key does not exist.
+func (m *MultiSkipList[K, V]) DeleteOne(key K) (V, bool) {
+	values, ok := m.list.GetMutable(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	value := (*values)[0]
+	if len(*values) == 1 {
+		m.list.Delete(key)
+	} else {
+		var zero V
+		(*values)[0] = zero // release the reference held by the slice
+		*values = (*values)[1:]
+	}
+	m.size--
+	return value, true
+}
+
+// DeleteAll removes every value stored under key and returns how many were removed.
+func (m *MultiSkipList[K, V]) DeleteAll(key K) int {
+	values, ok := m.list.Get(key)
+	if !ok {
+		return 0
+	}
+	m.list.Delete(key)
+	m.size -= len(values)
+	return len(values)
+}
+
+// Clear removes all values.
+func (m *MultiSkipList[K, V]) Clear() {
+	m.list.Clear()
+	m.size = 0
+}
+
+// Range calls the provided function for each key-value pair in sorted order
+// by key, and in insertion order for equal keys.
+// If the function returns false, the iteration stops.
+func (m *MultiSkipList[K, V]) Range(fn func(key K, value V) bool) {
+	m.list.Range(rangeValues(fn))
+}
+
+// RangeBetween calls the provided function for each key-value pair within
+// the given key range [start, end] (both inclusive), ordered like Range.
+func (m *MultiSkipList[K, V]) RangeBetween(start, end K, fn func(key K, value V) bool) {
+	m.list.RangeBetween(start, end, rangeValues(fn))
+}
+
+// rangeValues adapts fn to visit every value of a skip list entry.
+func rangeValues[K any, V any](fn func(key K, value V) bool) func(key K, values []V) bool {
+	return func(key K, values []V) bool {
+		for _, v := range values {
+			if !fn(key, v) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,75 @@
+package skip_list
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+func TestMultiSkipList(t *testing.T) {
+	m := NewOrderedMultiSkipList[int, string]()
+	if m.Len() != 0 || m.Has(1) || m.GetAll(1) != nil {
+		t.Error("Expected empty multi skip list")
+	}
+
+	m.Add(20, "b1")
+	m.Add(10, "a")
+	m.Add(20, "b2")
+	m.Add(30, "c")
+	m.Add(20, "b3")
+
+	if m.Len() != 5 || m.KeyCount() != 3 || m.Count(20) != 3 {
+		t.Errorf("Expected 5 values under 3 keys, got %d under %d", m.Len(), m.KeyCount())
+	}
+	if got := m.GetAll(20); !reflect.DeepEqual(got, []string{"b1", "b2", "b3"}) {
+		t.Errorf("Expected values in insertion order, got %v", got)
+	}
+
+	// GetAll returns a copy
+	m.GetAll(20)[0] = "changed"
+	if got := m.GetAll(20); got[0] != "b1" {
+		t.Errorf("Expected GetAll to return a copy, got %v", got)
+	}
+
+	var pairs []pair.Pair[int, string]
+	m.Range(func(k int, v string) bool {
+		pairs = append(pairs, pair.Pair[int, string]{First: k, Second: v})
+		return true
+	})
+	want := []pair.Pair[int, string]{{First: 10, Second: "a"}, {First: 20, Second: "b1"}, {First: 20, Second: "b2"}, {First: 20, Second: "b3"}, {First: 30, Second: "c"}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("Expected %v, got %v", want, pairs)
+	}
+
+	// Early termination inside a group of equal keys
+	var values []string
+	m.RangeBetween(15, 30, func(_ int, v string) bool {
+		values = append(values, v)
+		return len(values) < 2
+	})
+	if !reflect.DeepEqual(values, []string{"b1", "b2"}) {
+		t.Errorf("Expected [b1 b2], got %v", values)
+	}
+
+	if v, ok := m.DeleteOne(20); !ok || v != "b1" {
+		t.Errorf("DeleteOne(20) = %q, %v, want \"b1\"", v, ok)
+	}
+	if _, ok := m.DeleteOne(99); ok {
+		t.Error("Expected DeleteOne to fail for a missing key")
+	}
+	if v, _ := m.DeleteOne(10); v != "a" || m.Has(10) {
+		t.Error("Expected the key to be removed with its last value")
+	}
+	if n := m.DeleteAll(20); n != 2 || m.Has(20) || m.Len() != 1 {
+		t.Errorf("Expected 2 values removed, got %d", n)
+	}
+	if m.DeleteAll(20) != 0 {
+		t.Error("Expected DeleteAll to return 0 for a missing key")
+	}
+
+	m.Clear()
+	if m.Len() != 0 || m.KeyCount() != 0 {
+		t.Error("Expected empty multi skip list after Clear")
+	}
+}
@@ -0,0 +1,70 @@
+// Package skip_list provides a Skip List data structure implementation.
+// This file implements the options accepted by the skip list constructors.
+
+package skip_list
+
+import (
+	"math/rand"
+)
+
+// Option configures a skip list created by NewSkipList.
+type Option func(*options)
+
+type options struct {
+	rng         *rand.Rand // source of node levels, nil for the shared global source
+	probability float64    // probability of a node reaching the next level
+	maxLevel    int        // maximum number of levels
+}
+
+// newOptions returns the defaults updated by opts.
+func newOptions(opts []Option) options {
+	o := options{probability: probability, maxLevel: maxLevel}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// random returns a pseudo-random number in [0, 1).
+func (o *options) random() float64 {
+	if o.rng == nil {
+		return rand.Float64()
+	}
+	return o.rng.Float64()
+}
+
+// WithRandSource makes the skip list draw node levels from src instead of
+// the shared global source, so level assignment, and with it the shape of
+// the list, is reproducible. Lists created by Clone, CloneFunc and Split
+// share the source; like the list itself, a rand.Source is not safe for
+// concurrent use.
+func WithRandSource(src rand.Source) Option {
+	return func(o *options) {
+		o.rng = rand.New(src)
+	}
+}
+
+// WithSeed is shorthand for WithRandSource(rand.NewSource(seed)).
+func WithSeed(seed int64) Option {
+	return WithRandSource(rand.NewSource(seed))
+}
+
+// WithProbability sets the probability that a node reaching one level also
+// reaches the next, 0.5 by default. Lower values use less memory per node
+// at the cost of longer searches. Values outside (0, 1) are ignored.
+func WithProbability(p float64) Option {
+	return func(o *options) {
+		if p > 0 && p < 1 {
+			o.probability = p
+		}
+	}
+}
+
+// WithMaxLevel caps the number of levels, 32 by default. A list with n
+// levels stays efficient up to about (1/p)^n elements, where p is the
+// probability set by WithProbability. n is clamped to [1, 32].
+func WithMaxLevel(n int) Option {
+	return func(o *options) {
+		o.maxLevel = min(max(n, 1), maxLevel)
+	}
+}
@@ -5,9 +5,8 @@ package skip_list
 
 import (
 	"cmp"
+	"fmt"
 	"iter"
-	"math/rand"
-	"time"
 
 	"github.com/feepwang/br/container/pair"
 )
@@ -15,78 +14,131 @@ import (
 const (
 	// maxLevel defines the maximum number of levels in the skip list.
 	// This limits the height to prevent excessive memory usage.
+	// WithMaxLevel can lower it per list.
 	maxLevel = 32
 
 	// probability defines the probability of a node having a pointer at the next level.
 	// Traditional skip lists use p = 0.5, which provides good balance between
-	// search time and space usage.
+	// search time and space usage. WithProbability overrides it per list.
 	probability = 0.5
 )
 
 // node represents a single node in the skip list.
 type node[K comparable, V any] struct {
-	key     K
-	value   V
-	forward []*node[K, V] // Array of forward pointers for each level
+	key      K
+	value    V
+	forward  []*node[K, V] // Array of forward pointers for each level
+	span     []int         // span[i] is the number of bottom-level steps forward[i] skips
+	backward *node[K, V]   // Previous node on the bottom level, nil for the first node
+}
+
+// newNode creates a node with forward pointers and spans for levels 0 through level.
+func newNode[K comparable, V any](key K, value V, level int) *node[K, V] {
+	return &node[K, V]{
+		key:     key,
+		value:   value,
+		forward: make([]*node[K, V], level+1),
+		span:    make([]int, level+1),
+	}
 }
 
 // SkipList is a concrete implementation of the Interface.
 type SkipList[K comparable, V any] struct {
 	header  *node[K, V]      // Header node (sentinel)
+	tail    *node[K, V]      // Last node, nil if the list is empty
 	level   int              // Current maximum level of the list
 	length  int              // Number of elements in the list
-	rng     *rand.Rand       // Random number generator for level assignment
+	opts    options          // Level assignment configuration
 	compare func(a, b K) int // Comparison function for keys
 }
 
 // NewSkipList creates and returns a new empty skip list.
-func NewSkipList[K comparable, V any](compare func(a, b K) int) Interface[K, V] {
-	header := &node[K, V]{
-		forward: make([]*node[K, V], maxLevel),
-	}
+func NewSkipList[K comparable, V any](compare func(a, b K) int, opts ...Option) Interface[K, V] {
+	var zeroK K
+	var zeroV V
+	header := newNode(zeroK, zeroV, maxLevel-1)
 
 	return &SkipList[K, V]{
 		header:  header,
 		level:   0,
 		length:  0,
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		opts:    newOptions(opts),
 		compare: compare,
 	}
 }
 
 // NewOrderedSkipList creates a new skip list for ordered types (types that implement cmp.Ordered).
-func NewOrderedSkipList[K cmp.Ordered, V any]() Interface[K, V] {
-	return NewSkipList[K, V](cmp.Compare[K])
+func NewOrderedSkipList[K cmp.Ordered, V any](opts ...Option) Interface[K, V] {
+	return NewSkipList[K, V](cmp.Compare[K], opts...)
+}
+
+// NewSkipListFromSeq creates a skip list ordered by compare of the
+// key-value pairs of seq. When a key repeats, the last value wins.
+func NewSkipListFromSeq[K comparable, V any](seq iter.Seq2[K, V], compare func(a, b K) int, opts ...Option) Interface[K, V] {
+	sl := NewSkipList[K, V](compare, opts...)
+	for k, v := range seq {
+		sl.Set(k, v)
+	}
+	return sl
+}
+
+// NewOrderedSkipListFromSeq creates a skip list of the key-value pairs of
+// seq for ordered key types. When a key repeats, the last value wins.
+func NewOrderedSkipListFromSeq[K cmp.Ordered, V any](seq iter.Seq2[K, V], opts ...Option) Interface[K, V] {
+	return NewSkipListFromSeq(seq, cmp.Compare[K], opts...)
+}
+
+// compareKeys compares two keys in the order of the list.
+func (sl *SkipList[K, V]) compareKeys(a, b K) int {
+	return sl.compare(a, b)
 }
 
 // randomLevel generates a random level for a new node.
-// Uses geometric distribution with the specified probability.
+// Uses geometric distribution with the configured probability.
 func (sl *SkipList[K, V]) randomLevel() int {
 	level := 0
-	for sl.rng.Float64() < probability && level < maxLevel-1 {
+	for level < sl.opts.maxLevel-1 && sl.opts.random() < sl.opts.probability {
 		level++
 	}
 	return level
 }
 
+// find returns the first node with a key not less than key, or nil.
+// It is the allocation-free lookup used by read-only operations.
+func (sl *SkipList[K, V]) find(key K) *node[K, V] {
+	current := sl.header
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+			current = current.forward[i]
+		}
+	}
+	return current.forward[0]
+}
+
 // search finds the position where a key should be inserted or already exists.
-// Returns the update array needed for insertion/deletion operations.
-func (sl *SkipList[K, V]) search(key K) ([]*node[K, V], *node[K, V]) {
-	update := make([]*node[K, V], maxLevel)
+// It fills update with the predecessor at each level, needed for
+// insertion/deletion operations, and rank with the rank of each predecessor
+// (its 1-based position, 0 for the header). Returns the first node with a
+// key not less than key. The arrays are supplied by the caller so they can
+// live on its stack.
+func (sl *SkipList[K, V]) search(key K, update *[maxLevel]*node[K, V], rank *[maxLevel]int) *node[K, V] {
 	current := sl.header
+	traversed := 0
 
 	// Start from the highest level and work downward
 	for i := sl.level; i >= 0; i-- {
 		// Move forward while the next node's key is less than the search key
 		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+			traversed += current.span[i]
 			current = current.forward[i]
 		}
 		update[i] = current
+		rank[i] = traversed
 	}
 
 	// Move to the next node (potential match)
 	current = current.forward[0]
-	return update, current
+	return current
 }
 
 // Len returns the number of key-value pairs stored in the skip list.
@@ -96,7 +148,7 @@ func (sl *SkipList[K, V]) Len() int {
 
 // Get retrieves the value associated with the given key.
 func (sl *SkipList[K, V]) Get(key K) (V, bool) {
-	_, current := sl.search(key)
+	current := sl.find(key)
 	if current != nil && sl.compare(current.key, key) == 0 {
 		return current.value, true
 	}
@@ -106,7 +158,7 @@ func (sl *SkipList[K, V]) Get(key K) (V, bool) {
 
 // GetMutable returns a pointer to the value associated with the given key.
 func (sl *SkipList[K, V]) GetMutable(key K) (*V, bool) {
-	_, current := sl.search(key)
+	current := sl.find(key)
 	if current != nil && sl.compare(current.key, key) == 0 {
 		return &current.value, true
 	}
@@ -115,7 +167,9 @@ func (sl *SkipList[K, V]) GetMutable(key K) (*V, bool) {
 
 // Set inserts or updates a key-value pair in the skip list.
 func (sl *SkipList[K, V]) Set(key K, value V) {
-	update, current := sl.search(key)
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int
+	current := sl.search(key, &update, &rank)
 
 	// If key already exists, update the value
 	if current != nil && sl.compare(current.key, key) == 0 {
@@ -130,21 +184,27 @@ func (sl *SkipList[K, V]) Set(key K, value V) {
 	if newLevel > sl.level {
 		for i := sl.level + 1; i <= newLevel; i++ {
 			update[i] = sl.header
+			rank[i] = 0
+			sl.header.span[i] = sl.length
 		}
 		sl.level = newLevel
 	}
 
 	// Create new node
-	newNode := &node[K, V]{
-		key:     key,
-		value:   value,
-		forward: make([]*node[K, V], newLevel+1),
-	}
+	n := newNode(key, value, newLevel)
 
-	// Update forward pointers
+	// Update forward pointers, splitting the spans of the predecessors
 	for i := 0; i <= newLevel; i++ {
-		newNode.forward[i] = update[i].forward[i]
-		update[i].forward[i] = newNode
+		n.forward[i] = update[i].forward[i]
+		update[i].forward[i] = n
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	sl.linkBackward(update[0], n)
+
+	// Links above the new node now skip one more node
+	for i := newLevel + 1; i <= sl.level; i++ {
+		update[i].span[i]++
 	}
 
 	sl.length++
@@ -152,19 +212,35 @@ func (sl *SkipList[K, V]) Set(key K, value V) {
 
 // Delete removes the key-value pair with the given key from the skip list.
 func (sl *SkipList[K, V]) Delete(key K) bool {
-	update, current := sl.search(key)
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int
+	current := sl.search(key, &update, &rank)
 
 	// If key doesn't exist, return false
 	if current == nil || sl.compare(current.key, key) != 0 {
 		return false
 	}
 
-	// Update forward pointers to skip the node being deleted
+	sl.unlink(&update, current)
+	return true
+}
+
+// unlink removes node x, whose predecessor at each level is update[i].
+func (sl *SkipList[K, V]) unlink(update *[maxLevel]*node[K, V], x *node[K, V]) {
+	// Update forward pointers to skip the node being deleted, merging spans
 	for i := 0; i <= sl.level; i++ {
-		if update[i].forward[i] != current {
-			break
+		if update[i].forward[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].forward[i] = x.forward[i]
+		} else {
+			update[i].span[i]--
 		}
-		update[i].forward[i] = current.forward[i]
+	}
+
+	if x.forward[0] != nil {
+		x.forward[0].backward = x.backward
+	} else {
+		sl.tail = x.backward
 	}
 
 	// Update the level of the skip list if necessary
@@ -173,7 +249,170 @@ func (sl *SkipList[K, V]) Delete(key K) bool {
 	}
 
 	sl.length--
-	return true
+}
+
+// linkBackward sets the backward pointers around n, which was just linked
+// after prev on the bottom level.
+func (sl *SkipList[K, V]) linkBackward(prev, n *node[K, V]) {
+	if prev != sl.header {
+		n.backward = prev
+	}
+	if n.forward[0] != nil {
+		n.forward[0].backward = n
+	} else {
+		sl.tail = n
+	}
+}
+
+// DeleteBetween removes every key-value pair within the given range and
+// returns the number of pairs removed. The range is unlinked in one pass, in
+// O(log n + k) for k removed pairs, instead of one search per key. Reversed
+// bounds are swapped.
+func (sl *SkipList[K, V]) DeleteBetween(start, end K) int {
+	// Determine the logical start and end based on comparator
+	actualStart, actualEnd := start, end
+	if sl.compare(start, end) > 0 {
+		actualStart, actualEnd = end, start
+	}
+
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int
+	first := sl.search(actualStart, &update, &rank)
+
+	removed := 0
+	for x := first; x != nil && sl.compare(x.key, actualEnd) <= 0; x = x.forward[0] {
+		removed++
+	}
+	if removed == 0 {
+		return 0
+	}
+
+	// At each level, link the predecessor to the first node past the range,
+	// merging the spans of the skipped links
+	for i := 0; i <= sl.level; i++ {
+		span := update[i].span[i]
+		next := update[i].forward[i]
+		for next != nil && sl.compare(next.key, actualEnd) <= 0 {
+			span += next.span[i]
+			next = next.forward[i]
+		}
+		update[i].forward[i] = next
+		update[i].span[i] = span - removed
+	}
+
+	var prev *node[K, V]
+	if update[0] != sl.header {
+		prev = update[0]
+	}
+	if next := update[0].forward[0]; next != nil {
+		next.backward = prev
+	} else {
+		sl.tail = prev
+	}
+
+	// Update the level of the skip list if necessary
+	for sl.level > 0 && sl.header.forward[sl.level] == nil {
+		sl.level--
+	}
+
+	sl.length -= removed
+	return removed
+}
+
+// GetAt returns the key and value at index i (0-based) in key order in
+// O(log n). Returns zero values and false if i is out of range.
+func (sl *SkipList[K, V]) GetAt(i int) (K, V, bool) {
+	if i < 0 || i >= sl.length {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	var update [maxLevel]*node[K, V]
+	x := sl.seek(i, &update)
+	return x.key, x.value, true
+}
+
+// IndexOf returns the index (0-based) of key in key order in O(log n),
+// or -1 if the key does not exist.
+func (sl *SkipList[K, V]) IndexOf(key K) int {
+	current := sl.header
+	traversed := 0
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+	}
+	current = current.forward[0]
+	if current != nil && sl.compare(current.key, key) == 0 {
+		return traversed
+	}
+	return -1
+}
+
+// DeleteAt removes the pair at index i (0-based) in key order in O(log n)
+// and returns it. Returns zero values and false if i is out of range.
+func (sl *SkipList[K, V]) DeleteAt(i int) (K, V, bool) {
+	if i < 0 || i >= sl.length {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	var update [maxLevel]*node[K, V]
+	x := sl.seek(i, &update)
+	sl.unlink(&update, x)
+	return x.key, x.value, true
+}
+
+// Min returns the smallest key and its value in O(1).
+// Returns zero values and false if the skip list is empty.
+func (sl *SkipList[K, V]) Min() (K, V, bool) {
+	first := sl.header.forward[0]
+	if first == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return first.key, first.value, true
+}
+
+// Max returns the largest key and its value in O(1).
+// Returns zero values and false if the skip list is empty.
+func (sl *SkipList[K, V]) Max() (K, V, bool) {
+	if sl.tail == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return sl.tail.key, sl.tail.value, true
+}
+
+// PopMin removes the smallest key and returns it with its value.
+// Returns zero values and false if the skip list is empty.
+func (sl *SkipList[K, V]) PopMin() (K, V, bool) {
+	return sl.DeleteAt(0)
+}
+
+// PopMax removes the largest key and returns it with its value.
+// Returns zero values and false if the skip list is empty.
+func (sl *SkipList[K, V]) PopMax() (K, V, bool) {
+	return sl.DeleteAt(sl.length - 1)
+}
+
+// seek finds the node at index i, which must be in range, following spans.
+// It fills update with the predecessors of the node and returns the node.
+func (sl *SkipList[K, V]) seek(index int, update *[maxLevel]*node[K, V]) *node[K, V] {
+	current := sl.header
+	traversed := 0
+	for i := sl.level; i >= 0; i-- {
+		// Stop before the node at 1-based position index+1
+		for current.forward[i] != nil && traversed+current.span[i] <= index {
+			traversed += current.span[i]
+			current = current.forward[i]
+		}
+		update[i] = current
+	}
+	return current.forward[0]
 }
 
 // Has checks whether the given key exists in the skip list.
@@ -185,10 +424,134 @@ func (sl *SkipList[K, V]) Has(key K) bool {
 // Clear removes all key-value pairs from the skip list.
 func (sl *SkipList[K, V]) Clear() {
 	sl.header.forward = make([]*node[K, V], maxLevel)
+	sl.header.span = make([]int, maxLevel)
+	sl.tail = nil
 	sl.level = 0
 	sl.length = 0
 }
 
+// Clone returns an independent copy of the skip list in O(n).
+// Values are copied shallowly; use CloneFunc to deep-copy them.
+func (sl *SkipList[K, V]) Clone() Interface[K, V] {
+	return sl.CloneFunc(nil)
+}
+
+// CloneFunc returns an independent copy of the skip list in O(n), passing
+// every value through cloneValue. A nil cloneValue copies values shallowly.
+// Node levels are preserved, so the copy has the same search performance.
+func (sl *SkipList[K, V]) CloneFunc(cloneValue func(V) V) Interface[K, V] {
+	c := &SkipList[K, V]{
+		header:  newNode(sl.header.key, sl.header.value, maxLevel-1),
+		level:   sl.level,
+		length:  sl.length,
+		opts:    sl.opts,
+		compare: sl.compare,
+	}
+
+	copy(c.header.span, sl.header.span)
+
+	// last[i] is the most recently copied node that has a pointer at level i
+	last := c.tails()
+
+	for current := sl.header.forward[0]; current != nil; current = current.forward[0] {
+		n := newNode(current.key, current.value, len(current.forward)-1)
+		copy(n.span, current.span)
+		if cloneValue != nil {
+			n.value = cloneValue(current.value)
+		}
+		c.linkBackward(last[0], n)
+		for i := range n.forward {
+			last[i].forward[i] = n
+			last[i] = n
+		}
+	}
+	return c
+}
+
+// Merge copies every key-value pair of other into the skip list.
+func (sl *SkipList[K, V]) Merge(other Interface[K, V], resolve func(key K, current, incoming V) V) {
+	if other == nil {
+		return
+	}
+	other.Range(func(key K, incoming V) bool {
+		if resolve != nil {
+			if current, ok := sl.GetMutable(key); ok {
+				*current = resolve(key, *current, incoming)
+				return true
+			}
+		}
+		sl.Set(key, incoming)
+		return true
+	})
+}
+
+// Split partitions the skip list around key in O(n).
+func (sl *SkipList[K, V]) Split(key K) (Interface[K, V], Interface[K, V]) {
+	left := NewSkipList[K, V](sl.compare).(*SkipList[K, V])
+	right := NewSkipList[K, V](sl.compare).(*SkipList[K, V])
+	left.opts, right.opts = sl.opts, sl.opts
+	leftTail, rightTail := left.tails(), right.tails()
+
+	for current := sl.header.forward[0]; current != nil; current = current.forward[0] {
+		if sl.compare(current.key, key) < 0 {
+			left.appendSorted(&leftTail, current.key, current.value)
+		} else {
+			right.appendSorted(&rightTail, current.key, current.value)
+		}
+	}
+	left.rebuildSpans()
+	right.rebuildSpans()
+	return left, right
+}
+
+// tails returns the initial per-level tail array for appendSorted.
+func (sl *SkipList[K, V]) tails() [maxLevel]*node[K, V] {
+	var tails [maxLevel]*node[K, V]
+	for i := range tails {
+		tails[i] = sl.header
+	}
+	return tails
+}
+
+// appendSorted links a new node after every existing node without searching.
+// key must be greater than all keys in the list; tails[i] tracks the last
+// node with a pointer at level i. Spans are left stale; call rebuildSpans
+// once appending is done.
+func (sl *SkipList[K, V]) appendSorted(tails *[maxLevel]*node[K, V], key K, value V) {
+	level := sl.randomLevel()
+	if level > sl.level {
+		sl.level = level
+	}
+	n := newNode(key, value, level)
+	sl.linkBackward(tails[0], n)
+	for i := 0; i <= level; i++ {
+		tails[i].forward[i] = n
+		tails[i] = n
+	}
+	sl.length++
+}
+
+// rebuildSpans recomputes every span in one pass over the bottom level.
+func (sl *SkipList[K, V]) rebuildSpans() {
+	// prev[i] is the last node seen with a pointer at level i, at 1-based position pos[i]
+	var prev [maxLevel]*node[K, V]
+	var pos [maxLevel]int
+	for i := range prev {
+		prev[i] = sl.header
+	}
+	p := 0
+	for x := sl.header.forward[0]; x != nil; x = x.forward[0] {
+		p++
+		for i := range x.forward {
+			prev[i].span[i] = p - pos[i]
+			prev[i], pos[i] = x, p
+		}
+	}
+	for i := range prev {
+		prev[i].span[i] = sl.length - pos[i]
+	}
+}
+
 // Keys returns a slice of all keys in the skip list in sorted order.
 func (sl *SkipList[K, V]) Keys() []K {
 	keys := make([]K, 0, sl.length)
@@ -342,3 +705,135 @@ func (sl *SkipList[K, V]) AllBetween(start, end K) iter.Seq2[K, V] {
 		}
 	}
 }
+
+// RangeDescending calls the provided function for each key-value pair in
+// descending order by key.
+func (sl *SkipList[K, V]) RangeDescending(fn func(key K, value V) bool) {
+	for current := sl.tail; current != nil; current = current.backward {
+		if !fn(current.key, current.value) {
+			break
+		}
+	}
+}
+
+// RangeBetweenDesc calls the provided function for key-value pairs within
+// the given range in descending order by key. Reversed bounds are swapped.
+func (sl *SkipList[K, V]) RangeBetweenDesc(start, end K, fn func(key K, value V) bool) {
+	// Determine the logical start and end based on comparator
+	actualStart, actualEnd := start, end
+	if sl.compare(start, end) > 0 {
+		actualStart, actualEnd = end, start
+	}
+
+	// Walk down from the last node with key <= actualEnd
+	for current := sl.lastNotAfter(actualEnd); current != nil && sl.compare(current.key, actualStart) >= 0; current = current.backward {
+		if !fn(current.key, current.value) {
+			break
+		}
+	}
+}
+
+// lastNotAfter returns the last node with a key less than or equal to key,
+// or nil if there is none.
+func (sl *SkipList[K, V]) lastNotAfter(key K) *node[K, V] {
+	current := sl.header
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) <= 0 {
+			current = current.forward[i]
+		}
+	}
+	if current == sl.header {
+		return nil
+	}
+	return current
+}
+
+// AllDescending returns an iterator over all key-value pairs in descending order by key.
+func (sl *SkipList[K, V]) AllDescending() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.RangeDescending(yield)
+	}
+}
+
+// AllBetweenDesc returns an iterator over key-value pairs within the given
+// range in descending order by key.
+func (sl *SkipList[K, V]) AllBetweenDesc(start, end K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.RangeBetweenDesc(start, end, yield)
+	}
+}
+
+// Validate checks the structural invariants of the skip list: keys strictly
+// increase along the bottom level, every node appears on exactly the levels
+// below its height, no node is taller than the list level, and the element
+// count matches. Returns a descriptive error for the first violation found.
+func (sl *SkipList[K, V]) Validate() error {
+	if sl.level < 0 || sl.level >= maxLevel {
+		return fmt.Errorf("skip_list: level %d out of range [0, %d)", sl.level, maxLevel)
+	}
+	if len(sl.header.forward) != maxLevel {
+		return fmt.Errorf("skip_list: header has %d levels, want %d", len(sl.header.forward), maxLevel)
+	}
+	for i := sl.level + 1; i < maxLevel; i++ {
+		if sl.header.forward[i] != nil {
+			return fmt.Errorf("skip_list: header links level %d above list level %d", i, sl.level)
+		}
+	}
+	if sl.level > 0 && sl.header.forward[sl.level] == nil {
+		return fmt.Errorf("skip_list: list level %d is empty", sl.level)
+	}
+
+	// prev[i] is the last node seen that has a pointer at level i. Walking
+	// the bottom level, each node must be the successor of prev[i] on every
+	// level below its height, which makes each level a sorted subsequence
+	// of the level beneath it.
+	prev := make([]*node[K, V], sl.level+1)
+	pos := make([]int, sl.level+1)
+	for i := range prev {
+		prev[i] = sl.header
+	}
+	count := 0
+	for x := sl.header.forward[0]; x != nil; x = x.forward[0] {
+		height := len(x.forward)
+		if height == 0 || height > sl.level+1 {
+			return fmt.Errorf("skip_list: node %v has height %d, list level is %d", x.key, height, sl.level)
+		}
+		if height > sl.opts.maxLevel {
+			return fmt.Errorf("skip_list: node %v has height %d, max level is %d", x.key, height, sl.opts.maxLevel)
+		}
+		if prev0 := prev[0]; prev0 != sl.header && sl.compare(prev0.key, x.key) >= 0 {
+			return fmt.Errorf("skip_list: keys out of order at %v after %v", x.key, prev0.key)
+		}
+		if want := prev[0]; x.backward != want && !(want == sl.header && x.backward == nil) {
+			return fmt.Errorf("skip_list: node %v has a wrong backward pointer", x.key)
+		}
+		if len(x.span) != height {
+			return fmt.Errorf("skip_list: node %v has %d spans for height %d", x.key, len(x.span), height)
+		}
+		count++
+		for i := 0; i < height; i++ {
+			if prev[i].forward[i] != x {
+				return fmt.Errorf("skip_list: level %d does not link to node %v", i, x.key)
+			}
+			if prev[i].span[i] != count-pos[i] {
+				return fmt.Errorf("skip_list: level %d link to node %v spans %d, want %d", i, x.key, prev[i].span[i], count-pos[i])
+			}
+			prev[i], pos[i] = x, count
+		}
+	}
+	for i, p := range prev {
+		if p.forward[i] != nil {
+			return fmt.Errorf("skip_list: level %d links past the last node", i)
+		}
+		if p.span[i] != count-pos[i] {
+			return fmt.Errorf("skip_list: level %d tail spans %d, want %d", i, p.span[i], count-pos[i])
+		}
+	}
+	if last := prev[0]; sl.tail != last && !(last == sl.header && sl.tail == nil) {
+		return fmt.Errorf("skip_list: tail does not point to the last node")
+	}
+	if count != sl.length {
+		return fmt.Errorf("skip_list: length is %d, counted %d nodes", sl.length, count)
+	}
+	return nil
+}
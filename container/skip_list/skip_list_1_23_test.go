@@ -5,6 +5,7 @@ package skip_list
 
 import (
 	"cmp"
+	"maps"
 	"reflect"
 	"testing"
 
@@ -395,3 +396,76 @@ func TestSkipList123ComplexIteratorScenario(t *testing.T) {
 		t.Errorf("Expected even/odd pattern %v, got %v", expectedPattern, evenOddPattern)
 	}
 }
+
+func TestSkipList123AllDescending(t *testing.T) {
+	sl := NewOrderedSkipList[int, string]()
+	for _, k := range []int{1, 3, 5, 7} {
+		sl.Set(k, "v")
+	}
+
+	var got []int
+	for k := range sl.AllDescending() {
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{7, 5, 3, 1}) {
+		t.Errorf("Expected [7 5 3 1], got %v", got)
+	}
+
+	// Reversed bounds are accepted like AllBetween
+	for _, bounds := range [][2]int{{2, 6}, {6, 2}} {
+		got = nil
+		for k := range sl.AllBetweenDesc(bounds[0], bounds[1]) {
+			got = append(got, k)
+		}
+		if !reflect.DeepEqual(got, []int{5, 3}) {
+			t.Errorf("AllBetweenDesc(%d, %d) = %v, want [5 3]", bounds[0], bounds[1], got)
+		}
+	}
+}
+
+func TestMultiSkipList123All(t *testing.T) {
+	m := NewMultiSkipList[string, int](func(a, b string) int { return cmp.Compare(b, a) })
+	m.Add("a", 1)
+	m.Add("b", 2)
+	m.Add("a", 3)
+
+	var got []int
+	for _, v := range m.All() {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{2, 1, 3}) {
+		t.Errorf("Expected [2 1 3], got %v", got)
+	}
+
+	got = nil
+	for _, v := range m.AllBetween("a", "a") {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", got)
+	}
+}
+
+func TestSkipList123DeleteBetweenReversedBounds(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	for i := 1; i <= 5; i++ {
+		sl.Set(i, i)
+	}
+	// Reversed bounds are accepted like AllBetween
+	if n := sl.DeleteBetween(4, 2); n != 3 || !reflect.DeepEqual(sl.Keys(), []int{1, 5}) {
+		t.Errorf("Expected [1 5] after removing 3 pairs, got %v after %d", sl.Keys(), n)
+	}
+}
+
+func TestNewSkipListFromSeq(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b"}
+	sl := NewOrderedSkipListFromSeq(maps.All(m), WithSeed(1))
+	if !reflect.DeepEqual(sl.Keys(), []int{1, 2, 3}) {
+		t.Errorf("Expected keys [1 2 3], got %v", sl.Keys())
+	}
+
+	desc := NewSkipListFromSeq(maps.All(m), func(a, b int) int { return cmp.Compare(b, a) })
+	if !reflect.DeepEqual(desc.Keys(), []int{3, 2, 1}) {
+		t.Errorf("Expected keys [3 2 1], got %v", desc.Keys())
+	}
+}
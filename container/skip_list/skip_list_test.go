@@ -1,6 +1,7 @@
 package skip_list
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -194,3 +195,546 @@ func TestSkipListStringKeys(t *testing.T) {
 		t.Errorf("Expected values %v, got %v", expectedValues, values)
 	}
 }
+
+func TestSkipListClone(t *testing.T) {
+	sl := NewOrderedSkipList[int, []string]()
+	for i := 0; i < 100; i++ {
+		sl.Set(i, []string{"v"})
+	}
+
+	clone := sl.Clone()
+	deep := sl.CloneFunc(func(v []string) []string {
+		return append([]string(nil), v...)
+	})
+
+	clone.Delete(10)
+	clone.Set(1000, nil)
+	if sl.Len() != 100 || !sl.Has(10) || sl.Has(1000) {
+		t.Error("Expected original skip list to be unaffected by clone mutations")
+	}
+	if clone.Len() != 100 {
+		t.Errorf("Expected clone length 100, got %d", clone.Len())
+	}
+	if !reflect.DeepEqual(deep.Keys(), sl.Keys()) {
+		t.Error("Expected deep clone to have the same keys")
+	}
+
+	// Every clone level stays searchable
+	for i := 0; i < 100; i++ {
+		if !deep.Has(i) {
+			t.Fatalf("Expected deep clone to contain %d", i)
+		}
+	}
+
+	v, _ := sl.GetMutable(5)
+	(*v)[0] = "changed"
+	if got, _ := clone.Get(5); got[0] != "changed" {
+		t.Errorf("Expected shallow clone to share value, got %v", got)
+	}
+	if got, _ := deep.Get(5); got[0] != "v" {
+		t.Errorf("Expected deep clone to keep its own value, got %v", got)
+	}
+}
+
+func TestSkipListMergeAndSplit(t *testing.T) {
+	a := NewOrderedSkipList[int, int]()
+	b := NewOrderedSkipList[int, int]()
+	for i := 0; i < 10; i++ {
+		a.Set(i, 1)
+		b.Set(i+5, 10)
+	}
+
+	a.Merge(b, func(key, current, incoming int) int {
+		return current + incoming
+	})
+	if a.Len() != 15 {
+		t.Errorf("Expected merged length 15, got %d", a.Len())
+	}
+	if v, _ := a.Get(4); v != 1 {
+		t.Errorf("Expected value 1 for key 4, got %d", v)
+	}
+	if v, _ := a.Get(7); v != 11 {
+		t.Errorf("Expected resolved value 11 for key 7, got %d", v)
+	}
+	if v, _ := a.Get(14); v != 10 {
+		t.Errorf("Expected value 10 for key 14, got %d", v)
+	}
+
+	left, right := a.Split(6)
+	if !reflect.DeepEqual(left.Keys(), []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("Unexpected left keys %v", left.Keys())
+	}
+	if right.Len() != 9 || a.Len() != 15 {
+		t.Errorf("Unexpected lengths: right %d, original %d", right.Len(), a.Len())
+	}
+	for i := 6; i < 15; i++ {
+		if !right.Has(i) {
+			t.Errorf("Expected right to contain %d", i)
+		}
+	}
+
+	// Split lists accept further mutations
+	right.Set(100, 0)
+	right.Delete(6)
+	if k := right.Keys(); k[0] != 7 || k[len(k)-1] != 100 {
+		t.Errorf("Unexpected right keys after mutation %v", k)
+	}
+}
+
+func TestSkipListValidate(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 2000; i++ {
+		k := rng.Intn(200)
+		if rng.Intn(3) == 0 {
+			sl.Delete(k)
+		} else {
+			sl.Set(k, i)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Fatalf("Validate after %d operations: %v", i+1, err)
+		}
+	}
+
+	impl := sl.(*SkipList[int, int])
+
+	impl.length++
+	if err := sl.Validate(); err == nil {
+		t.Error("Expected error for wrong length")
+	}
+	impl.length--
+
+	// Swap two adjacent keys on the bottom level
+	first := impl.header.forward[0]
+	second := first.forward[0]
+	first.key, second.key = second.key, first.key
+	if err := sl.Validate(); err == nil {
+		t.Error("Expected error for out-of-order keys")
+	}
+	first.key, second.key = second.key, first.key
+
+	// Unlink a tall node from an upper level only
+	top := impl.header.forward[impl.level]
+	impl.header.forward[impl.level] = top.forward[impl.level]
+	if err := sl.Validate(); err == nil {
+		t.Error("Expected error for node missing from an upper level")
+	}
+	impl.header.forward[impl.level] = top
+
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Expected valid list after restoring, got %v", err)
+	}
+}
+
+func TestSkipListIndexed(t *testing.T) {
+	sl := NewOrderedSkipList[int, string]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		sl.Set(k, "v")
+	}
+
+	for i, want := range []int{10, 20, 30, 40, 50} {
+		if k, _, ok := sl.GetAt(i); !ok || k != want {
+			t.Errorf("GetAt(%d) = %d, %v, want %d", i, k, ok, want)
+		}
+		if got := sl.IndexOf(want); got != i {
+			t.Errorf("IndexOf(%d) = %d, want %d", want, got, i)
+		}
+	}
+	if _, _, ok := sl.GetAt(-1); ok {
+		t.Error("Expected GetAt(-1) to fail")
+	}
+	if _, _, ok := sl.GetAt(5); ok {
+		t.Error("Expected GetAt(5) to fail")
+	}
+	if got := sl.IndexOf(25); got != -1 {
+		t.Errorf("Expected -1 for a missing key, got %d", got)
+	}
+
+	if k, _, ok := sl.DeleteAt(1); !ok || k != 20 {
+		t.Errorf("DeleteAt(1) = %d, %v, want 20", k, ok)
+	}
+	if _, _, ok := sl.DeleteAt(4); ok {
+		t.Error("Expected DeleteAt(4) to fail")
+	}
+	if !reflect.DeepEqual(sl.Keys(), []int{10, 30, 40, 50}) || sl.IndexOf(50) != 3 {
+		t.Errorf("Unexpected keys after DeleteAt: %v", sl.Keys())
+	}
+	if err := sl.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSkipListIndexedRandom(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	rng := rand.New(rand.NewSource(5))
+	ref := map[int]bool{}
+	for i := 0; i < 3000; i++ {
+		k := rng.Intn(500)
+		switch rng.Intn(4) {
+		case 0:
+			sl.Delete(k)
+			delete(ref, k)
+		case 1:
+			if sl.Len() > 0 {
+				k, _, _ := sl.DeleteAt(rng.Intn(sl.Len()))
+				delete(ref, k)
+			}
+		default:
+			sl.Set(k, i)
+			ref[k] = true
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := sl.Keys()
+	if len(keys) != len(ref) {
+		t.Fatalf("Expected %d keys, got %d", len(ref), len(keys))
+	}
+	for i, k := range keys {
+		if got, _, _ := sl.GetAt(i); got != k {
+			t.Errorf("GetAt(%d) = %d, want %d", i, got, k)
+		}
+		if got := sl.IndexOf(k); got != i {
+			t.Errorf("IndexOf(%d) = %d, want %d", k, got, i)
+		}
+	}
+
+	// Clones and split halves keep their ranks
+	left, right := sl.Clone().Split(250)
+	for _, part := range []Interface[int, int]{left, right} {
+		if err := part.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		for i, k := range part.Keys() {
+			if part.IndexOf(k) != i {
+				t.Errorf("IndexOf(%d) = %d after Split, want %d", k, part.IndexOf(k), i)
+			}
+		}
+	}
+}
+
+func TestSkipListMinMax(t *testing.T) {
+	sl := NewOrderedSkipList[int, string]()
+	if _, _, ok := sl.Min(); ok {
+		t.Error("Expected Min to fail on an empty skip list")
+	}
+	if _, _, ok := sl.Max(); ok {
+		t.Error("Expected Max to fail on an empty skip list")
+	}
+	if _, _, ok := sl.PopMin(); ok {
+		t.Error("Expected PopMin to fail on an empty skip list")
+	}
+	if _, _, ok := sl.PopMax(); ok {
+		t.Error("Expected PopMax to fail on an empty skip list")
+	}
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		sl.Set(k, string(rune('a'+k)))
+	}
+	if k, v, ok := sl.Min(); !ok || k != 1 || v != "b" {
+		t.Errorf("Min() = %d, %q, %v, want 1, \"b\"", k, v, ok)
+	}
+	if k, v, ok := sl.Max(); !ok || k != 9 || v != "j" {
+		t.Errorf("Max() = %d, %q, %v, want 9, \"j\"", k, v, ok)
+	}
+
+	// Popping from both ends drains the list in order
+	var popped []int
+	for sl.Len() > 0 {
+		k, _, _ := sl.PopMin()
+		popped = append(popped, k)
+		if k, _, ok := sl.PopMax(); ok {
+			popped = append(popped, k)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(popped, []int{1, 9, 3, 7, 5}) {
+		t.Errorf("Expected [1 9 3 7 5], got %v", popped)
+	}
+}
+
+func TestSkipListDescending(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	var got []int
+	collect := func(k, _ int) bool {
+		got = append(got, k)
+		return true
+	}
+
+	sl.RangeDescending(collect)
+	if len(got) != 0 {
+		t.Errorf("Expected no keys, got %v", got)
+	}
+
+	for _, k := range []int{4, 2, 8, 6, 10} {
+		sl.Set(k, k)
+	}
+	sl.RangeDescending(collect)
+	if !reflect.DeepEqual(got, []int{10, 8, 6, 4, 2}) {
+		t.Errorf("Expected [10 8 6 4 2], got %v", got)
+	}
+
+	tests := []struct {
+		start, end int
+		want       []int
+	}{
+		{3, 8, []int{8, 6, 4}},
+		{2, 10, []int{10, 8, 6, 4, 2}},
+		{5, 5, nil},
+		{11, 20, nil},
+		{0, 1, nil},
+		{8, 3, []int{8, 6, 4}},
+	}
+	for _, tt := range tests {
+		got = nil
+		sl.RangeBetweenDesc(tt.start, tt.end, collect)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RangeBetweenDesc(%d, %d) = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	// Early termination: the latest two entries
+	got = nil
+	sl.RangeDescending(func(k, _ int) bool {
+		got = append(got, k)
+		return len(got) < 2
+	})
+	if !reflect.DeepEqual(got, []int{10, 8}) {
+		t.Errorf("Expected [10 8], got %v", got)
+	}
+
+	// Backward links survive deletions at both ends and in the middle
+	sl.Delete(10)
+	sl.Delete(2)
+	sl.Delete(6)
+	got = nil
+	sl.RangeDescending(collect)
+	if !reflect.DeepEqual(got, []int{8, 4}) {
+		t.Errorf("Expected [8 4], got %v", got)
+	}
+	if k, _, _ := sl.Max(); k != 8 {
+		t.Errorf("Expected Max 8, got %d", k)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// Clones and split halves link backward too
+	left, right := sl.Clone().Split(5)
+	for _, part := range []Interface[int, int]{left, right} {
+		if err := part.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+	sl.Clear()
+	if _, _, ok := sl.Max(); ok {
+		t.Error("Expected Max to fail after Clear")
+	}
+}
+
+// benchSize is the number of keys used by the benchmark workloads.
+const benchSize = 1 << 16
+
+func benchList() (Interface[int, int], []int) {
+	keys := rand.New(rand.NewSource(42)).Perm(benchSize)
+	sl := NewOrderedSkipList[int, int]()
+	for _, k := range keys {
+		sl.Set(k, k)
+	}
+	return sl, keys
+}
+
+func BenchmarkSkipListGet(b *testing.B) {
+	sl, keys := benchList()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sl.Get(keys[i&(benchSize-1)])
+	}
+}
+
+func BenchmarkSkipListSetExisting(b *testing.B) {
+	sl, keys := benchList()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sl.Set(keys[i&(benchSize-1)], i)
+	}
+}
+
+func BenchmarkSkipListChurn(b *testing.B) {
+	sl, keys := benchList()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(benchSize-1)]
+		sl.Delete(k)
+		sl.Set(k, i)
+	}
+}
+
+// heights returns the number of levels of every node in key order.
+func heights(sl Interface[int, int]) []int {
+	var h []int
+	for x := sl.(*SkipList[int, int]).header.forward[0]; x != nil; x = x.forward[0] {
+		h = append(h, len(x.forward))
+	}
+	return h
+}
+
+func TestSkipListOptions(t *testing.T) {
+	build := func(opts ...Option) Interface[int, int] {
+		sl := NewOrderedSkipList[int, int](opts...)
+		for i := 0; i < 1000; i++ {
+			sl.Set(i, i)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		return sl
+	}
+
+	// The same seed produces the same shape
+	a, b := build(WithSeed(7)), build(WithRandSource(rand.NewSource(7)))
+	if !reflect.DeepEqual(heights(a), heights(b)) {
+		t.Error("Expected equal seeds to assign equal levels")
+	}
+	if reflect.DeepEqual(heights(a), heights(build(WithSeed(8)))) {
+		t.Error("Expected different seeds to assign different levels")
+	}
+
+	// A single level degrades to a sorted linked list
+	flat := build(WithSeed(1), WithMaxLevel(1))
+	for _, h := range heights(flat) {
+		if h != 1 {
+			t.Fatalf("Expected height 1 with WithMaxLevel(1), got %d", h)
+		}
+	}
+	if k, _, _ := flat.GetAt(500); k != 500 {
+		t.Errorf("Expected GetAt(500) = 500, got %d", k)
+	}
+
+	capped := build(WithSeed(1), WithMaxLevel(3), WithProbability(0.9))
+	tall := 0
+	for _, h := range heights(capped) {
+		if h > 3 {
+			t.Fatalf("Expected heights of at most 3, got %d", h)
+		}
+		if h == 3 {
+			tall++
+		}
+	}
+	if tall < 500 {
+		t.Errorf("Expected most nodes to reach the cap with probability 0.9, got %d", tall)
+	}
+
+	// Invalid values fall back to the defaults
+	o := newOptions([]Option{WithProbability(1), WithProbability(-1), WithMaxLevel(100)})
+	if o.probability != probability || o.maxLevel != maxLevel {
+		t.Errorf("Expected defaults, got probability %v and max level %d", o.probability, o.maxLevel)
+	}
+	if o := newOptions([]Option{WithMaxLevel(0)}); o.maxLevel != 1 {
+		t.Errorf("Expected max level 1, got %d", o.maxLevel)
+	}
+
+	// Clones keep the configuration
+	clone := flat.Clone()
+	clone.Set(-1, -1)
+	if err := clone.Validate(); err != nil {
+		t.Error(err)
+	}
+	for _, h := range heights(clone) {
+		if h != 1 {
+			t.Fatalf("Expected clone to keep WithMaxLevel(1), got height %d", h)
+		}
+	}
+}
+
+func TestSkipListRangeBetweenReversed(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	for i := 1; i <= 6; i++ {
+		sl.Set(i, i)
+	}
+
+	var got []int
+	sl.RangeBetween(5, 2, func(k, _ int) bool {
+		got = append(got, k)
+		return true
+	})
+	if !reflect.DeepEqual(got, []int{2, 3, 4, 5}) {
+		t.Errorf("Expected reversed bounds to be swapped, got %v", got)
+	}
+}
+
+func TestSkipListDeleteBetween(t *testing.T) {
+	sl := NewOrderedSkipList[int, int]()
+	for i := 0; i < 10; i++ {
+		sl.Set(i*10, i)
+	}
+
+	if n := sl.DeleteBetween(15, 45); n != 3 {
+		t.Errorf("Expected 3 pairs removed, got %d", n)
+	}
+	if !reflect.DeepEqual(sl.Keys(), []int{0, 10, 50, 60, 70, 80, 90}) {
+		t.Errorf("Unexpected keys %v", sl.Keys())
+	}
+	if n := sl.DeleteBetween(51, 59); n != 0 {
+		t.Errorf("Expected nothing removed from an empty range, got %d", n)
+	}
+	if n := sl.DeleteBetween(70, 1000); n != 3 {
+		t.Errorf("Expected the tail to be removed, got %d", n)
+	}
+	if k, _, _ := sl.Max(); k != 60 {
+		t.Errorf("Expected Max 60 after removing the tail, got %d", k)
+	}
+	if n := sl.DeleteBetween(5, -5); n != 1 {
+		t.Errorf("Expected reversed bounds to be swapped and remove the head, got %d", n)
+	}
+	if n := sl.DeleteBetween(-5, 0); n != 0 {
+		t.Errorf("Expected the head to be gone already, got %d", n)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if n := sl.DeleteBetween(-1000, 1000); n != 3 || sl.Len() != 0 {
+		t.Errorf("Expected every pair removed, got %d", n)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSkipListDeleteBetweenRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	sl := NewOrderedSkipList[int, int](WithSeed(11))
+	ref := map[int]bool{}
+	for round := 0; round < 200; round++ {
+		for i := 0; i < 20; i++ {
+			k := rng.Intn(1000)
+			sl.Set(k, k)
+			ref[k] = true
+		}
+		lo := rng.Intn(1000)
+		hi := lo + rng.Intn(100)
+		want := 0
+		for k := range ref {
+			if k >= lo && k <= hi {
+				delete(ref, k)
+				want++
+			}
+		}
+		if got := sl.DeleteBetween(lo, hi); got != want {
+			t.Fatalf("DeleteBetween(%d, %d) = %d, want %d", lo, hi, got, want)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Fatalf("Validate after DeleteBetween(%d, %d): %v", lo, hi, err)
+		}
+		if sl.Len() != len(ref) {
+			t.Fatalf("Expected %d pairs, got %d", len(ref), sl.Len())
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package sliding_window provides containers that maintain statistics over a
+// sliding window of the most recent observations.
+// This file implements the indexed ordered multiset used by the window containers.
+
+package sliding_window
+
+import (
+	"cmp"
+)
+
+// treapNode is a node in a size-augmented treap.
+// Equal values share one node and are tracked by count.
+type treapNode[T cmp.Ordered] struct {
+	value    T
+	count    int    // number of copies of value
+	size     int    // total number of copies in this subtree
+	priority uint64 // heap priority, keeps the tree balanced in expectation
+	left     *treapNode[T]
+	right    *treapNode[T]
+}
+
+// nodeSize returns the subtree size of n, treating nil as empty.
+func nodeSize[T cmp.Ordered](n *treapNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recomputes the subtree size of n from its children.
+func (n *treapNode[T]) update() {
+	n.size = n.count + nodeSize(n.left) + nodeSize(n.right)
+}
+
+// orderTree is an ordered multiset supporting rank queries in O(log n) expected time.
+type orderTree[T cmp.Ordered] struct {
+	root *treapNode[T]
+	seed uint64 // xorshift state for node priorities
+}
+
+// newOrderTree creates an empty ordered multiset.
+func newOrderTree[T cmp.Ordered]() *orderTree[T] {
+	return &orderTree[T]{seed: 0x9E3779B97F4A7C15}
+}
+
+// nextPriority returns the next pseudo-random priority.
+func (t *orderTree[T]) nextPriority() uint64 {
+	t.seed ^= t.seed << 13
+	t.seed ^= t.seed >> 7
+	t.seed ^= t.seed << 17
+	return t.seed
+}
+
+// len returns the number of values in the multiset, counting duplicates.
+func (t *orderTree[T]) len() int {
+	return nodeSize(t.root)
+}
+
+// insert adds one copy of value.
+func (t *orderTree[T]) insert(value T) {
+	t.root = t.insertAt(t.root, value)
+}
+
+func (t *orderTree[T]) insertAt(n *treapNode[T], value T) *treapNode[T] {
+	if n == nil {
+		return &treapNode[T]{value: value, count: 1, size: 1, priority: t.nextPriority()}
+	}
+	switch c := cmp.Compare(value, n.value); {
+	case c < 0:
+		n.left = t.insertAt(n.left, value)
+		if n.left.priority > n.priority {
+			n = rotateRight(n)
+		}
+	case c > 0:
+		n.right = t.insertAt(n.right, value)
+		if n.right.priority > n.priority {
+			n = rotateLeft(n)
+		}
+	default:
+		n.count++
+	}
+	n.update()
+	return n
+}
+
+// remove deletes one copy of value. Returns false if value is not present.
+func (t *orderTree[T]) remove(value T) bool {
+	var removed bool
+	t.root, removed = removeAt(t.root, value)
+	return removed
+}
+
+func removeAt[T cmp.Ordered](n *treapNode[T], value T) (*treapNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := cmp.Compare(value, n.value); {
+	case c < 0:
+		n.left, removed = removeAt(n.left, value)
+	case c > 0:
+		n.right, removed = removeAt(n.right, value)
+	default:
+		removed = true
+		if n.count > 1 {
+			n.count--
+			break
+		}
+		// Rotate the node down until it has at most one child, then splice it out
+		switch {
+		case n.left == nil:
+			return n.right, true
+		case n.right == nil:
+			return n.left, true
+		case n.left.priority > n.right.priority:
+			n = rotateRight(n)
+			n.right, _ = removeAt(n.right, value)
+		default:
+			n = rotateLeft(n)
+			n.left, _ = removeAt(n.left, value)
+		}
+	}
+	n.update()
+	return n, removed
+}
+
+// kth returns the i-th smallest value (0-based), counting duplicates.
+func (t *orderTree[T]) kth(i int) (T, bool) {
+	if i < 0 || i >= t.len() {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for {
+		leftSize := nodeSize(n.left)
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i < leftSize+n.count:
+			return n.value, true
+		default:
+			i -= leftSize + n.count
+			n = n.right
+		}
+	}
+}
+
+// rank returns the number of values strictly less than value.
+func (t *orderTree[T]) rank(value T) int {
+	r := 0
+	n := t.root
+	for n != nil {
+		switch c := cmp.Compare(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			r += nodeSize(n.left) + n.count
+			n = n.right
+		default:
+			return r + nodeSize(n.left)
+		}
+	}
+	return r
+}
+
+// rotateLeft performs a left rotation and returns the new subtree root.
+func rotateLeft[T cmp.Ordered](x *treapNode[T]) *treapNode[T] {
+	y := x.right
+	x.right = y.left
+	y.left = x
+	x.update()
+	y.update()
+	return y
+}
+
+// rotateRight performs a right rotation and returns the new subtree root.
+func rotateRight[T cmp.Ordered](x *treapNode[T]) *treapNode[T] {
+	y := x.left
+	x.left = y.right
+	y.right = x
+	x.update()
+	y.update()
+	return y
+}
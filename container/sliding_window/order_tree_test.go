@@ -0,0 +1,46 @@
+package sliding_window
+
+import (
+	"testing"
+)
+
+func TestOrderTree(t *testing.T) {
+	tree := newOrderTree[int]()
+	for _, v := range []int{3, 1, 2, 3, 5} {
+		tree.insert(v)
+	}
+
+	if tree.len() != 5 {
+		t.Errorf("Expected length 5, got %d", tree.len())
+	}
+
+	expected := []int{1, 2, 3, 3, 5}
+	for i, want := range expected {
+		if got, ok := tree.kth(i); !ok || got != want {
+			t.Errorf("kth(%d) = %d, want %d", i, got, want)
+		}
+	}
+	if _, ok := tree.kth(5); ok {
+		t.Error("Expected false for out-of-range kth")
+	}
+
+	if r := tree.rank(3); r != 2 {
+		t.Errorf("Expected rank(3) = 2, got %d", r)
+	}
+	if r := tree.rank(4); r != 4 {
+		t.Errorf("Expected rank(4) = 4, got %d", r)
+	}
+
+	if !tree.remove(3) {
+		t.Error("Expected true when removing existing value")
+	}
+	if tree.remove(4) {
+		t.Error("Expected false when removing missing value")
+	}
+	if got, _ := tree.kth(2); got != 3 {
+		t.Errorf("Expected remaining copy of 3 at index 2, got %d", got)
+	}
+	if tree.len() != 4 {
+		t.Errorf("Expected length 4, got %d", tree.len())
+	}
+}
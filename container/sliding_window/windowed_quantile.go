@@ -0,0 +1,122 @@
+// Package sliding_window provides containers that maintain statistics over a
+// sliding window of the most recent observations.
+// This file implements WindowedQuantile for window median/quantile queries.
+
+package sliding_window
+
+import (
+	"cmp"
+	"math"
+)
+
+// entry is an observation waiting in the expiry queue.
+type entry[T cmp.Ordered] struct {
+	value T
+	seq   int64
+}
+
+// WindowedQuantile maintains the order statistics of the values pushed within
+// a sliding window. Values are kept in an indexed ordered multiset for rank
+// queries, and in a FIFO queue ordered by sequence number for expiry.
+//
+// Sequence numbers are caller-defined (timestamps, counters, offsets) and must
+// be pushed in non-decreasing order.
+type WindowedQuantile[T cmp.Ordered] struct {
+	tree  *orderTree[T]
+	queue []entry[T] // expiry queue, queue[head:] holds live entries
+	head  int
+}
+
+// NewWindowedQuantile creates a new empty WindowedQuantile.
+func NewWindowedQuantile[T cmp.Ordered]() *WindowedQuantile[T] {
+	return &WindowedQuantile[T]{
+		tree: newOrderTree[T](),
+	}
+}
+
+// Len returns the number of values currently in the window.
+func (w *WindowedQuantile[T]) Len() int {
+	return len(w.queue) - w.head
+}
+
+// Push adds a value observed at the given sequence number.
+// Returns false without adding the value if seq is smaller than the
+// sequence number of the most recent push.
+func (w *WindowedQuantile[T]) Push(value T, seq int64) bool {
+	if w.Len() > 0 && seq < w.queue[len(w.queue)-1].seq {
+		return false
+	}
+	w.queue = append(w.queue, entry[T]{value: value, seq: seq})
+	w.tree.insert(value)
+	return true
+}
+
+// EvictOlderThan removes every value whose sequence number is less than seq.
+// Returns the number of values removed.
+func (w *WindowedQuantile[T]) EvictOlderThan(seq int64) int {
+	removed := 0
+	for w.head < len(w.queue) && w.queue[w.head].seq < seq {
+		w.tree.remove(w.queue[w.head].value)
+		w.queue[w.head] = entry[T]{} // release references held by value
+		w.head++
+		removed++
+	}
+
+	// Compact the queue once the dead prefix dominates it
+	if w.head > 0 && w.head >= len(w.queue)/2 {
+		n := copy(w.queue, w.queue[w.head:])
+		w.queue = w.queue[:n]
+		w.head = 0
+	}
+	return removed
+}
+
+// Clear removes all values from the window.
+func (w *WindowedQuantile[T]) Clear() {
+	w.tree = newOrderTree[T]()
+	w.queue = nil
+	w.head = 0
+}
+
+// Kth returns the i-th smallest value in the window (0-based).
+// Returns the zero value and false if i is out of range.
+func (w *WindowedQuantile[T]) Kth(i int) (T, bool) {
+	return w.tree.kth(i)
+}
+
+// Rank returns the number of values in the window strictly less than value.
+func (w *WindowedQuantile[T]) Rank(value T) int {
+	return w.tree.rank(value)
+}
+
+// Quantile returns the q-quantile of the window using the nearest-rank method,
+// where q is in [0, 1]. Quantile(0) is the minimum and Quantile(1) the maximum.
+// Returns the zero value and false if the window is empty or q is out of range.
+func (w *WindowedQuantile[T]) Quantile(q float64) (T, bool) {
+	n := w.Len()
+	if n == 0 || q < 0 || q > 1 || math.IsNaN(q) {
+		var zero T
+		return zero, false
+	}
+	i := int(math.Ceil(q*float64(n))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return w.tree.kth(i)
+}
+
+// Median returns the lower median of the window.
+// Returns the zero value and false if the window is empty.
+func (w *WindowedQuantile[T]) Median() (T, bool) {
+	return w.tree.kth((w.Len() - 1) / 2)
+}
+
+// Min returns the smallest value in the window.
+func (w *WindowedQuantile[T]) Min() (T, bool) {
+	return w.tree.kth(0)
+}
+
+// Max returns the largest value in the window.
+func (w *WindowedQuantile[T]) Max() (T, bool) {
+	return w.tree.kth(w.Len() - 1)
+}
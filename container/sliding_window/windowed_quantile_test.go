@@ -0,0 +1,133 @@
+package sliding_window
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestWindowedQuantileBasic(t *testing.T) {
+	w := NewWindowedQuantile[int]()
+
+	// Test empty window
+	if w.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", w.Len())
+	}
+	if _, ok := w.Quantile(0.5); ok {
+		t.Error("Expected false for quantile of empty window")
+	}
+	if _, ok := w.Median(); ok {
+		t.Error("Expected false for median of empty window")
+	}
+
+	for i, v := range []int{5, 1, 4, 2, 3} {
+		if !w.Push(v, int64(i)) {
+			t.Errorf("Expected Push(%d, %d) to succeed", v, i)
+		}
+	}
+
+	if m, ok := w.Median(); !ok || m != 3 {
+		t.Errorf("Expected median 3, got %d", m)
+	}
+	if v, _ := w.Quantile(0); v != 1 {
+		t.Errorf("Expected Quantile(0) = 1, got %d", v)
+	}
+	if v, _ := w.Quantile(1); v != 5 {
+		t.Errorf("Expected Quantile(1) = 5, got %d", v)
+	}
+	if v, _ := w.Quantile(0.9); v != 5 {
+		t.Errorf("Expected Quantile(0.9) = 5, got %d", v)
+	}
+	if v, _ := w.Quantile(0.2); v != 1 {
+		t.Errorf("Expected Quantile(0.2) = 1, got %d", v)
+	}
+	if _, ok := w.Quantile(1.5); ok {
+		t.Error("Expected false for out-of-range quantile")
+	}
+	if r := w.Rank(4); r != 3 {
+		t.Errorf("Expected Rank(4) = 3, got %d", r)
+	}
+
+	// Out-of-order sequence numbers are rejected
+	if w.Push(10, 2) {
+		t.Error("Expected Push with decreasing seq to fail")
+	}
+	if w.Len() != 5 {
+		t.Errorf("Expected length 5, got %d", w.Len())
+	}
+}
+
+func TestWindowedQuantileEvict(t *testing.T) {
+	w := NewWindowedQuantile[int]()
+	for i := 0; i < 10; i++ {
+		w.Push(i, int64(i))
+	}
+
+	if n := w.EvictOlderThan(6); n != 6 {
+		t.Errorf("Expected 6 evictions, got %d", n)
+	}
+	if w.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", w.Len())
+	}
+	if v, _ := w.Min(); v != 6 {
+		t.Errorf("Expected min 6, got %d", v)
+	}
+	if v, _ := w.Max(); v != 9 {
+		t.Errorf("Expected max 9, got %d", v)
+	}
+	if n := w.EvictOlderThan(6); n != 0 {
+		t.Errorf("Expected no evictions, got %d", n)
+	}
+
+	w.Clear()
+	if w.Len() != 0 {
+		t.Errorf("Expected length 0 after Clear, got %d", w.Len())
+	}
+}
+
+func TestWindowedQuantileDuplicates(t *testing.T) {
+	w := NewWindowedQuantile[int]()
+	for i, v := range []int{7, 7, 7, 1, 7} {
+		w.Push(v, int64(i))
+	}
+	w.EvictOlderThan(2)
+
+	// Window is now [7, 1, 7]
+	if w.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", w.Len())
+	}
+	if m, _ := w.Median(); m != 7 {
+		t.Errorf("Expected median 7, got %d", m)
+	}
+	if v, _ := w.Min(); v != 1 {
+		t.Errorf("Expected min 1, got %d", v)
+	}
+}
+
+func TestWindowedQuantileRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	w := NewWindowedQuantile[int]()
+	const window = 50
+
+	var values []int
+	for seq := 0; seq < 2000; seq++ {
+		v := rng.Intn(100)
+		w.Push(v, int64(seq))
+		values = append(values, v)
+		w.EvictOlderThan(int64(seq - window + 1))
+		if len(values) > window {
+			values = values[1:]
+		}
+
+		sorted := append([]int(nil), values...)
+		sort.Ints(sorted)
+		for i, want := range sorted {
+			if got, ok := w.Kth(i); !ok || got != want {
+				t.Fatalf("seq %d: Kth(%d) = %d, want %d", seq, i, got, want)
+			}
+		}
+		if got, _ := w.Median(); got != sorted[(len(sorted)-1)/2] {
+			t.Fatalf("seq %d: Median() = %d, want %d", seq, got, sorted[(len(sorted)-1)/2])
+		}
+	}
+}
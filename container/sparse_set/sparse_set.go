@@ -0,0 +1,95 @@
+// Package sparse_set provides a set of integers from a fixed universe with
+// O(1) add, remove, membership and clear.
+
+package sparse_set
+
+// SparseSet is a set of integers in [0, universe) stored in two arrays: a
+// dense array of the members in insertion order, and a sparse array giving
+// each member's position in the dense one. Every operation, including
+// Clear, is O(1), and iteration walks only the dense members, which suits
+// marking that is reset every frame or iteration. Memory is O(universe).
+//
+// Removing a member moves the last member into its place, so iteration order
+// is insertion order only until the first removal.
+type SparseSet struct {
+	dense  []int // members, the first n of which are valid
+	sparse []int // sparse[i] is the position of i in dense, if i is a member
+	n      int   // number of members
+}
+
+// NewSparseSet creates an empty set for the integers 0 to universe-1.
+// A negative universe is treated as 0.
+func NewSparseSet(universe int) *SparseSet {
+	universe = max(universe, 0)
+	return &SparseSet{
+		dense:  make([]int, universe),
+		sparse: make([]int, universe),
+	}
+}
+
+// Universe returns the number of integers the set can hold.
+func (s *SparseSet) Universe() int {
+	return len(s.sparse)
+}
+
+// Len returns the number of members.
+func (s *SparseSet) Len() int {
+	return s.n
+}
+
+// Contains returns true if i is a member. Stale entries in the sparse
+// array left by Clear and Remove are rejected by checking the dense array.
+func (s *SparseSet) Contains(i int) bool {
+	if i < 0 || i >= len(s.sparse) {
+		return false
+	}
+	pos := s.sparse[i]
+	return pos < s.n && s.dense[pos] == i
+}
+
+// Add adds i to the set.
+// Returns true if i was added, false if it was already a member or is
+// outside the universe.
+func (s *SparseSet) Add(i int) bool {
+	if i < 0 || i >= len(s.sparse) || s.Contains(i) {
+		return false
+	}
+	s.dense[s.n] = i
+	s.sparse[i] = s.n
+	s.n++
+	return true
+}
+
+// Remove removes i from the set.
+// Returns true if i was found and removed, false otherwise.
+func (s *SparseSet) Remove(i int) bool {
+	if !s.Contains(i) {
+		return false
+	}
+	pos := s.sparse[i]
+	last := s.dense[s.n-1]
+	s.dense[pos] = last
+	s.sparse[last] = pos
+	s.n--
+	return true
+}
+
+// Clear removes all members in O(1).
+func (s *SparseSet) Clear() {
+	s.n = 0
+}
+
+// Range calls fn for each member in the order of the dense array until fn
+// returns false. fn must not modify the set.
+func (s *SparseSet) Range(fn func(i int) bool) {
+	for _, i := range s.dense[:s.n] {
+		if !fn(i) {
+			return
+		}
+	}
+}
+
+// Slice returns the members in the order of the dense array.
+func (s *SparseSet) Slice() []int {
+	return append([]int(nil), s.dense[:s.n]...)
+}
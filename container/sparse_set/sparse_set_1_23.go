@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+// Package sparse_set provides a set of integers from a fixed universe with
+// O(1) add, remove, membership and clear.
+// This file implements the iterator-based methods of SparseSet (go1.23).
+
+package sparse_set
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the members in the order of the dense
+// array. The set must not be modified during iteration.
+func (s *SparseSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		s.Range(yield)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build go1.23
+// +build go1.23
+
+package sparse_set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSparseSetAll(t *testing.T) {
+	s := NewSparseSet(10)
+	for _, v := range []int{4, 1, 9} {
+		s.Add(v)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{4, 1, 9}) {
+		t.Errorf("Expected [4 1 9], got %v", got)
+	}
+}
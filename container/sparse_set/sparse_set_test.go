@@ -0,0 +1,124 @@
+package sparse_set
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSparseSetBasic(t *testing.T) {
+	s := NewSparseSet(10)
+	if s.Universe() != 10 || s.Len() != 0 {
+		t.Errorf("Expected an empty set over 10 integers, got %d members over %d", s.Len(), s.Universe())
+	}
+	if !s.Add(3) || !s.Add(7) || !s.Add(0) || s.Add(3) || s.Add(10) || s.Add(-1) {
+		t.Error("Add returned unexpected results")
+	}
+	if !reflect.DeepEqual(s.Slice(), []int{3, 7, 0}) {
+		t.Errorf("Expected members in insertion order [3 7 0], got %v", s.Slice())
+	}
+	if !s.Remove(3) || s.Remove(3) || s.Remove(11) {
+		t.Error("Remove returned unexpected results")
+	}
+	if !reflect.DeepEqual(s.Slice(), []int{0, 7}) {
+		t.Errorf("Expected the last member to fill the gap, got %v", s.Slice())
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Contains(0) || s.Contains(7) {
+		t.Error("Expected an empty set after Clear")
+	}
+	if !s.Add(7) || !s.Contains(7) || s.Contains(0) {
+		t.Error("Expected stale entries to be ignored after Clear")
+	}
+
+	empty := NewSparseSet(-5)
+	if empty.Universe() != 0 || empty.Add(0) {
+		t.Error("Expected a negative universe to hold nothing")
+	}
+}
+
+func TestSparseSetRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const universe = 100
+	s := NewSparseSet(universe)
+	ref := make(map[int]bool)
+	for i := 0; i < 10000; i++ {
+		v := rng.Intn(universe)
+		switch rng.Intn(10) {
+		case 0:
+			s.Clear()
+			clear(ref)
+		case 1, 2, 3, 4:
+			if got := s.Remove(v); got != ref[v] {
+				t.Fatalf("Remove(%d) = %v, want %v", v, got, ref[v])
+			}
+			delete(ref, v)
+		default:
+			if got := s.Add(v); got != !ref[v] {
+				t.Fatalf("Add(%d) = %v, want %v", v, got, !ref[v])
+			}
+			ref[v] = true
+		}
+		if s.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", s.Len(), len(ref))
+		}
+	}
+	for v := 0; v < universe; v++ {
+		if s.Contains(v) != ref[v] {
+			t.Fatalf("Contains(%d) = %v, want %v", v, s.Contains(v), ref[v])
+		}
+	}
+	members := s.Slice()
+	sort.Ints(members)
+	if len(members) != len(ref) {
+		t.Fatalf("Slice() has %d members, want %d", len(members), len(ref))
+	}
+}
+
+func TestSparseSetRangeStops(t *testing.T) {
+	s := NewSparseSet(5)
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+	count := 0
+	s.Range(func(int) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Expected Range to stop after 2 members, got %d", count)
+	}
+}
+
+// sink keeps benchmark results alive.
+var sink int
+
+// BenchmarkClear compares marking two elements and clearing them with a
+// bool slice, whose clear is O(universe).
+func BenchmarkClear(b *testing.B) {
+	const universe = 1 << 16
+	b.Run("SparseSet", func(b *testing.B) {
+		s := NewSparseSet(universe)
+		for i := 0; i < b.N; i++ {
+			s.Add(i % universe)
+			s.Add((i * 7) % universe)
+			if s.Contains((i * 3) % universe) {
+				sink++
+			}
+			s.Clear()
+		}
+	})
+	b.Run("BoolSlice", func(b *testing.B) {
+		marks := make([]bool, universe)
+		for i := 0; i < b.N; i++ {
+			marks[i%universe] = true
+			marks[(i*7)%universe] = true
+			if marks[(i*3)%universe] {
+				sink++
+			}
+			clear(marks)
+		}
+	})
+}
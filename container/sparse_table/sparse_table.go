@@ -0,0 +1,83 @@
+// Package sparse_table provides an immutable sparse table answering range
+// queries over static data in O(1).
+
+package sparse_table
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// SparseTable answers range queries with an idempotent, associative combine
+// function (such as min, max, gcd, bitwise and/or) over a slice that never
+// changes. Construction takes O(n log n) time and space; each query is O(1)
+// because any range is covered by two overlapping power-of-two blocks.
+type SparseTable[T any] struct {
+	// levels[k][i] combines the 2^k elements starting at i
+	levels  [][]T
+	combine func(a, b T) T
+}
+
+// NewSparseTable builds a sparse table over data. combine must be
+// associative and idempotent (combine(x, x) == x), otherwise query results
+// are undefined. data is copied, so later changes to it are not observed.
+func NewSparseTable[T any](data []T, combine func(a, b T) T) *SparseTable[T] {
+	n := len(data)
+	st := &SparseTable[T]{combine: combine}
+	if n == 0 {
+		return st
+	}
+
+	st.levels = make([][]T, bits.Len(uint(n)))
+	st.levels[0] = append([]T(nil), data...)
+	for k := 1; k < len(st.levels); k++ {
+		half := 1 << (k - 1)
+		prev := st.levels[k-1]
+		level := make([]T, n-(1<<k)+1)
+		for i := range level {
+			level[i] = combine(prev[i], prev[i+half])
+		}
+		st.levels[k] = level
+	}
+	return st
+}
+
+// NewMinSparseTable builds a sparse table answering range-minimum queries.
+func NewMinSparseTable[T cmp.Ordered](data []T) *SparseTable[T] {
+	return NewSparseTable(data, func(a, b T) T { return min(a, b) })
+}
+
+// NewMaxSparseTable builds a sparse table answering range-maximum queries.
+func NewMaxSparseTable[T cmp.Ordered](data []T) *SparseTable[T] {
+	return NewSparseTable(data, func(a, b T) T { return max(a, b) })
+}
+
+// Len returns the number of elements in the table.
+func (st *SparseTable[T]) Len() int {
+	if len(st.levels) == 0 {
+		return 0
+	}
+	return len(st.levels[0])
+}
+
+// Query returns the combination of the elements in [lo, hi).
+// Returns the zero value and false if the range is empty or out of bounds.
+func (st *SparseTable[T]) Query(lo, hi int) (T, bool) {
+	if lo < 0 || hi > st.Len() || lo >= hi {
+		var zero T
+		return zero, false
+	}
+	k := bits.Len(uint(hi-lo)) - 1
+	level := st.levels[k]
+	return st.combine(level[lo], level[hi-(1<<k)]), true
+}
+
+// At returns the element at index i.
+// Returns the zero value and false if i is out of bounds.
+func (st *SparseTable[T]) At(i int) (T, bool) {
+	if i < 0 || i >= st.Len() {
+		var zero T
+		return zero, false
+	}
+	return st.levels[0][i], true
+}
@@ -0,0 +1,82 @@
+package sparse_table
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSparseTableMinMax(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 7, 8, 9, 100} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = rng.Intn(1000) - 500
+		}
+		mins, maxs := NewMinSparseTable(data), NewMaxSparseTable(data)
+		if mins.Len() != n {
+			t.Errorf("Expected length %d, got %d", n, mins.Len())
+		}
+
+		for lo := 0; lo < n; lo++ {
+			for hi := lo + 1; hi <= n; hi++ {
+				wantMin, wantMax := data[lo], data[lo]
+				for _, v := range data[lo:hi] {
+					wantMin, wantMax = min(wantMin, v), max(wantMax, v)
+				}
+				if got, ok := mins.Query(lo, hi); !ok || got != wantMin {
+					t.Fatalf("n=%d: min[%d, %d) = %d, want %d", n, lo, hi, got, wantMin)
+				}
+				if got, ok := maxs.Query(lo, hi); !ok || got != wantMax {
+					t.Fatalf("n=%d: max[%d, %d) = %d, want %d", n, lo, hi, got, wantMax)
+				}
+			}
+		}
+	}
+}
+
+func TestSparseTableGCD(t *testing.T) {
+	gcd := func(a, b int) int {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	st := NewSparseTable([]int{12, 18, 24, 9, 27, 5}, gcd)
+
+	tests := []struct {
+		lo, hi, want int
+	}{
+		{0, 3, 6}, {0, 4, 3}, {3, 5, 9}, {0, 6, 1}, {2, 3, 24},
+	}
+	for _, tt := range tests {
+		if got, _ := st.Query(tt.lo, tt.hi); got != tt.want {
+			t.Errorf("gcd[%d, %d) = %d, want %d", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestSparseTableBounds(t *testing.T) {
+	data := []int{3, 1, 2}
+	st := NewMinSparseTable(data)
+	data[1] = -100 // the table keeps its own copy
+
+	for _, r := range [][2]int{{-1, 2}, {0, 4}, {2, 2}, {2, 1}} {
+		if _, ok := st.Query(r[0], r[1]); ok {
+			t.Errorf("Expected Query(%d, %d) to fail", r[0], r[1])
+		}
+	}
+	if got, _ := st.Query(0, 3); got != 1 {
+		t.Errorf("Expected min 1, got %d", got)
+	}
+	if v, ok := st.At(2); !ok || v != 2 {
+		t.Errorf("Expected At(2) = 2, got %d, %v", v, ok)
+	}
+	if _, ok := st.At(3); ok {
+		t.Error("Expected At(3) to fail")
+	}
+
+	empty := NewMinSparseTable[int](nil)
+	if _, ok := empty.Query(0, 0); ok || empty.Len() != 0 {
+		t.Error("Expected empty table")
+	}
+}
@@ -0,0 +1,234 @@
+// Package suffix_array provides a suffix array with an LCP array for
+// full-text substring search over a fixed text.
+
+package suffix_array
+
+import (
+	"bytes"
+	"sort"
+)
+
+// SuffixArray indexes every suffix of a text in lexicographical order.
+//
+// The suffix array is built by prefix doubling with counting sorts in
+// O(n log n), and the LCP array with Kasai's algorithm in O(n).
+// Substring lookups take O(m log n) for a pattern of length m.
+type SuffixArray struct {
+	text []byte
+	sa   []int // sa[i] is the start of the i-th smallest suffix
+	lcp  []int // lcp[i] is the common prefix length of suffixes sa[i-1] and sa[i]
+}
+
+// New builds a suffix array over text. text is not copied and must not
+// be modified while the suffix array is in use.
+func New(text []byte) *SuffixArray {
+	sa := buildSuffixArray(text)
+	return &SuffixArray{
+		text: text,
+		sa:   sa,
+		lcp:  buildLCP(text, sa),
+	}
+}
+
+// NewFromString builds a suffix array over s.
+func NewFromString(s string) *SuffixArray {
+	return New([]byte(s))
+}
+
+// buildSuffixArray sorts the suffixes of s by prefix doubling: after the
+// round for k, suffixes are ordered by their first 2k bytes, with rank
+// holding the equivalence class of each prefix.
+func buildSuffixArray(s []byte) []int {
+	n := len(s)
+	if n == 0 {
+		return []int{}
+	}
+	sa := make([]int, n)
+	rank := make([]int, n)
+	tmp := make([]int, n)
+	cnt := make([]int, max(256, n))
+
+	// Initial order by first byte
+	for _, c := range s {
+		cnt[c]++
+	}
+	for i := 1; i < 256; i++ {
+		cnt[i] += cnt[i-1]
+	}
+	for i := n - 1; i >= 0; i-- {
+		cnt[s[i]]--
+		sa[cnt[s[i]]] = i
+	}
+	classes := 1
+	for i := 1; i < n; i++ {
+		if s[sa[i]] != s[sa[i-1]] {
+			classes++
+		}
+		rank[sa[i]] = classes - 1
+	}
+
+	for k := 1; classes < n; k <<= 1 {
+		// Order by second key: suffixes without a second half come first
+		p := 0
+		for i := n - k; i < n; i++ {
+			tmp[p] = i
+			p++
+		}
+		for _, j := range sa {
+			if j >= k {
+				tmp[p] = j - k
+				p++
+			}
+		}
+
+		// Stable counting sort by first key
+		clear(cnt[:classes])
+		for _, r := range rank {
+			cnt[r]++
+		}
+		for i := 1; i < classes; i++ {
+			cnt[i] += cnt[i-1]
+		}
+		for i := n - 1; i >= 0; i-- {
+			j := tmp[i]
+			cnt[rank[j]]--
+			sa[cnt[rank[j]]] = j
+		}
+
+		// Recompute classes from (first key, second key) pairs
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		tmp[sa[0]] = 0
+		classes = 1
+		for i := 1; i < n; i++ {
+			cur, prev := sa[i], sa[i-1]
+			if rank[cur] != rank[prev] || second(cur) != second(prev) {
+				classes++
+			}
+			tmp[cur] = classes - 1
+		}
+		rank, tmp = tmp, rank
+	}
+	return sa
+}
+
+// buildLCP computes the LCP array with Kasai's algorithm, which reuses the
+// previous suffix's match length since it shrinks by at most one per step.
+func buildLCP(s []byte, sa []int) []int {
+	n := len(sa)
+	lcp := make([]int, n)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	h := 0
+	for p := 0; p < n; p++ {
+		if rank[p] == 0 {
+			h = 0
+			continue
+		}
+		q := sa[rank[p]-1]
+		for p+h < n && q+h < n && s[p+h] == s[q+h] {
+			h++
+		}
+		lcp[rank[p]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+// Len returns the length of the indexed text.
+func (a *SuffixArray) Len() int {
+	return len(a.text)
+}
+
+// Text returns the indexed text.
+func (a *SuffixArray) Text() []byte {
+	return a.text
+}
+
+// Suffixes returns a copy of the suffix array: the start offsets of all
+// suffixes in lexicographical order.
+func (a *SuffixArray) Suffixes() []int {
+	return append([]int(nil), a.sa...)
+}
+
+// LCP returns a copy of the LCP array. Element i is the length of the
+// longest common prefix of the suffixes at ranks i-1 and i; element 0 is 0.
+func (a *SuffixArray) LCP() []int {
+	return append([]int(nil), a.lcp...)
+}
+
+// lookupRange returns the rank range [lo, hi) of suffixes starting with pattern.
+func (a *SuffixArray) lookupRange(pattern []byte) (int, int) {
+	n, m := len(a.sa), len(pattern)
+	prefix := func(i int) []byte {
+		p := a.sa[i]
+		return a.text[p:min(p+m, len(a.text))]
+	}
+	lo := sort.Search(n, func(i int) bool {
+		return bytes.Compare(prefix(i), pattern) >= 0
+	})
+	hi := lo + sort.Search(n-lo, func(i int) bool {
+		return bytes.Compare(prefix(lo+i), pattern) > 0
+	})
+	return lo, hi
+}
+
+// Lookup returns the offsets of every occurrence of pattern in the text in
+// ascending order. An empty pattern matches at every offset.
+func (a *SuffixArray) Lookup(pattern []byte) []int {
+	lo, hi := a.lookupRange(pattern)
+	offsets := append([]int(nil), a.sa[lo:hi]...)
+	sort.Ints(offsets)
+	return offsets
+}
+
+// LookupString is like Lookup for a string pattern.
+func (a *SuffixArray) LookupString(pattern string) []int {
+	return a.Lookup([]byte(pattern))
+}
+
+// Count returns the number of occurrences of pattern in the text.
+func (a *SuffixArray) Count(pattern []byte) int {
+	lo, hi := a.lookupRange(pattern)
+	return hi - lo
+}
+
+// Contains returns true if pattern occurs in the text.
+func (a *SuffixArray) Contains(pattern []byte) bool {
+	return a.Count(pattern) > 0
+}
+
+// LongestRepeatedSubstring returns the longest substring occurring at least
+// twice in the text (occurrences may overlap), or nil if there is none.
+// Among equally long candidates, the lexicographically smallest is returned.
+func (a *SuffixArray) LongestRepeatedSubstring() []byte {
+	best, at := 0, 0
+	for i, h := range a.lcp {
+		if h > best {
+			best, at = h, a.sa[i]
+		}
+	}
+	if best == 0 {
+		return nil
+	}
+	return a.text[at : at+best]
+}
+
+// DistinctSubstrings returns the number of distinct non-empty substrings
+// of the text.
+func (a *SuffixArray) DistinctSubstrings() int {
+	n := len(a.text)
+	total := n * (n + 1) / 2
+	for _, h := range a.lcp {
+		total -= h
+	}
+	return total
+}
@@ -0,0 +1,110 @@
+package suffix_array
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSuffixArrayBanana(t *testing.T) {
+	a := NewFromString("banana")
+
+	if want := []int{5, 3, 1, 0, 4, 2}; !reflect.DeepEqual(a.Suffixes(), want) {
+		t.Errorf("Expected suffix array %v, got %v", want, a.Suffixes())
+	}
+	if want := []int{0, 1, 3, 0, 0, 2}; !reflect.DeepEqual(a.LCP(), want) {
+		t.Errorf("Expected LCP %v, got %v", want, a.LCP())
+	}
+	if got := string(a.LongestRepeatedSubstring()); got != "ana" {
+		t.Errorf("Expected longest repeated substring 'ana', got %q", got)
+	}
+	if got := a.DistinctSubstrings(); got != 15 {
+		t.Errorf("Expected 15 distinct substrings, got %d", got)
+	}
+
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"ana", []int{1, 3}},
+		{"a", []int{1, 3, 5}},
+		{"banana", []int{0}},
+		{"nab", []int{}},
+		{"bananas", []int{}},
+	}
+	for _, tt := range tests {
+		got := a.LookupString(tt.pattern)
+		if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("Lookup(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+		if a.Count([]byte(tt.pattern)) != len(tt.want) {
+			t.Errorf("Count(%q) = %d, want %d", tt.pattern, a.Count([]byte(tt.pattern)), len(tt.want))
+		}
+	}
+	if !a.Contains([]byte("nan")) || a.Contains([]byte("x")) {
+		t.Error("Unexpected Contains result")
+	}
+}
+
+func TestSuffixArrayEmpty(t *testing.T) {
+	a := New(nil)
+	if a.Len() != 0 || len(a.Suffixes()) != 0 {
+		t.Error("Expected empty suffix array")
+	}
+	if a.LongestRepeatedSubstring() != nil || a.Contains([]byte("a")) {
+		t.Error("Expected no matches in empty text")
+	}
+	if NewFromString("abc").LongestRepeatedSubstring() != nil {
+		t.Error("Expected no repeated substring in 'abc'")
+	}
+}
+
+func TestSuffixArrayRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 200; iter++ {
+		text := make([]byte, rng.Intn(60))
+		for i := range text {
+			text[i] = "ab\x00\xff"[rng.Intn(4)]
+		}
+		a := New(text)
+
+		want := make([]int, len(text))
+		for i := range want {
+			want[i] = i
+		}
+		sort.Slice(want, func(i, j int) bool {
+			return bytes.Compare(text[want[i]:], text[want[j]:]) < 0
+		})
+		if got := a.Suffixes(); len(got) != len(want) || len(want) > 0 && !reflect.DeepEqual(got, want) {
+			t.Fatalf("text %q: expected suffix array %v, got %v", text, want, a.Suffixes())
+		}
+
+		lcp := a.LCP()
+		for i := 1; i < len(want); i++ {
+			x, y := text[want[i-1]:], text[want[i]:]
+			h := 0
+			for h < len(x) && h < len(y) && x[h] == y[h] {
+				h++
+			}
+			if lcp[i] != h {
+				t.Fatalf("text %q: lcp[%d] = %d, want %d", text, i, lcp[i], h)
+			}
+		}
+
+		if len(text) > 0 {
+			lo := rng.Intn(len(text))
+			pattern := text[lo : lo+rng.Intn(min(4, len(text)-lo))+1]
+			var occ []int
+			for i := 0; i+len(pattern) <= len(text); i++ {
+				if bytes.Equal(text[i:i+len(pattern)], pattern) {
+					occ = append(occ, i)
+				}
+			}
+			if got := a.Lookup(pattern); !reflect.DeepEqual(got, occ) {
+				t.Fatalf("text %q: Lookup(%q) = %v, want %v", text, pattern, got, occ)
+			}
+		}
+	}
+}
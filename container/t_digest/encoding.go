@@ -0,0 +1,135 @@
+// Package t_digest provides a t-digest for estimating quantiles of a stream
+// of values in bounded memory.
+// This file implements binary serialization for TDigest.
+
+package t_digest
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// digestMagic identifies the binary encoding of a TDigest, followed by a
+// version byte.
+const (
+	digestMagic   = "BRTDG"
+	digestVersion = 1
+)
+
+// integralWeights is the flag set when every weight is encoded as a uvarint.
+const integralWeights = 1
+
+// ErrInvalidEncoding is returned when decoding data that is not a valid
+// TDigest encoding.
+var ErrInvalidEncoding = errors.New("t_digest: invalid encoding")
+
+// The encoding is the magic and version, a flags byte, the compression, min
+// and max as little-endian float64s, the centroid count as a uvarint, then
+// each centroid's mean as a float64 followed by its weight. Weights are
+// uvarints when integralWeights is set, as they are for digests built with
+// Add alone, and float64s otherwise.
+
+// MarshalBinary returns the binary encoding of the digest.
+// It implements encoding.BinaryMarshaler.
+func (d *TDigest) MarshalBinary() ([]byte, error) {
+	d.compress()
+	var flags byte = integralWeights
+	for _, c := range d.centroids {
+		if c.weight != math.Trunc(c.weight) || c.weight > 1<<53 {
+			flags &^= integralWeights
+			break
+		}
+	}
+
+	data := make([]byte, 0, len(digestMagic)+2+24+binary.MaxVarintLen64+16*len(d.centroids))
+	data = append(data, digestMagic...)
+	data = append(data, digestVersion, flags)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(d.compression))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(d.min))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(d.max))
+	data = binary.AppendUvarint(data, uint64(len(d.centroids)))
+	for _, c := range d.centroids {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(c.mean))
+		if flags&integralWeights != 0 {
+			data = binary.AppendUvarint(data, uint64(c.weight))
+		} else {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(c.weight))
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the digest with the decoded data,
+// including its compression.
+// It implements encoding.BinaryUnmarshaler.
+// On error the digest is left unchanged.
+func (d *TDigest) UnmarshalBinary(data []byte) error {
+	if len(data) < len(digestMagic)+2+24 || string(data[:len(digestMagic)]) != digestMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if v := data[len(digestMagic)]; v != digestVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	flags := data[len(digestMagic)+1]
+	if flags&^integralWeights != 0 {
+		return fmt.Errorf("%w: unknown flags %#x", ErrInvalidEncoding, flags)
+	}
+	data = data[len(digestMagic)+2:]
+
+	compression := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	lo := math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+	hi := math.Float64frombits(binary.LittleEndian.Uint64(data[16:]))
+	if !(compression > 0) || math.IsInf(compression, 0) {
+		return fmt.Errorf("%w: bad compression %v", ErrInvalidEncoding, compression)
+	}
+	data = data[24:]
+
+	n, k := binary.Uvarint(data)
+	// Every centroid takes at least nine bytes, which bounds the allocation
+	if k <= 0 || n > uint64(len(data)-k)/9 {
+		return fmt.Errorf("%w: bad centroid count", ErrInvalidEncoding)
+	}
+	data = data[k:]
+
+	centroids := make([]centroid, n)
+	count := 0.0
+	for i := range centroids {
+		if len(data) < 8 {
+			return fmt.Errorf("%w: truncated centroids", ErrInvalidEncoding)
+		}
+		c := centroid{mean: math.Float64frombits(binary.LittleEndian.Uint64(data))}
+		data = data[8:]
+		if flags&integralWeights != 0 {
+			w, k := binary.Uvarint(data)
+			if k <= 0 {
+				return fmt.Errorf("%w: truncated centroids", ErrInvalidEncoding)
+			}
+			c.weight = float64(w)
+			data = data[k:]
+		} else {
+			if len(data) < 8 {
+				return fmt.Errorf("%w: truncated centroids", ErrInvalidEncoding)
+			}
+			c.weight = math.Float64frombits(binary.LittleEndian.Uint64(data))
+			data = data[8:]
+		}
+		if !(c.weight > 0) || math.IsNaN(c.mean) || c.mean < lo || c.mean > hi ||
+			(i > 0 && c.mean < centroids[i-1].mean) {
+			return fmt.Errorf("%w: bad centroid %d", ErrInvalidEncoding, i)
+		}
+		centroids[i] = c
+		count += c.weight
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+
+	d.Clear()
+	d.compression = compression
+	if n > 0 {
+		d.centroids, d.count, d.min, d.max = centroids, count, lo, hi
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package t_digest
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTDigestBinaryRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, weighted := range []bool{false, true} {
+		d := NewTDigest(50)
+		for i := 0; i < 10000; i++ {
+			if weighted {
+				d.AddWeighted(rng.NormFloat64(), rng.Float64()+0.5)
+			} else {
+				d.Add(rng.NormFloat64())
+			}
+		}
+
+		data, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+		decoded := NewTDigest(10)
+		decoded.Add(1)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary: %v", err)
+		}
+
+		if decoded.Compression() != 50 || decoded.Len() != d.Len() {
+			t.Errorf("weighted=%v: expected compression 50 and %d centroids, got %v and %d",
+				weighted, d.Len(), decoded.Compression(), decoded.Len())
+		}
+		if math.Abs(decoded.Count()-d.Count()) > 1e-6*d.Count() {
+			t.Errorf("weighted=%v: expected count %v, got %v", weighted, d.Count(), decoded.Count())
+		}
+		for _, q := range []float64{0, 0.01, 0.5, 0.99, 1} {
+			if math.Abs(decoded.Quantile(q)-d.Quantile(q)) > 1e-9 {
+				t.Errorf("weighted=%v: Quantile(%v): expected %v, got %v", weighted, q, d.Quantile(q), decoded.Quantile(q))
+			}
+		}
+	}
+}
+
+func TestTDigestBinaryEmpty(t *testing.T) {
+	data, _ := NewTDigest(100).MarshalBinary()
+	decoded := NewTDigest(100)
+	decoded.Add(1)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if decoded.Count() != 0 || !math.IsNaN(decoded.Min()) {
+		t.Errorf("Expected empty digest, got count %v", decoded.Count())
+	}
+}
+
+func TestTDigestUnmarshalInvalid(t *testing.T) {
+	d := NewTDigest(100)
+	d.Add(1)
+	d.Add(2)
+	data, _ := d.MarshalBinary()
+	header := len(digestMagic) + 2
+
+	// Centroids out of order
+	swapped := append([]byte(nil), data...)
+	copy(swapped[header+25:], data[header+25+9:header+25+18])
+	copy(swapped[header+25+9:], data[header+25:header+25+9])
+
+	cases := map[string][]byte{
+		"empty":       nil,
+		"bad magic":   append([]byte("XXXXX"), data[5:]...),
+		"version":     append(append([]byte(digestMagic), 9), data[6:]...),
+		"flags":       append(append([]byte(digestMagic), digestVersion, 4), data[7:]...),
+		"compression": append(append(append([]byte(nil), data[:header]...), make([]byte, 8)...), data[header+8:]...),
+		"count":       append(append([]byte(nil), data[:header+24]...), 0xff, 0xff, 0x03),
+		"truncated":   data[:len(data)-1],
+		"trailing":    append(append([]byte(nil), data...), 0),
+		"order":       swapped,
+	}
+	for name, input := range cases {
+		decoded := NewTDigest(10)
+		decoded.Add(5)
+		if err := decoded.UnmarshalBinary(input); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: expected ErrInvalidEncoding, got %v", name, err)
+		}
+		if decoded.Compression() != 10 || decoded.Count() != 1 {
+			t.Errorf("%s: expected digest to be unchanged", name)
+		}
+	}
+}
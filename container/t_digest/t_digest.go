@@ -0,0 +1,239 @@
+// Package t_digest provides a t-digest for estimating quantiles of a stream
+// of values in bounded memory.
+package t_digest
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultCompression is used when NewTDigest is given a non-positive compression.
+const defaultCompression = 100
+
+// centroid summarizes weight values around mean.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest estimates quantiles and the CDF of a stream of values. Values are
+// clustered into centroids whose size is bounded by the k1 scale function:
+// clusters near the median hold many values while those near the tails hold
+// few, so extreme quantiles such as p99 and p99.9 stay accurate.
+//
+// Memory is O(compression) regardless of how many values are added; with the
+// default compression of 100 a digest holds a few hundred centroids at most.
+// Added values are buffered and merged into the centroids in batches, so
+// queries may compress the buffer first.
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged centroids, sorted by mean
+	buffer      []centroid // values added since the last merge
+	count       float64    // total weight, merged and buffered
+	min         float64
+	max         float64
+}
+
+// NewTDigest creates an empty TDigest. Larger compression values trade
+// memory for accuracy; a non-positive value selects the default of 100.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression > 0) {
+		compression = defaultCompression
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Compression returns the compression parameter of the digest.
+func (d *TDigest) Compression() float64 {
+	return d.compression
+}
+
+// Count returns the total weight of the values added.
+func (d *TDigest) Count() float64 {
+	return d.count
+}
+
+// Min returns the smallest value added, or NaN if the digest is empty.
+func (d *TDigest) Min() float64 {
+	if d.count == 0 {
+		return math.NaN()
+	}
+	return d.min
+}
+
+// Max returns the largest value added, or NaN if the digest is empty.
+func (d *TDigest) Max() float64 {
+	if d.count == 0 {
+		return math.NaN()
+	}
+	return d.max
+}
+
+// Add records the value x.
+func (d *TDigest) Add(x float64) {
+	d.AddWeighted(x, 1)
+}
+
+// AddWeighted records the value x with the given weight, as if x had been
+// added weight times. NaN values and non-positive weights are ignored.
+func (d *TDigest) AddWeighted(x, weight float64) {
+	if math.IsNaN(x) || !(weight > 0) {
+		return
+	}
+	d.buffer = append(d.buffer, centroid{mean: x, weight: weight})
+	d.count += weight
+	d.min = min(d.min, x)
+	d.max = max(d.max, x)
+	if len(d.buffer) >= d.bufferSize() {
+		d.compress()
+	}
+}
+
+// bufferSize returns the number of values buffered before a merge.
+func (d *TDigest) bufferSize() int {
+	return 5 * int(math.Ceil(d.compression))
+}
+
+// compress merges the buffered values into the centroids. Neighboring
+// centroids are combined while the combined cluster stays within one unit
+// of the scale function k.
+func (d *TDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	cur := all[0]
+	before := 0.0 // weight of the centroids before cur
+	limit := d.kInverse(d.k(0) + 1)
+	for _, next := range all[1:] {
+		if (before+cur.weight+next.weight)/d.count <= limit {
+			cur.weight += next.weight
+			cur.mean += (next.mean - cur.mean) * next.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		limit = d.kInverse(d.k(before/d.count) + 1)
+		cur = next
+	}
+	d.centroids = append(merged, cur)
+	d.buffer = d.buffer[:0]
+}
+
+// k is the k1 scale function, mapping quantile q to the index of its cluster.
+func (d *TDigest) k(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse is the inverse of k, returning 1 beyond the last cluster.
+func (d *TDigest) kInverse(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(2*math.Pi*k/d.compression) + 1) / 2
+}
+
+// Quantile returns an estimate of the value at quantile q, the value below
+// which a fraction q of the weight lies. q is clamped to [0, 1], and NaN is
+// returned if the digest is empty.
+func (d *TDigest) Quantile(q float64) float64 {
+	d.compress()
+	if d.count == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return d.min
+	}
+	if q >= 1 {
+		return d.max
+	}
+
+	// Each centroid is taken to sit at the middle of its weight, with values
+	// interpolated linearly between neighbors and out to min and max.
+	target := q * d.count
+	prevMean, prevPos := d.min, 0.0
+	pos := 0.0
+	for _, c := range d.centroids {
+		center := pos + c.weight/2
+		if target < center {
+			return interpolate(target, prevPos, center, prevMean, c.mean)
+		}
+		prevMean, prevPos = c.mean, center
+		pos += c.weight
+	}
+	return interpolate(target, prevPos, d.count, prevMean, d.max)
+}
+
+// CDF returns an estimate of the fraction of the weight at or below x.
+// NaN is returned if the digest is empty.
+func (d *TDigest) CDF(x float64) float64 {
+	d.compress()
+	if d.count == 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < d.min {
+		return 0
+	}
+	if x >= d.max {
+		return 1
+	}
+
+	prevMean, prevPos := d.min, 0.0
+	pos := 0.0
+	for _, c := range d.centroids {
+		center := pos + c.weight/2
+		if x < c.mean {
+			return interpolate(x, prevMean, c.mean, prevPos, center) / d.count
+		}
+		prevMean, prevPos = c.mean, center
+		pos += c.weight
+	}
+	return interpolate(x, prevMean, d.max, prevPos, d.count) / d.count
+}
+
+// interpolate maps x from [x0, x1] linearly onto [y0, y1].
+func interpolate(x, x0, x1, y0, y1 float64) float64 {
+	if x1 <= x0 {
+		return y0
+	}
+	return y0 + (x-x0)/(x1-x0)*(y1-y0)
+}
+
+// Merge adds the values summarized by other to d. other is compressed but
+// otherwise left unchanged. The digests may have different compressions;
+// d keeps its own.
+func (d *TDigest) Merge(other *TDigest) {
+	other.compress()
+	if other.count == 0 {
+		return
+	}
+	d.buffer = append(d.buffer, other.centroids...)
+	d.count += other.count
+	d.min = min(d.min, other.min)
+	d.max = max(d.max, other.max)
+	d.compress()
+}
+
+// Len returns the number of centroids held after compressing the buffer.
+func (d *TDigest) Len() int {
+	d.compress()
+	return len(d.centroids)
+}
+
+// Clear removes all values from the digest.
+func (d *TDigest) Clear() {
+	d.centroids = nil
+	d.buffer = nil
+	d.count = 0
+	d.min = math.Inf(1)
+	d.max = math.Inf(-1)
+}
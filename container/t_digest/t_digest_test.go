@@ -0,0 +1,162 @@
+package t_digest
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// exactQuantile returns the value at quantile q of the sorted values.
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+// rankError returns how far, in quantile units, the estimate of quantile q
+// lies from q among the sorted values.
+func rankError(sorted []float64, q, estimate float64) float64 {
+	rank := float64(sort.SearchFloat64s(sorted, estimate)) / float64(len(sorted))
+	return math.Abs(rank - q)
+}
+
+func TestTDigestEmpty(t *testing.T) {
+	d := NewTDigest(0)
+	if d.Compression() != defaultCompression {
+		t.Errorf("Expected default compression, got %v", d.Compression())
+	}
+	if !math.IsNaN(d.Quantile(0.5)) || !math.IsNaN(d.CDF(1)) || !math.IsNaN(d.Min()) || !math.IsNaN(d.Max()) {
+		t.Error("Expected NaN from an empty digest")
+	}
+
+	d.Add(math.NaN())
+	d.AddWeighted(1, 0)
+	if d.Count() != 0 {
+		t.Errorf("Expected NaN values and zero weights to be ignored, got count %v", d.Count())
+	}
+}
+
+func TestTDigestSingleValue(t *testing.T) {
+	d := NewTDigest(100)
+	d.Add(42)
+	for _, q := range []float64{0, 0.5, 1} {
+		if got := d.Quantile(q); got != 42 {
+			t.Errorf("Quantile(%v): expected 42, got %v", q, got)
+		}
+	}
+	if d.CDF(41) != 0 || d.CDF(42) != 1 {
+		t.Errorf("Expected CDF 0 below and 1 at the value, got %v and %v", d.CDF(41), d.CDF(42))
+	}
+}
+
+func TestTDigestAccuracy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	distributions := map[string]func() float64{
+		"uniform":     rng.Float64,
+		"normal":      rng.NormFloat64,
+		"exponential": rng.ExpFloat64,
+	}
+
+	for name, next := range distributions {
+		d := NewTDigest(100)
+		values := make([]float64, 100000)
+		for i := range values {
+			values[i] = next()
+			d.Add(values[i])
+		}
+		sort.Float64s(values)
+
+		if d.Count() != float64(len(values)) {
+			t.Errorf("%s: expected count %d, got %v", name, len(values), d.Count())
+		}
+		if d.Min() != values[0] || d.Max() != values[len(values)-1] {
+			t.Errorf("%s: expected min %v and max %v, got %v and %v", name, values[0], values[len(values)-1], d.Min(), d.Max())
+		}
+		if d.Len() > 2*int(d.Compression()) {
+			t.Errorf("%s: expected at most %d centroids, got %d", name, 2*int(d.Compression()), d.Len())
+		}
+
+		for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+			// The k1 scale function bounds the error by roughly q(1-q)
+			tolerance := max(0.01*math.Sqrt(q*(1-q)), 0.0005)
+			if err := rankError(values, q, d.Quantile(q)); err > tolerance {
+				t.Errorf("%s: Quantile(%v) = %v has rank error %v, want <= %v", name, q, d.Quantile(q), err, tolerance)
+			}
+			x := exactQuantile(values, q)
+			if err := math.Abs(d.CDF(x) - q); err > tolerance {
+				t.Errorf("%s: CDF(%v) = %v, want %v within %v", name, x, d.CDF(x), q, tolerance)
+			}
+		}
+	}
+}
+
+func TestTDigestMonotonic(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	d := NewTDigest(50)
+	for i := 0; i < 10000; i++ {
+		d.Add(rng.NormFloat64())
+	}
+
+	prevQ, prevC := math.Inf(-1), 0.0
+	for i := 0; i <= 1000; i++ {
+		q := d.Quantile(float64(i) / 1000)
+		if q < prevQ {
+			t.Fatalf("Quantile is not monotonic at %v: %v < %v", float64(i)/1000, q, prevQ)
+		}
+		prevQ = q
+
+		c := d.CDF(-4 + 8*float64(i)/1000)
+		if c < prevC {
+			t.Fatalf("CDF is not monotonic at %v: %v < %v", -4+8*float64(i)/1000, c, prevC)
+		}
+		prevC = c
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	merged := NewTDigest(100)
+	var values []float64
+	for shard := 0; shard < 8; shard++ {
+		d := NewTDigest(100)
+		for i := 0; i < 10000; i++ {
+			v := rng.NormFloat64() + float64(shard)
+			values = append(values, v)
+			d.Add(v)
+		}
+		merged.Merge(d)
+		if d.Count() != 10000 {
+			t.Errorf("Expected merged digest to be unchanged, got count %v", d.Count())
+		}
+	}
+	merged.Merge(NewTDigest(100))
+	sort.Float64s(values)
+
+	if merged.Count() != float64(len(values)) {
+		t.Errorf("Expected count %d, got %v", len(values), merged.Count())
+	}
+	if merged.Min() != values[0] || merged.Max() != values[len(values)-1] {
+		t.Errorf("Expected min %v and max %v, got %v and %v", values[0], values[len(values)-1], merged.Min(), merged.Max())
+	}
+	for _, q := range []float64{0.01, 0.5, 0.99} {
+		if err := rankError(values, q, merged.Quantile(q)); err > 0.01 {
+			t.Errorf("Quantile(%v) has rank error %v after merge", q, err)
+		}
+	}
+}
+
+func TestTDigestWeighted(t *testing.T) {
+	d := NewTDigest(100)
+	d.AddWeighted(1, 3)
+	d.AddWeighted(10, 1)
+	if d.Count() != 4 {
+		t.Errorf("Expected count 4, got %v", d.Count())
+	}
+	if got := d.CDF(5); math.Abs(got-0.75) > 0.2 {
+		t.Errorf("Expected CDF(5) near 0.75, got %v", got)
+	}
+
+	d.Clear()
+	if d.Count() != 0 || !math.IsNaN(d.Quantile(0.5)) {
+		t.Errorf("Expected empty digest after Clear, got count %v", d.Count())
+	}
+}
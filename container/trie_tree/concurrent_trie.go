@@ -0,0 +1,290 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements the Interface as a lock-free copy-on-write Trie.
+
+package trie_tree
+
+import (
+	"sort"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// cowNode is an immutable node of a ConcurrentTrie. Nodes are never modified
+// once they are reachable from the published root; writers copy the nodes on
+// the path they change instead.
+type cowNode struct {
+	children map[rune]*cowNode // children nodes mapped by character
+	count    int               // number of words ending at or below this node
+	isEnd    bool              // true if this node represents the end of a word
+}
+
+// clone returns a shallow copy of n with its own children map.
+// A nil n clones to an empty node.
+func (n *cowNode) clone() *cowNode {
+	c := &cowNode{children: make(map[rune]*cowNode)}
+	if n != nil {
+		for char, child := range n.children {
+			c.children[char] = child
+		}
+		c.count = n.count
+		c.isEnd = n.isEnd
+	}
+	return c
+}
+
+// sortedChars returns the characters of the node's children in ascending order.
+func (n *cowNode) sortedChars() []rune {
+	chars := make([]rune, 0, len(n.children))
+	for char := range n.children {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		return chars[i] < chars[j]
+	})
+	return chars
+}
+
+// ConcurrentTrie implements the Interface as a Trie that is safe for
+// concurrent use by multiple goroutines without a global lock.
+//
+// Readers load the current root atomically and walk an immutable snapshot, so
+// they never block and always observe a consistent set of words, even while
+// writers run. Writers copy the nodes along the path of the word they change
+// and publish the new root with a compare-and-swap, retrying if another writer
+// published first. Writes to disjoint subtrees only contend on the final swap.
+//
+// This suits read-heavy workloads, such as many goroutines looking up words
+// while a background job refreshes the dictionary. Each write allocates
+// O(len(word)) nodes and copies their child maps.
+type ConcurrentTrie struct {
+	root atomic.Pointer[cowNode]
+}
+
+// NewConcurrentTrie creates a new ConcurrentTrie.
+func NewConcurrentTrie() *ConcurrentTrie {
+	t := &ConcurrentTrie{}
+	t.root.Store(&cowNode{children: make(map[rune]*cowNode)})
+	return t
+}
+
+// Insert adds a word to the trie.
+func (t *ConcurrentTrie) Insert(word string) {
+	if word == "" {
+		return
+	}
+	for {
+		old := t.root.Load()
+		root, added := cowInsert(old, word)
+		if !added || t.root.CompareAndSwap(old, root) {
+			return
+		}
+	}
+}
+
+// cowInsert returns a copy of n with word inserted below it, and whether the
+// word was newly added. n is left unchanged; when the word already exists n
+// itself is returned.
+func cowInsert(n *cowNode, word string) (*cowNode, bool) {
+	if word == "" {
+		if n != nil && n.isEnd {
+			return n, false
+		}
+		c := n.clone()
+		c.isEnd = true
+		c.count++
+		return c, true
+	}
+
+	char, width := utf8.DecodeRuneInString(word)
+	var child *cowNode
+	if n != nil {
+		child = n.children[char]
+	}
+	newChild, added := cowInsert(child, word[width:])
+	if !added {
+		return n, false
+	}
+	c := n.clone()
+	c.children[char] = newChild
+	c.count++
+	return c, true
+}
+
+// Search returns true if the word exists in the trie.
+func (t *ConcurrentTrie) Search(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	node := cowFind(t.root.Load(), word)
+	return node != nil && node.isEnd
+}
+
+// StartsWith returns true if there are any words in the trie that start with the given prefix.
+func (t *ConcurrentTrie) StartsWith(prefix string) bool {
+	root := t.root.Load()
+	if prefix == "" {
+		return root.count > 0
+	}
+
+	return cowFind(root, prefix) != nil
+}
+
+// Delete removes a word from the trie and returns true if the word was found and removed.
+func (t *ConcurrentTrie) Delete(word string) bool {
+	if word == "" {
+		return false
+	}
+	for {
+		old := t.root.Load()
+		root, removed := cowDelete(old, word)
+		if !removed {
+			return false
+		}
+		if root == nil {
+			root = &cowNode{children: make(map[rune]*cowNode)}
+		}
+		if t.root.CompareAndSwap(old, root) {
+			return true
+		}
+	}
+}
+
+// cowDelete returns a copy of n with word removed below it, and whether the
+// word was found. Nodes that no longer lead to any word are dropped, so the
+// result is nil when n becomes empty. n is left unchanged.
+func cowDelete(n *cowNode, word string) (*cowNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	if word == "" {
+		if !n.isEnd {
+			return n, false
+		}
+		if n.count == 1 {
+			return nil, true
+		}
+		c := n.clone()
+		c.isEnd = false
+		c.count--
+		return c, true
+	}
+
+	char, width := utf8.DecodeRuneInString(word)
+	newChild, removed := cowDelete(n.children[char], word[width:])
+	if !removed {
+		return n, false
+	}
+	if n.count == 1 {
+		return nil, true
+	}
+	c := n.clone()
+	if newChild == nil {
+		delete(c.children, char)
+	} else {
+		c.children[char] = newChild
+	}
+	c.count--
+	return c, true
+}
+
+// DeletePrefix atomically removes every word that starts with the given
+// prefix and returns how many were removed. Concurrent readers observe either
+// all of the words or none of them. An empty prefix removes all words.
+func (t *ConcurrentTrie) DeletePrefix(prefix string) int {
+	for {
+		old := t.root.Load()
+		root, removed := cowDeletePrefix(old, prefix)
+		if removed == 0 {
+			return 0
+		}
+		if root == nil {
+			root = &cowNode{children: make(map[rune]*cowNode)}
+		}
+		if t.root.CompareAndSwap(old, root) {
+			return removed
+		}
+	}
+}
+
+// cowDeletePrefix returns a copy of n without the subtree under prefix, and
+// the number of words removed. Like cowDelete, it returns nil when n becomes
+// empty and leaves n unchanged.
+func cowDeletePrefix(n *cowNode, prefix string) (*cowNode, int) {
+	if n == nil {
+		return nil, 0
+	}
+	if prefix == "" {
+		return nil, n.count
+	}
+
+	char, width := utf8.DecodeRuneInString(prefix)
+	newChild, removed := cowDeletePrefix(n.children[char], prefix[width:])
+	if removed == 0 {
+		return n, 0
+	}
+	if n.count == removed {
+		return nil, removed
+	}
+	c := n.clone()
+	if newChild == nil {
+		delete(c.children, char)
+	} else {
+		c.children[char] = newChild
+	}
+	c.count -= removed
+	return c, removed
+}
+
+// Len returns the number of words stored in the trie.
+func (t *ConcurrentTrie) Len() int {
+	return t.root.Load().count
+}
+
+// Clear removes all words from the trie.
+func (t *ConcurrentTrie) Clear() {
+	t.root.Store(&cowNode{children: make(map[rune]*cowNode)})
+}
+
+// GetAllWords returns a slice of all words stored in the trie in lexicographical order.
+func (t *ConcurrentTrie) GetAllWords() []string {
+	var words []string
+	cowCollect(t.root.Load(), nil, &words)
+	return words
+}
+
+// GetWordsWithPrefix returns a slice of all words that start with the given prefix
+// in lexicographical order.
+func (t *ConcurrentTrie) GetWordsWithPrefix(prefix string) []string {
+	var words []string
+	node := cowFind(t.root.Load(), prefix)
+	if node == nil {
+		return words // Return empty slice if prefix doesn't exist
+	}
+	cowCollect(node, []byte(prefix), &words)
+	return words
+}
+
+// cowFind traverses the trie below n to find the node representing the given string.
+// Returns nil if the string is not found.
+func cowFind(n *cowNode, str string) *cowNode {
+	for _, char := range str {
+		child, exists := n.children[char]
+		if !exists {
+			return nil
+		}
+		n = child
+	}
+	return n
+}
+
+// cowCollect performs a depth-first search to collect all words from a given node.
+// path holds the key of node and is extended in place for the children.
+func cowCollect(n *cowNode, path []byte, words *[]string) {
+	if n.isEnd {
+		*words = append(*words, string(path))
+	}
+	for _, char := range n.sortedChars() {
+		cowCollect(n.children[char], utf8.AppendRune(path, char), words)
+	}
+}
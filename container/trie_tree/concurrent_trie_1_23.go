@@ -0,0 +1,45 @@
+//go:build go1.23
+// +build go1.23
+
+// Package trie_tree provides go1.23-specific methods for ConcurrentTrie.
+// This file adds iter.Seq related methods for Interface.
+
+package trie_tree
+
+import (
+	"iter"
+	"unicode/utf8"
+)
+
+// WordSeq returns an iterator for all words in the trie in lexicographical order (go1.23).
+// The iterator walks the snapshot current when iteration starts, so concurrent
+// writes are not observed.
+func (t *ConcurrentTrie) WordSeq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		cowRange(t.root.Load(), nil, yield)
+	}
+}
+
+// PrefixSeq returns an iterator for all words that start with the given prefix
+// in lexicographical order (go1.23). Like WordSeq, it walks a snapshot.
+func (t *ConcurrentTrie) PrefixSeq(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if node := cowFind(t.root.Load(), prefix); node != nil {
+			cowRange(node, []byte(prefix), yield)
+		}
+	}
+}
+
+// cowRange yields the words below n in lexicographical order and returns
+// false if yield stopped the iteration.
+func cowRange(n *cowNode, path []byte, yield func(string) bool) bool {
+	if n.isEnd && !yield(string(path)) {
+		return false
+	}
+	for _, char := range n.sortedChars() {
+		if !cowRange(n.children[char], utf8.AppendRune(path, char), yield) {
+			return false
+		}
+	}
+	return true
+}
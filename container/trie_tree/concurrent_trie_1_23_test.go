@@ -0,0 +1,30 @@
+//go:build go1.23
+// +build go1.23
+
+package trie_tree
+
+import (
+	"testing"
+)
+
+func TestConcurrentTrieSeq(t *testing.T) {
+	trie := NewConcurrentTrie()
+	for _, w := range []string{"tea", "ten", "to"} {
+		trie.Insert(w)
+	}
+	var got []string
+	for w := range trie.PrefixSeq("te") {
+		// Writes during iteration do not affect the snapshot
+		trie.Insert("tee")
+		got = append(got, w)
+	}
+	if len(got) != 2 || got[0] != "tea" || got[1] != "ten" {
+		t.Errorf("Expected [tea ten], got %v", got)
+	}
+	for w := range trie.WordSeq() {
+		if w != "tea" {
+			t.Errorf("Expected first word 'tea', got %q", w)
+		}
+		break
+	}
+}
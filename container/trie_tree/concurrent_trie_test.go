@@ -0,0 +1,170 @@
+package trie_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentTrieBasic(t *testing.T) {
+	trie := NewConcurrentTrie()
+
+	if trie.Len() != 0 || trie.StartsWith("") {
+		t.Errorf("Expected empty trie, got length %d", trie.Len())
+	}
+
+	words := []string{"hello", "help", "he", "hero", "world", "café"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	trie.Insert("help")
+	trie.Insert("")
+
+	if trie.Len() != len(words) {
+		t.Errorf("Expected length %d, got %d", len(words), trie.Len())
+	}
+	for _, w := range words {
+		if !trie.Search(w) {
+			t.Errorf("Expected to find word '%s'", w)
+		}
+	}
+	if trie.Search("hel") || trie.Search("") {
+		t.Error("Expected prefixes and the empty word not to be found")
+	}
+	if !trie.StartsWith("hel") || !trie.StartsWith("caf") || trie.StartsWith("hi") {
+		t.Error("Unexpected StartsWith result")
+	}
+
+	want := []string{"he", "hello", "help", "hero"}
+	if got := trie.GetWordsWithPrefix("he"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if !trie.Delete("he") || trie.Delete("he") || trie.Delete("hel") {
+		t.Error("Unexpected Delete result")
+	}
+	if !trie.Search("hello") || trie.Len() != len(words)-1 {
+		t.Error("Expected other words to survive deletion")
+	}
+
+	// Deleting the last word below a prefix prunes it
+	trie.Delete("world")
+	if trie.StartsWith("w") {
+		t.Error("Expected 'w' branch to be pruned")
+	}
+
+	trie.Clear()
+	if trie.Len() != 0 || len(trie.GetAllWords()) != 0 {
+		t.Error("Expected empty trie after Clear")
+	}
+}
+
+func TestConcurrentTrieSnapshot(t *testing.T) {
+	trie := NewConcurrentTrie()
+	trie.Insert("a")
+	trie.Insert("ab")
+
+	// A node reachable from an old root is never modified
+	old := trie.root.Load()
+	trie.Insert("abc")
+	trie.Delete("a")
+	if old.count != 2 || !old.children['a'].isEnd || old.children['a'].children['b'].children['c'] != nil {
+		t.Error("Expected published nodes to be immutable")
+	}
+
+	// Deleting every word resets the root
+	trie.Delete("ab")
+	trie.Delete("abc")
+	if trie.Len() != 0 || len(trie.root.Load().children) != 0 {
+		t.Error("Expected empty root after deleting every word")
+	}
+}
+
+func TestConcurrentTrieDeletePrefix(t *testing.T) {
+	trie := NewConcurrentTrie()
+	for _, w := range []string{"user:1", "user:12", "user:2", "users"} {
+		trie.Insert(w)
+	}
+
+	if got := trie.DeletePrefix("user:1"); got != 2 {
+		t.Errorf("Expected 2 words removed, got %d", got)
+	}
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, []string{"user:2", "users"}) || trie.Len() != 2 {
+		t.Errorf("Expected [user:2 users], got %v", got)
+	}
+	if trie.DeletePrefix("admin") != 0 {
+		t.Error("Expected 0 for a missing prefix")
+	}
+	if got := trie.DeletePrefix(""); got != 2 || trie.Len() != 0 || trie.StartsWith("u") {
+		t.Errorf("Expected empty prefix to remove all words, got %d", got)
+	}
+}
+
+func TestConcurrentTrieRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	trie := NewConcurrentTrie()
+	ref := NewTrie()
+
+	for i := 0; i < 2000; i++ {
+		w := fmt.Sprintf("%x", rng.Intn(512))
+		if rng.Intn(3) == 0 {
+			if got, want := trie.Delete(w), ref.Delete(w); got != want {
+				t.Fatalf("Delete(%q) = %v, want %v", w, got, want)
+			}
+		} else {
+			trie.Insert(w)
+			ref.Insert(w)
+		}
+	}
+	if got, want := trie.GetAllWords(), ref.GetAllWords(); !reflect.DeepEqual(got, want) || trie.Len() != ref.Len() {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestConcurrentTrieParallel(t *testing.T) {
+	trie := NewConcurrentTrie()
+	const writers, perWriter = 8, 200
+
+	var wg sync.WaitGroup
+	for g := 0; g < writers; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				trie.Insert(fmt.Sprintf("w%d-%d", g, i))
+			}
+			for i := 0; i < perWriter; i += 2 {
+				trie.Delete(fmt.Sprintf("w%d-%d", g, i))
+			}
+		}(g)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				trie.Search(fmt.Sprintf("w0-%d", i))
+				trie.GetWordsWithPrefix("w1-")
+			}
+		}()
+	}
+	wg.Wait()
+
+	var want []string
+	for g := 0; g < writers; g++ {
+		for i := 1; i < perWriter; i += 2 {
+			want = append(want, fmt.Sprintf("w%d-%d", g, i))
+		}
+	}
+	sort.Strings(want)
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, want) || trie.Len() != len(want) {
+		t.Errorf("Expected %d words, got %d", len(want), trie.Len())
+	}
+}
+
+func TestConcurrentTrieInterfaceCompliance(t *testing.T) {
+	var _ Interface = NewConcurrentTrie()
+}
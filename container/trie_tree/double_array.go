@@ -0,0 +1,294 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements an immutable double-array trie built from a Trie.
+
+package trie_tree
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// doubleArrayMagic identifies the binary encoding of a DoubleArrayTrie,
+// followed by a version byte.
+const (
+	doubleArrayMagic   = "BRDATR"
+	doubleArrayVersion = 1
+)
+
+// endCode is the transition code that marks the end of a word; byte c is
+// encoded as code c+1.
+const endCode = 0
+
+// DoubleArrayTrie is an immutable trie stored in two flat int32 arrays.
+// A transition from state s on byte c leads to state t = base[s]+c+1, valid
+// when check[t] == s, so each step is two array reads with no hashing or
+// pointer chasing. The flat layout is cache friendly and serializes
+// directly, which makes it suited to high-throughput matching against a
+// dictionary that changes rarely.
+//
+// Build one from a populated Trie with NewDoubleArrayTrie. Words are matched
+// byte by byte on their UTF-8 encoding. A DoubleArrayTrie is safe for
+// concurrent use.
+type DoubleArrayTrie struct {
+	base      []int32
+	check     []int32 // parent state of each slot, -1 for free slots
+	size      int     // number of words stored
+	normalize func(string) string
+}
+
+// NewDoubleArrayTrie builds a DoubleArrayTrie holding the words of t.
+// Queries are normalized like those of t; later changes to t are not
+// reflected.
+func NewDoubleArrayTrie(t *Trie) *DoubleArrayTrie {
+	var words [][]byte
+	t.rangeWeighted(func(word []byte, _ float64) bool {
+		words = append(words, append([]byte(nil), word...))
+		return true
+	})
+
+	d := &DoubleArrayTrie{
+		base:      []int32{0},
+		check:     []int32{0}, // the root is state 0
+		size:      len(words),
+		normalize: t.normalize,
+	}
+	if len(words) > 0 {
+		b := &doubleArrayBuilder{d: d, nextFree: 1}
+		b.build(0, words, 0)
+	}
+	return d
+}
+
+// doubleArrayBuilder places the states of a DoubleArrayTrie.
+type doubleArrayBuilder struct {
+	d        *DoubleArrayTrie
+	nextFree int // no free slot lies below this index
+}
+
+// build places the children of state s, which represents the first depth
+// bytes shared by words. words are sorted and distinct.
+func (b *doubleArrayBuilder) build(s int32, words [][]byte, depth int) {
+	// Group the words by the code following the shared prefix
+	var codes []int32
+	var groups [][][]byte
+	for i := 0; i < len(words); {
+		code := int32(endCode)
+		if depth < len(words[i]) {
+			code = int32(words[i][depth]) + 1
+		}
+		j := i + 1
+		for j < len(words) && depth < len(words[j]) && int32(words[j][depth])+1 == code {
+			j++
+		}
+		codes = append(codes, code)
+		groups = append(groups, words[i:j])
+		i = j
+	}
+
+	base := b.findBase(codes)
+	b.d.base[s] = base
+	for _, code := range codes {
+		b.d.check[base+code] = s
+	}
+	for i, code := range codes {
+		if code != endCode {
+			b.build(base+code, groups[i], depth+1)
+		}
+	}
+}
+
+// findBase returns the smallest base >= 1 for which every slot base+code is
+// free, growing the arrays as needed.
+func (b *doubleArrayBuilder) findBase(codes []int32) int32 {
+	d := b.d
+	for b.nextFree < len(d.check) && d.check[b.nextFree] >= 0 {
+		b.nextFree++
+	}
+
+	base := max(int32(b.nextFree)-codes[0], 1)
+	for {
+		b.grow(int(base + codes[len(codes)-1]))
+		fits := true
+		for _, code := range codes {
+			if d.check[base+code] >= 0 {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return base
+		}
+		base++
+	}
+}
+
+// grow extends the arrays so that index i is valid.
+func (b *doubleArrayBuilder) grow(i int) {
+	d := b.d
+	for len(d.check) <= i {
+		d.base = append(d.base, 0)
+		d.check = append(d.check, -1)
+	}
+}
+
+// key returns s as stored in the trie, after normalization.
+func (d *DoubleArrayTrie) key(s string) string {
+	if d.normalize == nil {
+		return s
+	}
+	return d.normalize(s)
+}
+
+// next returns the state reached from s with code, or -1 if there is none.
+func (d *DoubleArrayTrie) next(s, code int32) int32 {
+	t := d.base[s] + code
+	if t <= 0 || int(t) >= len(d.check) || d.check[t] != s {
+		return -1
+	}
+	return t
+}
+
+// find returns the state reached by str, or -1 if str is not a prefix of any word.
+func (d *DoubleArrayTrie) find(str string) int32 {
+	s := int32(0)
+	for i := 0; i < len(str) && s >= 0; i++ {
+		s = d.next(s, int32(str[i])+1)
+	}
+	return s
+}
+
+// Len returns the number of words stored in the trie.
+func (d *DoubleArrayTrie) Len() int {
+	return d.size
+}
+
+// Search returns true if the word exists in the trie.
+func (d *DoubleArrayTrie) Search(word string) bool {
+	word = d.key(word)
+	if word == "" {
+		return false
+	}
+
+	s := d.find(word)
+	return s >= 0 && d.next(s, endCode) >= 0
+}
+
+// StartsWith returns true if there are any words in the trie that start with the given prefix.
+func (d *DoubleArrayTrie) StartsWith(prefix string) bool {
+	prefix = d.key(prefix)
+	if prefix == "" {
+		return d.size > 0
+	}
+
+	return d.find(prefix) >= 0
+}
+
+// LongestPrefix returns the longest word in the trie that is a prefix of s,
+// and false if there is none. See Trie.LongestPrefix.
+func (d *DoubleArrayTrie) LongestPrefix(s string) (string, bool) {
+	s = d.key(s)
+	match, found := 0, false
+	state := int32(0)
+	for i := 0; i < len(s); i++ {
+		if state = d.next(state, int32(s[i])+1); state < 0 {
+			break
+		}
+		if d.next(state, endCode) >= 0 {
+			match, found = i+1, true
+		}
+	}
+	return s[:match], found
+}
+
+// GetAllWords returns a slice of all words stored in the trie in lexicographical order.
+func (d *DoubleArrayTrie) GetAllWords() []string {
+	return d.GetWordsWithPrefix("")
+}
+
+// GetWordsWithPrefix returns a slice of all words that start with the given prefix
+// in lexicographical order.
+func (d *DoubleArrayTrie) GetWordsWithPrefix(prefix string) []string {
+	var words []string
+	prefix = d.key(prefix)
+	if s := d.find(prefix); s >= 0 {
+		d.collectWords(s, []byte(prefix), &words)
+	}
+	return words
+}
+
+// collectWords performs a depth-first search to collect all words from state s.
+// path holds the key of s and is extended in place for the children.
+func (d *DoubleArrayTrie) collectWords(s int32, path []byte, words *[]string) {
+	if d.next(s, endCode) >= 0 {
+		*words = append(*words, string(path))
+	}
+	for code := int32(1); code <= 256; code++ {
+		if t := d.next(s, code); t >= 0 {
+			d.collectWords(t, append(path, byte(code-1)), words)
+		}
+	}
+}
+
+// The encoding is the magic and version, the word count and the array
+// length as uvarints, then base and check as little-endian int32s.
+
+// MarshalBinary returns the binary encoding of the trie. The normalizer is
+// not encoded.
+// It implements encoding.BinaryMarshaler.
+func (d *DoubleArrayTrie) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(doubleArrayMagic)+1+2*binary.MaxVarintLen64+8*len(d.base))
+	data = append(data, doubleArrayMagic...)
+	data = append(data, doubleArrayVersion)
+	data = binary.AppendUvarint(data, uint64(d.size))
+	data = binary.AppendUvarint(data, uint64(len(d.base)))
+	for _, v := range d.base {
+		data = binary.LittleEndian.AppendUint32(data, uint32(v))
+	}
+	for _, v := range d.check {
+		data = binary.LittleEndian.AppendUint32(data, uint32(v))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the trie with the decoded data.
+// The normalizer, if any, is kept.
+// It implements encoding.BinaryUnmarshaler.
+// On error the trie is left unchanged.
+func (d *DoubleArrayTrie) UnmarshalBinary(data []byte) error {
+	if len(data) < len(doubleArrayMagic)+1 || string(data[:len(doubleArrayMagic)]) != doubleArrayMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if v := data[len(doubleArrayMagic)]; v != doubleArrayVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	data = data[len(doubleArrayMagic)+1:]
+
+	size, n := binary.Uvarint(data)
+	if n <= 0 {
+		return fmt.Errorf("%w: bad word count", ErrInvalidEncoding)
+	}
+	data = data[n:]
+	length, n := binary.Uvarint(data)
+	if n <= 0 || length == 0 || length > uint64(len(data)-n)/8 || length > 1<<31-1 {
+		return fmt.Errorf("%w: bad array length", ErrInvalidEncoding)
+	}
+	data = data[n:]
+	if uint64(len(data)) != 8*length {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, uint64(len(data))-8*length)
+	}
+	if size > length {
+		return fmt.Errorf("%w: word count out of bounds", ErrInvalidEncoding)
+	}
+
+	base := make([]int32, length)
+	check := make([]int32, length)
+	for i := range base {
+		base[i] = int32(binary.LittleEndian.Uint32(data[4*i:]))
+		check[i] = int32(binary.LittleEndian.Uint32(data[4*(int(length)+i):]))
+		if check[i] < -1 || int64(check[i]) >= int64(length) {
+			return fmt.Errorf("%w: state %d out of bounds", ErrInvalidEncoding, i)
+		}
+	}
+	d.base, d.check, d.size = base, check, int(size)
+	return nil
+}
@@ -0,0 +1,136 @@
+package trie_tree
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDoubleArrayTrieBasic(t *testing.T) {
+	trie := NewTrie()
+	words := []string{"he", "hello", "help", "hero", "world", "café", "日本", "日本語"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	d := NewDoubleArrayTrie(trie)
+
+	if d.Len() != len(words) {
+		t.Errorf("Expected length %d, got %d", len(words), d.Len())
+	}
+	for _, w := range words {
+		if !d.Search(w) {
+			t.Errorf("Expected to find word '%s'", w)
+		}
+	}
+	for _, w := range []string{"", "h", "hel", "heroes", "caf", "日"} {
+		if d.Search(w) {
+			t.Errorf("Expected not to find word '%s'", w)
+		}
+	}
+	if !d.StartsWith("") || !d.StartsWith("hel") || !d.StartsWith("日") || d.StartsWith("hi") {
+		t.Error("Unexpected StartsWith result")
+	}
+	if got, want := d.GetAllWords(), trie.GetAllWords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got, want := d.GetWordsWithPrefix("he"), trie.GetWordsWithPrefix("he"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got, ok := d.LongestPrefix("helpful"); !ok || got != "help" {
+		t.Errorf("Expected longest prefix 'help', got %q, %v", got, ok)
+	}
+	if _, ok := d.LongestPrefix("xyz"); ok {
+		t.Error("Expected no longest prefix for 'xyz'")
+	}
+
+	// Later changes to the source trie are not reflected
+	trie.Insert("new")
+	if d.Search("new") {
+		t.Error("Expected the double-array trie to be immutable")
+	}
+}
+
+func TestDoubleArrayTrieEmpty(t *testing.T) {
+	d := NewDoubleArrayTrie(NewTrie())
+	if d.Len() != 0 || d.StartsWith("") || d.Search("a") || len(d.GetAllWords()) != 0 {
+		t.Error("Expected empty double-array trie")
+	}
+}
+
+func TestDoubleArrayTrieOptions(t *testing.T) {
+	trie := NewTrie(WithCaseFolding(), WithByteMode())
+	trie.Insert("Hello")
+	trie.Insert("\xff\x00")
+	d := NewDoubleArrayTrie(trie)
+
+	if !d.Search("HELLO") || !d.StartsWith("HEL") || !d.Search("\xff\x00") {
+		t.Error("Expected queries to be normalized like the source trie")
+	}
+}
+
+func TestDoubleArrayTrieRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	trie := NewTrie()
+	for i := 0; i < 3000; i++ {
+		trie.Insert(fmt.Sprintf("%x", rng.Int63n(1<<20)))
+	}
+	d := NewDoubleArrayTrie(trie)
+
+	if got, want := d.GetAllWords(), trie.GetAllWords(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %d words, got %d", len(want), len(got))
+	}
+	for i := 0; i < 3000; i++ {
+		w := fmt.Sprintf("%x", rng.Int63n(1<<20))
+		if got, want := d.Search(w), trie.Search(w); got != want {
+			t.Errorf("Search(%q) = %v, want %v", w, got, want)
+		}
+		if got, want := d.StartsWith(w[:2]), trie.StartsWith(w[:2]); got != want {
+			t.Errorf("StartsWith(%q) = %v, want %v", w[:2], got, want)
+		}
+	}
+}
+
+func TestDoubleArrayTrieBinary(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"a", "ab", "abc", "b", "日本"} {
+		trie.Insert(w)
+	}
+	d := NewDoubleArrayTrie(trie)
+
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	decoded := &DoubleArrayTrie{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.GetAllWords(), d.GetAllWords()) || decoded.Len() != d.Len() {
+		t.Errorf("Expected %v, got %v", d.GetAllWords(), decoded.GetAllWords())
+	}
+
+	corrupt := [][]byte{
+		nil,
+		[]byte("BRTRIE\x01"),
+		append([]byte("BRDATR\x02"), data[7:]...),
+		data[:len(data)-1],
+		append(append([]byte(nil), data...), 0),
+	}
+	for i, c := range corrupt {
+		if err := decoded.UnmarshalBinary(c); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("case %d: expected ErrInvalidEncoding, got %v", i, err)
+		}
+	}
+	if decoded.Len() != d.Len() {
+		t.Error("Expected failed decoding to leave the trie unchanged")
+	}
+
+	// Out-of-range states are rejected instead of causing panics on lookup
+	bad := append([]byte(nil), data...)
+	bad[len(bad)-1] = 0x7f
+	if err := decoded.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding for a bad state, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements binary serialization for Trie.
+
+package trie_tree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// trieMagic identifies the binary encoding of a Trie, followed by a version byte.
+const (
+	trieMagic   = "BRTRIE"
+	trieVersion = 1
+)
+
+// maxEncodedWordLen bounds the word length accepted when decoding, so
+// corrupt input cannot trigger huge allocations.
+const maxEncodedWordLen = 1 << 20
+
+// ErrInvalidEncoding is returned when decoding data that is not a valid
+// Trie encoding.
+var ErrInvalidEncoding = errors.New("trie_tree: invalid encoding")
+
+// The encoding is the magic and version, the word count as a uvarint, then
+// every word in lexicographical order, front-coded against the previous
+// word: the shared prefix length and suffix length as uvarints, the suffix
+// bytes, and the weight as a little-endian float64.
+
+// WriteTo writes the binary encoding of the trie to w.
+// It implements io.WriterTo.
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	var scratch [binary.MaxVarintLen64]byte
+	write := func(p []byte) {
+		m, _ := bw.Write(p) // the first error is reported by Flush
+		n += int64(m)
+	}
+	writeUvarint := func(v uint64) {
+		write(scratch[:binary.PutUvarint(scratch[:], v)])
+	}
+
+	write([]byte(trieMagic))
+	write([]byte{trieVersion})
+	writeUvarint(uint64(t.size))
+
+	var prev []byte
+	t.rangeWeighted(func(word []byte, weight float64) bool {
+		shared := commonPrefixLen(string(prev), string(word))
+		writeUvarint(uint64(shared))
+		writeUvarint(uint64(len(word) - shared))
+		write(word[shared:])
+		binary.LittleEndian.PutUint64(scratch[:8], math.Float64bits(weight))
+		write(scratch[:8])
+		prev = append(prev[:0], word...)
+		return true
+	})
+	return n, bw.Flush()
+}
+
+// MarshalBinary returns the binary encoding of the trie.
+// It implements encoding.BinaryMarshaler.
+func (t *Trie) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := t.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFrom replaces the contents of the trie with the encoding read from r.
+// It implements io.ReaderFrom. If r is not an io.ByteReader it is buffered,
+// so bytes past the end of the encoding may be consumed.
+// On error the trie is left unchanged.
+func (t *Trie) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{}
+	if br, ok := r.(byteReader); ok {
+		cr.r = br
+	} else {
+		cr.r = bufio.NewReader(r)
+	}
+
+	decoded, err := decodeTrie(cr, t)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return cr.n, err
+	}
+	t.root, t.size = decoded.root, decoded.size
+	return cr.n, nil
+}
+
+// UnmarshalBinary replaces the contents of the trie with the decoded data.
+// It implements encoding.BinaryUnmarshaler.
+// On error the trie is left unchanged.
+func (t *Trie) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	decoded, err := decodeTrie(&countingReader{r: r}, t)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, r.Len())
+	}
+	t.root, t.size = decoded.root, decoded.size
+	return nil
+}
+
+// decodeTrie decodes a trie from r into a new trie configured like cfg.
+func decodeTrie(r *countingReader, cfg *Trie) (*Trie, error) {
+	header := make([]byte, len(trieMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(trieMagic)]) != trieMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if header[len(trieMagic)] != trieVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, header[len(trieMagic)])
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Trie{root: newTrieNode(), mode: cfg.mode, normalize: cfg.normalize}
+	var word []byte
+	var weightBuf [8]byte
+	for i := uint64(0); i < count; i++ {
+		shared, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		suffix, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if shared > uint64(len(word)) || suffix > maxEncodedWordLen {
+			return nil, fmt.Errorf("%w: word %d out of bounds", ErrInvalidEncoding, i)
+		}
+		word = append(word[:shared], make([]byte, suffix)...)
+		if _, err := io.ReadFull(r, word[shared:]); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, weightBuf[:]); err != nil {
+			return nil, err
+		}
+		if len(word) == 0 {
+			return nil, fmt.Errorf("%w: empty word", ErrInvalidEncoding)
+		}
+		t.InsertWithWeight(string(word), math.Float64frombits(binary.LittleEndian.Uint64(weightBuf[:])))
+	}
+	if uint64(t.size) != count {
+		return nil, fmt.Errorf("%w: duplicate words", ErrInvalidEncoding)
+	}
+	return t, nil
+}
+
+// byteReader is the reader interface needed for decoding.
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r byteReader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+// rangeWeighted calls fn for every word, as UTF-8 bytes, with its weight in
+// lexicographical order. The byte slice is reused between calls.
+// Stops early if fn returns false.
+func (t *Trie) rangeWeighted(fn func(word []byte, weight float64) bool) {
+	var walk func(node *trieNode, path []byte) bool
+	walk = func(node *trieNode, path []byte) bool {
+		if node.isEnd && !fn(path, node.weight) {
+			return false
+		}
+		for _, char := range node.sortedChars() {
+			if !walk(node.children[char], t.mode.appendUnit(path, char)) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(t.root, nil)
+}
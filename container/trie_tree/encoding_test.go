@@ -0,0 +1,97 @@
+package trie_tree
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestTrieBinaryRoundTrip(t *testing.T) {
+	trie := NewTrie()
+	trie.InsertWithWeight("hello", 2.5)
+	trie.InsertWithWeight("help", -1)
+	trie.Insert("he")
+	trie.Insert("日本語")
+	trie.Insert("日本")
+
+	data, err := trie.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	decoded := NewTrie()
+	decoded.Insert("stale")
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.GetAllWords(), trie.GetAllWords()) {
+		t.Errorf("Expected %v, got %v", trie.GetAllWords(), decoded.GetAllWords())
+	}
+	if w, _ := decoded.Weight("hello"); w != 2.5 {
+		t.Errorf("Expected weight 2.5, got %v", w)
+	}
+	if got := decoded.TopK("he", 1); len(got) != 1 || got[0] != "hello" {
+		t.Errorf("Expected TopK to use decoded weights, got %v", got)
+	}
+	if decoded.CountWordsWithPrefix("日") != 2 {
+		t.Errorf("Expected 2 words with prefix '日', got %d", decoded.CountWordsWithPrefix("日"))
+	}
+
+	// WriteTo and ReadFrom report the same byte counts
+	var buf bytes.Buffer
+	n, err := trie.WriteTo(&buf)
+	if err != nil || n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Expected WriteTo to write %d bytes, wrote %d, %v", len(data), n, err)
+	}
+	other := NewTrie()
+	if n, err := other.ReadFrom(io.MultiReader(&buf)); err != nil || n != int64(len(data)) {
+		t.Errorf("Expected ReadFrom to read %d bytes, read %d, %v", len(data), n, err)
+	}
+	if other.Len() != trie.Len() {
+		t.Errorf("Expected %d words, got %d", trie.Len(), other.Len())
+	}
+}
+
+func TestTrieBinaryEmpty(t *testing.T) {
+	data, err := NewTrie().MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	trie := NewTrie()
+	if err := trie.UnmarshalBinary(data); err != nil || trie.Len() != 0 {
+		t.Errorf("Expected empty trie, got %d words, %v", trie.Len(), err)
+	}
+}
+
+func TestTrieBinaryInvalid(t *testing.T) {
+	trie := NewTrie()
+	trie.Insert("abc")
+	trie.Insert("abd")
+	data, _ := trie.MarshalBinary()
+
+	target := NewTrie()
+	target.Insert("keep")
+
+	cases := map[string][]byte{
+		"bad magic": append([]byte("XXTRIE"), data[6:]...),
+		"version":   append(append([]byte(trieMagic), 9), data[7:]...),
+		"truncated": data[:len(data)-3],
+		"trailing":  append(append([]byte(nil), data...), 0),
+	}
+	for name, input := range cases {
+		if err := target.UnmarshalBinary(input); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if err := target.UnmarshalBinary(data[:len(data)-3]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated input, got %v", err)
+	}
+	if err := target.UnmarshalBinary([]byte("XXTRIE\x01\x00")); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding for bad magic, got %v", err)
+	}
+	if !target.Search("keep") || target.Len() != 1 {
+		t.Error("Expected failed decoding to leave the trie unchanged")
+	}
+}
@@ -0,0 +1,66 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements approximate search on Trie by Levenshtein distance.
+
+package trie_tree
+
+// SearchFuzzy returns all words within maxDistance edits (insertions,
+// deletions or substitutions of a rune) of word, in lexicographical order.
+//
+// One row of the Levenshtein DP table is computed per trie node, and a
+// subtree is skipped as soon as every entry of its row exceeds maxDistance,
+// so shared prefixes are only scored once.
+func (t *Trie) SearchFuzzy(word string, maxDistance int) []string {
+	var words []string
+	t.fuzzy(word, maxDistance, func(w string) bool {
+		words = append(words, w)
+		return true
+	})
+	return words
+}
+
+// fuzzy calls fn for every word within maxDistance of word in
+// lexicographical order. Stops early if fn returns false.
+func (t *Trie) fuzzy(word string, maxDistance int, fn func(string) bool) {
+	if maxDistance < 0 {
+		return
+	}
+	target := t.mode.units(t.key(word))
+	row := make([]int, len(target)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for _, char := range t.root.sortedChars() {
+		if !fuzzyWalk(t.mode, t.root.children[char], char, t.mode.appendUnit(nil, char), target, row, maxDistance, fn) {
+			return
+		}
+	}
+}
+
+// fuzzyWalk computes the DP row for node, reached through char with key
+// path, from its parent's row prev. Returns false if fn requested a stop.
+func fuzzyWalk(mode unitMode, node *trieNode, char rune, path []byte, target []rune, prev []int, maxDistance int, fn func(string) bool) bool {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	best := row[0]
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if target[j-1] == char {
+			cost = 0
+		}
+		row[j] = min(row[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		best = min(best, row[j])
+	}
+
+	if node.isEnd && row[len(row)-1] <= maxDistance && !fn(string(path)) {
+		return false
+	}
+	if best > maxDistance {
+		return true
+	}
+	for _, c := range node.sortedChars() {
+		if !fuzzyWalk(mode, node.children[c], c, mode.appendUnit(path, c), target, row, maxDistance, fn) {
+			return false
+		}
+	}
+	return true
+}
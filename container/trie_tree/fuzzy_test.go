@@ -0,0 +1,68 @@
+package trie_tree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// levenshtein is the reference edit distance used to check SearchFuzzy.
+func levenshtein(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	row := make([]int, len(y)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			prev, row[j] = row[j], min(row[j]+1, row[j-1]+1, prev+cost)
+		}
+	}
+	return row[len(y)]
+}
+
+func TestTrieSearchFuzzy(t *testing.T) {
+	trie := NewTrie()
+	words := []string{"book", "books", "cake", "boo", "boon", "cook", "cart", "back", "naïve", "naive"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		word string
+		max  int
+		want []string
+	}{
+		{"book", 0, []string{"book"}},
+		{"book", 1, []string{"boo", "book", "books", "boon", "cook"}},
+		{"bok", 1, []string{"boo", "book"}},
+		{"naive", 1, []string{"naive", "naïve"}},
+		{"xyz", 2, nil},
+		{"book", -1, nil},
+	}
+	for _, tt := range tests {
+		if got := trie.SearchFuzzy(tt.word, tt.max); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchFuzzy(%q, %d) = %v, want %v", tt.word, tt.max, got, tt.want)
+		}
+	}
+
+	// Cross-check against a brute-force scan
+	for _, q := range []string{"", "c", "bak", "carts", "cooks"} {
+		for max := 0; max <= 3; max++ {
+			var want []string
+			for _, w := range trie.GetAllWords() {
+				if levenshtein(q, w) <= max {
+					want = append(want, w)
+				}
+			}
+			if got := trie.SearchFuzzy(q, max); !reflect.DeepEqual(got, want) {
+				t.Errorf("SearchFuzzy(%q, %d) = %v, want %v", q, max, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements GlobSet, a trie of glob patterns with wildcard branches.
+
+package trie_tree
+
+import (
+	"sort"
+)
+
+// globSeparator is the path separator that '*' and '?' never match.
+const globSeparator = '/'
+
+// globLoop describes how a node reached through a wildcard edge consumes input.
+type globLoop uint8
+
+const (
+	loopNone globLoop = iota // literal or '?' node, consumes nothing by itself
+	loopStar                 // reached via '*', loops on any rune except the separator
+	loopAny                  // reached via '**', loops on any rune
+)
+
+// globNode represents a node in the pattern trie.
+type globNode struct {
+	children   map[rune]*globNode // literal edges
+	question   *globNode          // '?' edge: exactly one rune except the separator
+	star       *globNode          // '*' edge: zero or more runes except the separator
+	doubleStar *globNode          // '**' edge: zero or more runes of any kind
+	loop       globLoop           // self-loop kind of this node
+	pattern    string             // the pattern ending here, valid when isEnd is true
+	isEnd      bool               // true if a pattern ends at this node
+}
+
+// newGlobNode creates a new pattern trie node.
+func newGlobNode(loop globLoop) *globNode {
+	return &globNode{
+		children: make(map[rune]*globNode),
+		loop:     loop,
+	}
+}
+
+// empty reports whether the node carries no pattern and has no outgoing edges.
+func (n *globNode) empty() bool {
+	return !n.isEnd && len(n.children) == 0 &&
+		n.question == nil && n.star == nil && n.doubleStar == nil
+}
+
+// globToken is a single parsed element of a glob pattern.
+type globToken struct {
+	kind globLoop // loopStar for '*', loopAny for '**', loopNone otherwise
+	char rune     // literal rune, or '?' when wildcard is true
+	wild bool     // true for '?'
+}
+
+// parseGlob splits a pattern into tokens. A backslash escapes the next rune.
+func parseGlob(pattern string) []globToken {
+	runes := []rune(pattern)
+	tokens := make([]globToken, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			tokens = append(tokens, globToken{char: runes[i]})
+		case c == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			i++
+			tokens = append(tokens, globToken{kind: loopAny})
+		case c == '*':
+			tokens = append(tokens, globToken{kind: loopStar})
+		case c == '?':
+			tokens = append(tokens, globToken{char: c, wild: true})
+		default:
+			tokens = append(tokens, globToken{char: c})
+		}
+	}
+	return tokens
+}
+
+// GlobSet stores many glob patterns in a trie and matches paths against all
+// of them in a single traversal.
+//
+// Supported syntax:
+//   - '*' matches zero or more runes except '/'
+//   - '**' matches zero or more runes, including '/'
+//   - '?' matches exactly one rune except '/'
+//   - '\' escapes the following rune
+//
+// Patterns sharing a prefix share trie nodes, so matching costs
+// O(len(path) * active nodes) rather than O(len(path) * number of patterns).
+type GlobSet struct {
+	root *globNode
+	size int // number of patterns stored
+}
+
+// NewGlobSet creates a new empty GlobSet.
+func NewGlobSet() *GlobSet {
+	return &GlobSet{
+		root: newGlobNode(loopNone),
+	}
+}
+
+// child returns the edge of n for the token, or nil if it does not exist.
+func (n *globNode) child(tok globToken) *globNode {
+	switch {
+	case tok.kind == loopStar:
+		return n.star
+	case tok.kind == loopAny:
+		return n.doubleStar
+	case tok.wild:
+		return n.question
+	default:
+		return n.children[tok.char]
+	}
+}
+
+// Add inserts a pattern into the set.
+// Returns true if the pattern was not already present.
+func (g *GlobSet) Add(pattern string) bool {
+	node := g.root
+	for _, tok := range parseGlob(pattern) {
+		next := node.child(tok)
+		if next == nil {
+			next = newGlobNode(tok.kind)
+			switch {
+			case tok.kind == loopStar:
+				node.star = next
+			case tok.kind == loopAny:
+				node.doubleStar = next
+			case tok.wild:
+				node.question = next
+			default:
+				node.children[tok.char] = next
+			}
+		}
+		node = next
+	}
+
+	if node.isEnd {
+		return false
+	}
+	node.isEnd = true
+	node.pattern = pattern
+	g.size++
+	return true
+}
+
+// Remove deletes a pattern from the set.
+// Returns true if the pattern was found and removed.
+func (g *GlobSet) Remove(pattern string) bool {
+	tokens := parseGlob(pattern)
+	path := make([]*globNode, 0, len(tokens)+1)
+	node := g.root
+	path = append(path, node)
+	for _, tok := range tokens {
+		node = node.child(tok)
+		if node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+	if !node.isEnd {
+		return false
+	}
+
+	node.isEnd = false
+	node.pattern = ""
+	g.size--
+
+	// Prune nodes that no longer lead to any pattern
+	for i := len(tokens) - 1; i >= 0 && path[i+1].empty(); i-- {
+		parent, tok := path[i], tokens[i]
+		switch {
+		case tok.kind == loopStar:
+			parent.star = nil
+		case tok.kind == loopAny:
+			parent.doubleStar = nil
+		case tok.wild:
+			parent.question = nil
+		default:
+			delete(parent.children, tok.char)
+		}
+	}
+	return true
+}
+
+// Has returns true if the exact pattern is stored in the set.
+func (g *GlobSet) Has(pattern string) bool {
+	node := g.root
+	for _, tok := range parseGlob(pattern) {
+		if node = node.child(tok); node == nil {
+			return false
+		}
+	}
+	return node.isEnd
+}
+
+// Len returns the number of patterns stored in the set.
+func (g *GlobSet) Len() int {
+	return g.size
+}
+
+// Clear removes all patterns from the set.
+func (g *GlobSet) Clear() {
+	g.root = newGlobNode(loopNone)
+	g.size = 0
+}
+
+// Patterns returns all stored patterns in lexicographical order.
+func (g *GlobSet) Patterns() []string {
+	var patterns []string
+	stack := []*globNode{g.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.isEnd {
+			patterns = append(patterns, n.pattern)
+		}
+		for _, child := range n.children {
+			stack = append(stack, child)
+		}
+		for _, child := range []*globNode{n.question, n.star, n.doubleStar} {
+			if child != nil {
+				stack = append(stack, child)
+			}
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// MatchAny returns true if at least one pattern matches the path.
+func (g *GlobSet) MatchAny(path string) bool {
+	matched := false
+	g.match(path, func(*globNode) bool {
+		matched = true
+		return false
+	})
+	return matched
+}
+
+// MatchingPatterns returns all patterns matching the path in lexicographical order.
+func (g *GlobSet) MatchingPatterns(path string) []string {
+	var patterns []string
+	g.match(path, func(n *globNode) bool {
+		patterns = append(patterns, n.pattern)
+		return true
+	})
+	sort.Strings(patterns)
+	return patterns
+}
+
+// match simulates the pattern trie as an NFA over the path and calls fn for
+// every terminal node active after the whole path is consumed.
+// Stops early if fn returns false.
+func (g *GlobSet) match(path string, fn func(*globNode) bool) {
+	if g.size == 0 {
+		return
+	}
+
+	current := make(map[*globNode]struct{})
+	addClosure(current, g.root)
+
+	for _, c := range path {
+		next := make(map[*globNode]struct{}, len(current))
+		for n := range current {
+			if child, ok := n.children[c]; ok {
+				addClosure(next, child)
+			}
+			if c != globSeparator {
+				if n.question != nil {
+					addClosure(next, n.question)
+				}
+				if n.loop == loopStar {
+					addClosure(next, n)
+				}
+			}
+			if n.loop == loopAny {
+				addClosure(next, n)
+			}
+		}
+		if len(next) == 0 {
+			return
+		}
+		current = next
+	}
+
+	for n := range current {
+		if n.isEnd && !fn(n) {
+			return
+		}
+	}
+}
+
+// addClosure adds n and every node reachable from it through wildcard edges
+// that may match the empty string.
+func addClosure(states map[*globNode]struct{}, n *globNode) {
+	for n != nil {
+		if _, seen := states[n]; seen {
+			return
+		}
+		states[n] = struct{}{}
+		if n.doubleStar != nil {
+			addClosure(states, n.doubleStar)
+		}
+		n = n.star
+	}
+}
@@ -0,0 +1,128 @@
+package trie_tree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGlobSetBasic(t *testing.T) {
+	g := NewGlobSet()
+
+	// Test empty set
+	if g.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", g.Len())
+	}
+	if g.MatchAny("foo") {
+		t.Error("Expected no match in empty set")
+	}
+
+	// Test Add
+	if !g.Add("foo/*/bar") {
+		t.Error("Expected true when adding new pattern")
+	}
+	if g.Add("foo/*/bar") {
+		t.Error("Expected false when adding duplicate pattern")
+	}
+	if g.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", g.Len())
+	}
+	if !g.Has("foo/*/bar") {
+		t.Error("Expected pattern to be present")
+	}
+	if g.Has("foo/*") {
+		t.Error("Expected prefix of pattern not to be present")
+	}
+}
+
+func TestGlobSetMatch(t *testing.T) {
+	g := NewGlobSet()
+	patterns := []string{"foo/*/bar", "img/**.png", "a?c", "docs/*", "**", "exact"}
+	for _, p := range patterns {
+		g.Add(p)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"foo/x/bar", []string{"**", "foo/*/bar"}},
+		{"foo/x/y/bar", []string{"**"}},
+		{"foo//bar", []string{"**", "foo/*/bar"}},
+		{"img/a.png", []string{"**", "img/**.png"}},
+		{"img/a/b/c.png", []string{"**", "img/**.png"}},
+		{"img/a.jpg", []string{"**"}},
+		{"abc", []string{"**", "a?c"}},
+		{"a/c", []string{"**"}},
+		{"docs/readme", []string{"**", "docs/*"}},
+		{"docs/a/b", []string{"**"}},
+		{"exact", []string{"**", "exact"}},
+		{"", []string{"**"}},
+	}
+
+	for _, tt := range tests {
+		if got := g.MatchingPatterns(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchingPatterns(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	// Without the catch-all pattern some paths stop matching
+	g.Remove("**")
+	if g.MatchAny("foo/x/y/bar") {
+		t.Error("Expected no match for 'foo/x/y/bar'")
+	}
+	if !g.MatchAny("img/x/y.png") {
+		t.Error("Expected match for 'img/x/y.png'")
+	}
+}
+
+func TestGlobSetEscape(t *testing.T) {
+	g := NewGlobSet()
+	g.Add(`what\?`)
+	g.Add(`\*star`)
+
+	if !g.MatchAny("what?") {
+		t.Error("Expected escaped '?' to match literally")
+	}
+	if g.MatchAny("whatx") {
+		t.Error("Expected escaped '?' not to act as a wildcard")
+	}
+	if !g.MatchAny("*star") || g.MatchAny("xstar") {
+		t.Error("Expected escaped '*' to match literally")
+	}
+}
+
+func TestGlobSetRemove(t *testing.T) {
+	g := NewGlobSet()
+	g.Add("a/*")
+	g.Add("a/*/b")
+
+	if g.Remove("a/**") {
+		t.Error("Expected false when removing missing pattern")
+	}
+	if !g.Remove("a/*/b") {
+		t.Error("Expected true when removing existing pattern")
+	}
+	if g.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", g.Len())
+	}
+	if g.MatchAny("a/x/b") {
+		t.Error("Expected no match after removal")
+	}
+	if !g.MatchAny("a/x") {
+		t.Error("Expected remaining pattern to still match")
+	}
+
+	// Removed branch should be pruned
+	if g.root.children['a'].children['/'].star.children['/'] != nil {
+		t.Error("Expected removed branch to be pruned")
+	}
+
+	if !reflect.DeepEqual(g.Patterns(), []string{"a/*"}) {
+		t.Errorf("Expected patterns [a/*], got %v", g.Patterns())
+	}
+
+	g.Clear()
+	if g.Len() != 0 || g.MatchAny("a/x") {
+		t.Error("Expected empty set after Clear")
+	}
+}
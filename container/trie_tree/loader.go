@@ -0,0 +1,38 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements bulk loading of words into Trie.
+
+package trie_tree
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// InsertFromReader inserts the newline-delimited words read from r, one per
+// line, streaming them without building an intermediate slice. A trailing
+// "\r" is stripped and empty lines are skipped. Lines longer than 1 MiB
+// cause bufio.ErrTooLong.
+//
+// If progress is not nil it is called after each word with the number of
+// words read so far. Returns the number of words newly added, which excludes
+// duplicates, and the first read error; words read before an error remain
+// in the trie.
+func (t *Trie) InsertFromReader(r io.Reader, progress func(read int)) (int, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxEncodedWordLen)
+
+	start, read := t.size, 0
+	for sc.Scan() {
+		word := strings.TrimSuffix(sc.Text(), "\r")
+		if word == "" {
+			continue
+		}
+		t.Insert(word)
+		read++
+		if progress != nil {
+			progress(read)
+		}
+	}
+	return t.size - start, sc.Err()
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+// +build go1.23
+
+// Package trie_tree provides go1.23-specific methods for Trie.
+// This file adds iter.Seq based bulk loading.
+
+package trie_tree
+
+import (
+	"iter"
+)
+
+// InsertSeq inserts every word produced by seq (go1.23). Empty words are
+// skipped. If progress is not nil it is called after each word with the
+// number of words consumed so far. Returns the number of words newly added.
+func (t *Trie) InsertSeq(seq iter.Seq[string], progress func(read int)) int {
+	start, read := t.size, 0
+	for word := range seq {
+		if word == "" {
+			continue
+		}
+		t.Insert(word)
+		read++
+		if progress != nil {
+			progress(read)
+		}
+	}
+	return t.size - start
+}
+
+// NewTrieFromSeq creates a trie of the words produced by seq (go1.23),
+// skipping empty words as InsertSeq does.
+func NewTrieFromSeq(seq iter.Seq[string], opts ...Option) *Trie {
+	t := NewTrie(opts...)
+	t.InsertSeq(seq, nil)
+	return t
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+// +build go1.23
+
+package trie_tree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrieInsertSeq(t *testing.T) {
+	trie := NewTrie(WithCaseFolding())
+	last := 0
+	added := trie.InsertSeq(slices.Values([]string{"Go", "", "go", "Rust"}), func(read int) {
+		last = read
+	})
+	if added != 2 || last != 3 {
+		t.Errorf("Expected 2 new words after 3 read, got %d after %d", added, last)
+	}
+	if !slices.Equal(trie.GetAllWords(), []string{"go", "rust"}) {
+		t.Errorf("Expected [go rust], got %v", trie.GetAllWords())
+	}
+
+	// Words can be streamed from another container
+	other := NewTrie()
+	if other.InsertSeq(trie.WordSeq(), nil) != 2 {
+		t.Errorf("Expected 2 words copied, got %v", other.GetAllWords())
+	}
+}
+
+func TestNewTrieFromSeq(t *testing.T) {
+	trie := NewTrieFromSeq(slices.Values([]string{"Go", "", "GOPHER", "go"}), WithCaseFolding())
+	if trie.Len() != 2 || !trie.Search("gopher") {
+		t.Errorf("Expected a trie of {go, gopher}, got %v", trie.GetAllWords())
+	}
+}
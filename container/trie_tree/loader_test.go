@@ -0,0 +1,53 @@
+package trie_tree
+
+import (
+	"bufio"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTrieInsertFromReader(t *testing.T) {
+	trie := NewTrie()
+	trie.Insert("apple")
+
+	input := "banana\r\napple\n\ncherry\nbanana\ndate"
+	var calls []int
+	added, err := trie.InsertFromReader(strings.NewReader(input), func(read int) {
+		calls = append(calls, read)
+	})
+	if err != nil {
+		t.Fatalf("InsertFromReader: %v", err)
+	}
+	if added != 3 {
+		t.Errorf("Expected 3 new words, got %d", added)
+	}
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, []string{"apple", "banana", "cherry", "date"}) {
+		t.Errorf("Expected [apple banana cherry date], got %v", got)
+	}
+	if !reflect.DeepEqual(calls, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected progress after every word, got %v", calls)
+	}
+
+	// Loaded words take part in weighted queries
+	trie.InsertWithWeight("cherry", 3)
+	if got := trie.TopK("", 1); !reflect.DeepEqual(got, []string{"cherry"}) {
+		t.Errorf("Expected [cherry], got %v", got)
+	}
+}
+
+func TestTrieInsertFromReaderErrors(t *testing.T) {
+	trie := NewTrie()
+	r := iotest.TimeoutReader(strings.NewReader("one\ntwo\n"))
+	if _, err := trie.InsertFromReader(r, nil); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+
+	long := strings.Repeat("x", maxEncodedWordLen+1)
+	added, err := trie.InsertFromReader(strings.NewReader("ok\n"+long), nil)
+	if !errors.Is(err, bufio.ErrTooLong) || added != 1 || !trie.Search("ok") {
+		t.Errorf("Expected ErrTooLong after 1 word, got %d, %v", added, err)
+	}
+}
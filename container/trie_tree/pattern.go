@@ -0,0 +1,101 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements wildcard pattern search on Trie.
+
+package trie_tree
+
+// patternToken is a single parsed element of a search pattern.
+type patternToken struct {
+	char rune // literal rune, valid when neither wildcard flag is set
+	one  bool // '?': exactly one rune
+	any  bool // '*': zero or more runes
+}
+
+// parsePattern splits a search pattern into tokens. A backslash escapes the
+// next rune, so `\?` and `\*` match literally. In byte mode every literal
+// rune becomes one token per byte.
+func parsePattern(pattern string, mode unitMode) []patternToken {
+	runes := []rune(pattern)
+	tokens := make([]patternToken, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '?':
+			tokens = append(tokens, patternToken{one: true})
+		case c == '*':
+			tokens = append(tokens, patternToken{any: true})
+		default:
+			if c == '\\' && i+1 < len(runes) {
+				i++
+				c = runes[i]
+			}
+			for _, unit := range mode.units(string(c)) {
+				tokens = append(tokens, patternToken{char: unit})
+			}
+		}
+	}
+	return tokens
+}
+
+// SearchPattern returns all words matching pattern in lexicographical order.
+// In a pattern, '?' matches exactly one rune (one byte in byte mode), '*'
+// matches any sequence including the empty one, and '\' escapes the
+// following rune.
+//
+// The pattern is run as an NFA alongside a depth-first walk of the trie, so
+// each trie node is visited at most once and no word is reported twice.
+func (t *Trie) SearchPattern(pattern string) []string {
+	var words []string
+	t.matchPattern(pattern, func(w string) bool {
+		words = append(words, w)
+		return true
+	})
+	return words
+}
+
+// matchPattern calls fn for every word matching pattern in lexicographical
+// order. Stops early if fn returns false.
+func (t *Trie) matchPattern(pattern string, fn func(string) bool) {
+	tokens := parsePattern(t.key(pattern), t.mode)
+	states := make([]bool, len(tokens)+1)
+	addPatternState(tokens, states, 0)
+	patternWalk(t.mode, t.root, nil, tokens, states, fn)
+}
+
+// addPatternState activates state i and every state reachable from it by
+// letting '*' tokens match the empty string.
+func addPatternState(tokens []patternToken, states []bool, i int) {
+	for ; i <= len(tokens); i++ {
+		states[i] = true
+		if i == len(tokens) || !tokens[i].any {
+			return
+		}
+	}
+}
+
+// patternWalk visits node, whose key is path and whose active NFA states are
+// states. Returns false if fn requested a stop.
+func patternWalk(mode unitMode, node *trieNode, path []byte, tokens []patternToken, states []bool, fn func(string) bool) bool {
+	if node.isEnd && states[len(tokens)] && !fn(string(path)) {
+		return false
+	}
+	for _, char := range node.sortedChars() {
+		next := make([]bool, len(states))
+		active := false
+		for i, on := range states[:len(tokens)] {
+			if !on {
+				continue
+			}
+			switch tok := tokens[i]; {
+			case tok.any:
+				addPatternState(tokens, next, i)
+				active = true
+			case tok.one || tok.char == char:
+				addPatternState(tokens, next, i+1)
+				active = true
+			}
+		}
+		if active && !patternWalk(mode, node.children[char], mode.appendUnit(path, char), tokens, next, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package trie_tree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrieSearchPattern(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"apple", "ample", "apply", "app", "application", "ap?le", "banana", "bandana", "日本語"} {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"ap?le", []string{"ap?le", "apple"}},
+		{`ap\?le`, []string{"ap?le"}},
+		{"app*", []string{"app", "apple", "application", "apply"}},
+		{"a*le", []string{"ample", "ap?le", "apple"}},
+		{"*an*a", []string{"banana", "bandana"}},
+		{"b?n*a", []string{"banana", "bandana"}},
+		{"日?語", []string{"日本語"}},
+		{"?", nil},
+		{"*", []string{"ample", "ap?le", "app", "apple", "application", "apply", "banana", "bandana", "日本語"}},
+		{"**p*y", []string{"apply"}},
+		{"", nil},
+		{"apple", []string{"apple"}},
+	}
+	for _, tt := range tests {
+		if got := trie.SearchPattern(tt.pattern); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements RadixTrie, a compressed trie with string edge labels.
+
+package trie_tree
+
+import (
+	"sort"
+	"strings"
+)
+
+// radixNode represents a node in a RadixTrie. The node is reached from its
+// parent through an edge labelled with label.
+type radixNode struct {
+	label    string       // non-empty edge label, except for the root
+	children []*radixNode // children sorted by the first byte of their label
+	isEnd    bool         // true if a word ends at this node
+}
+
+// child returns the index of the child whose label starts with b and
+// whether it exists. If it does not, the index is where it would be inserted.
+func (n *radixNode) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= b
+	})
+	return i, i < len(n.children) && n.children[i].label[0] == b
+}
+
+// RadixTrie implements the Interface as a compressed (Patricia) trie.
+// Chains of single-child nodes are merged into one edge labelled with the
+// whole substring, so long keys with few branch points, such as URLs and
+// file paths, need far fewer nodes than in a Trie.
+//
+// Edges are split on bytes. Words are ordered by their UTF-8 bytes, which
+// matches rune order for valid UTF-8.
+type RadixTrie struct {
+	root *radixNode
+	size int // number of words stored
+}
+
+// NewRadixTrie creates a new empty RadixTrie.
+func NewRadixTrie() *RadixTrie {
+	return &RadixTrie{
+		root: &radixNode{},
+	}
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// Insert adds a word to the trie.
+func (t *RadixTrie) Insert(word string) {
+	if word == "" {
+		return
+	}
+
+	node, rest := t.root, word
+	for {
+		i, ok := node.child(rest[0])
+		if !ok {
+			// No edge shares a first byte, attach the rest as a new leaf
+			leaf := &radixNode{label: rest, isEnd: true}
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = leaf
+			t.size++
+			return
+		}
+
+		c := node.children[i]
+		common := commonPrefixLen(c.label, rest)
+		if common < len(c.label) {
+			// Split the edge at the point where the word diverges
+			tail := &radixNode{label: c.label[common:], children: c.children, isEnd: c.isEnd}
+			c.label = c.label[:common]
+			c.children = []*radixNode{tail}
+			c.isEnd = false
+		}
+
+		node, rest = c, rest[common:]
+		if rest == "" {
+			if !node.isEnd {
+				node.isEnd = true
+				t.size++
+			}
+			return
+		}
+	}
+}
+
+// Search returns true if the word exists in the trie.
+func (t *RadixTrie) Search(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	node, rest := t.root, word
+	for rest != "" {
+		i, ok := node.child(rest[0])
+		if !ok || !strings.HasPrefix(rest, node.children[i].label) {
+			return false
+		}
+		node = node.children[i]
+		rest = rest[len(node.label):]
+	}
+	return node.isEnd
+}
+
+// findPrefix returns the highest node whose key starts with prefix, along
+// with that key. Returns nil if no word starts with prefix.
+func (t *RadixTrie) findPrefix(prefix string) (*radixNode, string) {
+	node, rest := t.root, prefix
+	for rest != "" {
+		i, ok := node.child(rest[0])
+		if !ok {
+			return nil, ""
+		}
+		c := node.children[i]
+		switch {
+		case strings.HasPrefix(c.label, rest):
+			// The prefix ends inside (or at the end of) this edge
+			return c, prefix[:len(prefix)-len(rest)] + c.label
+		case strings.HasPrefix(rest, c.label):
+			node, rest = c, rest[len(c.label):]
+		default:
+			return nil, ""
+		}
+	}
+	return node, prefix
+}
+
+// StartsWith returns true if there are any words in the trie that start with the given prefix.
+func (t *RadixTrie) StartsWith(prefix string) bool {
+	if prefix == "" {
+		return t.size > 0
+	}
+	node, _ := t.findPrefix(prefix)
+	return node != nil
+}
+
+// Delete removes a word from the trie and returns true if the word was found and removed.
+// Nodes are merged back so that no non-root node without a word has a single child.
+func (t *RadixTrie) Delete(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	var parent *radixNode
+	parentIndex := 0
+	node, rest := t.root, word
+	for rest != "" {
+		i, ok := node.child(rest[0])
+		if !ok || !strings.HasPrefix(rest, node.children[i].label) {
+			return false
+		}
+		parent, parentIndex = node, i
+		node = node.children[i]
+		rest = rest[len(node.label):]
+	}
+	if !node.isEnd {
+		return false
+	}
+
+	node.isEnd = false
+	t.size--
+
+	switch len(node.children) {
+	case 0:
+		// Remove the leaf, then its parent may be left as a pass-through node
+		parent.children = append(parent.children[:parentIndex], parent.children[parentIndex+1:]...)
+		if parent != t.root && !parent.isEnd && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		node.mergeChild()
+	}
+	return true
+}
+
+// mergeChild merges n with its only child.
+func (n *radixNode) mergeChild() {
+	c := n.children[0]
+	n.label += c.label
+	n.children = c.children
+	n.isEnd = c.isEnd
+}
+
+// LongestPrefix returns the longest word in the trie that is a prefix of s,
+// and false if there is none.
+func (t *RadixTrie) LongestPrefix(s string) (string, bool) {
+	match, found := 0, false
+	node, rest := t.root, s
+	for rest != "" {
+		i, ok := node.child(rest[0])
+		if !ok || !strings.HasPrefix(rest, node.children[i].label) {
+			break
+		}
+		node = node.children[i]
+		rest = rest[len(node.label):]
+		if node.isEnd {
+			match, found = len(s)-len(rest), true
+		}
+	}
+	return s[:match], found
+}
+
+// Len returns the number of words stored in the trie.
+func (t *RadixTrie) Len() int {
+	return t.size
+}
+
+// Clear removes all words from the trie.
+func (t *RadixTrie) Clear() {
+	t.root = &radixNode{}
+	t.size = 0
+}
+
+// GetAllWords returns a slice of all words stored in the trie in lexicographical order.
+func (t *RadixTrie) GetAllWords() []string {
+	return t.GetWordsWithPrefix("")
+}
+
+// GetWordsWithPrefix returns a slice of all words that start with the given prefix
+// in lexicographical order.
+func (t *RadixTrie) GetWordsWithPrefix(prefix string) []string {
+	var words []string
+	t.rangePrefix(prefix, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+	return words
+}
+
+// rangePrefix calls fn for every word starting with prefix in lexicographical
+// order. Stops early if fn returns false.
+func (t *RadixTrie) rangePrefix(prefix string, fn func(string) bool) {
+	if node, key := t.findPrefix(prefix); node != nil {
+		collectRadixWords(node, []byte(key), fn)
+	}
+}
+
+// collectRadixWords performs a depth-first traversal from node, whose key is
+// path, calling fn for every word. Returns false if fn requested a stop.
+func collectRadixWords(node *radixNode, path []byte, fn func(string) bool) bool {
+	if node.isEnd && !fn(string(path)) {
+		return false
+	}
+	for _, c := range node.children {
+		if !collectRadixWords(c, append(path, c.label...), fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package trie_tree
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// checkRadix fails the test if a non-root node without a word has fewer
+// than two children, or if children are not sorted by first byte.
+func checkRadix(t *testing.T, n *radixNode, isRoot bool) {
+	t.Helper()
+	if !isRoot && !n.isEnd && len(n.children) < 2 {
+		t.Fatalf("node %q is not compressed", n.label)
+	}
+	for i, c := range n.children {
+		if c.label == "" || (i > 0 && n.children[i-1].label[0] >= c.label[0]) {
+			t.Fatalf("children of %q are malformed", n.label)
+		}
+		checkRadix(t, c, false)
+	}
+}
+
+func TestRadixTrieBasic(t *testing.T) {
+	trie := NewRadixTrie()
+	words := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	trie.Insert("romane")
+	trie.Insert("")
+
+	if trie.Len() != len(words) {
+		t.Errorf("Expected length %d, got %d", len(words), trie.Len())
+	}
+	checkRadix(t, trie.root, true)
+
+	for _, w := range words {
+		if !trie.Search(w) {
+			t.Errorf("Expected to find %q", w)
+		}
+	}
+	for _, w := range []string{"rom", "roman", "rubicons", "", "x"} {
+		if trie.Search(w) {
+			t.Errorf("Expected not to find %q", w)
+		}
+	}
+
+	if !trie.StartsWith("rubi") || !trie.StartsWith("rubicundus") || trie.StartsWith("rubx") || !trie.StartsWith("") {
+		t.Error("Unexpected StartsWith result")
+	}
+	if got, want := trie.GetWordsWithPrefix("rub"), []string{"rubens", "ruber", "rubicon", "rubicundus"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got, want := trie.GetWordsWithPrefix("rubic"), []string{"rubicon", "rubicundus"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if !reflect.DeepEqual(trie.GetAllWords(), words) {
+		t.Errorf("Expected %v, got %v", words, trie.GetAllWords())
+	}
+
+	trie.Clear()
+	if trie.Len() != 0 || trie.StartsWith("r") {
+		t.Error("Expected empty trie after Clear")
+	}
+}
+
+func TestRadixTrieLongestPrefix(t *testing.T) {
+	trie := NewRadixTrie()
+	for _, w := range []string{"/api", "/api/v1", "/api/v1/users", "/static"} {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		input, want string
+		ok          bool
+	}{
+		{"/api/v1/users/42", "/api/v1/users", true},
+		{"/api/v1/user", "/api/v1", true},
+		{"/api/v2", "/api", true},
+		{"/ap", "", false},
+		{"/static", "/static", true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := trie.LongestPrefix(tt.input); got != tt.want || ok != tt.ok {
+			t.Errorf("LongestPrefix(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRadixTrieRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	trie := NewRadixTrie()
+	ref := make(map[string]bool)
+	gen := func() string {
+		b := make([]byte, rng.Intn(6)+1)
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 3000; i++ {
+		w := gen()
+		if rng.Intn(2) == 0 {
+			if trie.Delete(w) != ref[w] {
+				t.Fatalf("Delete(%q) disagreed with reference", w)
+			}
+			delete(ref, w)
+		} else {
+			trie.Insert(w)
+			ref[w] = true
+		}
+		checkRadix(t, trie.root, true)
+	}
+
+	want := make([]string, 0, len(ref))
+	for w := range ref {
+		want = append(want, w)
+	}
+	sort.Strings(want)
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, want) || trie.Len() != len(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestRadixTrieInterfaceCompliance(t *testing.T) {
+	var _ Interface = NewRadixTrie()
+}
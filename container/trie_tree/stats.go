@@ -0,0 +1,51 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements size statistics for Trie.
+
+package trie_tree
+
+import (
+	"unsafe"
+)
+
+// Approximate costs used by Stats.MemoryBytes. The exact figures depend on
+// the Go runtime's map implementation and allocator size classes.
+const (
+	mapHeaderBytes = 48 // header allocated for every children map
+	mapEntryBytes  = 24 // key, value and bookkeeping per map entry, with slack
+)
+
+// Stats describes the shape and approximate size of a Trie.
+type Stats struct {
+	Words    int // number of words stored
+	Nodes    int // number of nodes, including the root
+	Edges    int // number of parent-child links, Nodes-1
+	MaxDepth int // length of the longest word, in runes or bytes
+
+	// MemoryBytes is a rough estimate of the heap memory held by the nodes
+	// and their children maps, for capacity planning and tracking
+	// regressions rather than exact accounting.
+	MemoryBytes int
+}
+
+// Stats walks the trie and returns its statistics. It runs in O(n) for n
+// nodes.
+func (t *Trie) Stats() Stats {
+	s := Stats{Words: t.size}
+	type item struct {
+		node  *trieNode
+		depth int
+	}
+	stack := []item{{t.root, 0}}
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		s.Nodes++
+		s.MaxDepth = max(s.MaxDepth, it.depth)
+		s.MemoryBytes += int(unsafe.Sizeof(trieNode{})) + mapHeaderBytes + mapEntryBytes*len(it.node.children)
+		for _, child := range it.node.children {
+			stack = append(stack, item{child, it.depth + 1})
+		}
+	}
+	s.Edges = s.Nodes - 1
+	return s
+}
@@ -0,0 +1,38 @@
+package trie_tree
+
+import (
+	"testing"
+)
+
+func TestTrieStats(t *testing.T) {
+	trie := NewTrie()
+	empty := trie.Stats()
+	if empty.Words != 0 || empty.Nodes != 1 || empty.Edges != 0 || empty.MaxDepth != 0 || empty.MemoryBytes <= 0 {
+		t.Errorf("Unexpected stats for an empty trie: %+v", empty)
+	}
+
+	for _, w := range []string{"car", "cart", "cat", "dog", "日本"} {
+		trie.Insert(w)
+	}
+	// root, c-a-r-t, t under ca, d-o-g, 日-本
+	s := trie.Stats()
+	want := Stats{Words: 5, Nodes: 11, Edges: 10, MaxDepth: 4}
+	s.MemoryBytes = 0 // checked separately below
+	if s != want {
+		t.Errorf("Expected %+v, got %+v", want, s)
+	}
+	if trie.Stats().MemoryBytes <= empty.MemoryBytes {
+		t.Error("Expected the memory estimate to grow with the trie")
+	}
+
+	bytes := NewTrie(WithByteMode())
+	bytes.Insert("日本")
+	if s := bytes.Stats(); s.MaxDepth != 6 || s.Nodes != 7 {
+		t.Errorf("Expected byte mode to count 6 levels, got %+v", s)
+	}
+
+	trie.Delete("cart")
+	if s := trie.Stats(); s.Nodes != 10 || s.Words != 4 || s.MaxDepth != 3 {
+		t.Errorf("Expected Delete to prune the node, got %+v", s)
+	}
+}
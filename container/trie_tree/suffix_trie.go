@@ -0,0 +1,121 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements SuffixTrie for suffix queries.
+
+package trie_tree
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// SuffixTrie stores words keyed by their reverse, so suffix queries such as
+// EndsWith run in O(len(suffix)) like prefix queries on a Trie. This suits
+// matching file extensions or domain suffixes.
+//
+// It accepts the same options as NewTrie. Words are normalized before they
+// are reversed, so normalizers see them in their original order.
+type SuffixTrie struct {
+	trie      *Trie // reversed words
+	normalize func(string) string
+}
+
+// NewSuffixTrie creates a new SuffixTrie.
+func NewSuffixTrie(opts ...Option) *SuffixTrie {
+	t := NewTrie(opts...)
+	normalize := t.normalize
+	t.normalize = nil
+	return &SuffixTrie{trie: t, normalize: normalize}
+}
+
+// reverse returns the normalized s with its units in reverse order.
+func (t *SuffixTrie) reverse(s string) string {
+	if t.normalize != nil {
+		s = t.normalize(s)
+	}
+	return reverseUnits(t.trie.mode, s)
+}
+
+// reverseUnits returns s with its units in reverse order.
+func reverseUnits(mode unitMode, s string) string {
+	b := make([]byte, 0, len(s))
+	for i := len(s); i > 0; {
+		if mode == byteMode {
+			i--
+			b = append(b, s[i])
+			continue
+		}
+		r, width := utf8.DecodeLastRuneInString(s[:i])
+		i -= width
+		b = utf8.AppendRune(b, r)
+	}
+	return string(b)
+}
+
+// Insert adds a word to the trie.
+func (t *SuffixTrie) Insert(word string) {
+	t.trie.Insert(t.reverse(word))
+}
+
+// Search returns true if the word exists in the trie.
+func (t *SuffixTrie) Search(word string) bool {
+	return t.trie.Search(t.reverse(word))
+}
+
+// EndsWith returns true if there are any words in the trie that end with the given suffix.
+func (t *SuffixTrie) EndsWith(suffix string) bool {
+	return t.trie.StartsWith(t.reverse(suffix))
+}
+
+// Delete removes a word from the trie and returns true if the word was found and removed.
+func (t *SuffixTrie) Delete(word string) bool {
+	return t.trie.Delete(t.reverse(word))
+}
+
+// DeleteSuffix removes every word that ends with the given suffix and
+// returns how many were removed. An empty suffix removes all words.
+func (t *SuffixTrie) DeleteSuffix(suffix string) int {
+	return t.trie.DeletePrefix(t.reverse(suffix))
+}
+
+// Len returns the number of words stored in the trie.
+func (t *SuffixTrie) Len() int {
+	return t.trie.Len()
+}
+
+// Clear removes all words from the trie.
+func (t *SuffixTrie) Clear() {
+	t.trie.Clear()
+}
+
+// CountWordsWithSuffix returns the number of words that end with the given
+// suffix in O(len(suffix)), without enumerating them.
+func (t *SuffixTrie) CountWordsWithSuffix(suffix string) int {
+	return t.trie.CountWordsWithPrefix(t.reverse(suffix))
+}
+
+// GetAllWords returns a slice of all words stored in the trie in lexicographical order.
+func (t *SuffixTrie) GetAllWords() []string {
+	return t.GetWordsWithSuffix("")
+}
+
+// GetWordsWithSuffix returns a slice of all words that end with the given
+// suffix in lexicographical order.
+func (t *SuffixTrie) GetWordsWithSuffix(suffix string) []string {
+	words := t.trie.GetWordsWithPrefix(t.reverse(suffix))
+	for i, w := range words {
+		words[i] = reverseUnits(t.trie.mode, w)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// LongestSuffix returns the longest word in the trie that is a suffix of s,
+// and false if there is none. For example, with "com" and "example.com"
+// stored, LongestSuffix("www.example.com") returns "example.com".
+func (t *SuffixTrie) LongestSuffix(s string) (string, bool) {
+	match, found := t.trie.LongestPrefix(t.reverse(s))
+	if !found {
+		return "", false
+	}
+	return reverseUnits(t.trie.mode, match), true
+}
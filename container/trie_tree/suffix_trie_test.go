@@ -0,0 +1,110 @@
+package trie_tree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuffixTrieBasic(t *testing.T) {
+	st := NewSuffixTrie()
+	words := []string{"main.go", "trie.go", "README.md", "notes.md", "café", "日本語"}
+	for _, w := range words {
+		st.Insert(w)
+	}
+	st.Insert("main.go")
+
+	if st.Len() != len(words) {
+		t.Errorf("Expected length %d, got %d", len(words), st.Len())
+	}
+	for _, w := range words {
+		if !st.Search(w) {
+			t.Errorf("Expected to find word '%s'", w)
+		}
+	}
+	if st.Search("go") || st.Search("") {
+		t.Error("Expected suffixes and the empty word not to be found")
+	}
+
+	for _, s := range []string{"", ".go", "e.go", ".md", "é", "本語"} {
+		if !st.EndsWith(s) {
+			t.Errorf("Expected to find suffix '%s'", s)
+		}
+	}
+	if st.EndsWith(".txt") || st.EndsWith("x.go") || st.EndsWith("日") {
+		t.Error("Expected missing suffixes not to be found")
+	}
+
+	if got := st.GetWordsWithSuffix(".go"); !reflect.DeepEqual(got, []string{"main.go", "trie.go"}) {
+		t.Errorf("Expected [main.go trie.go], got %v", got)
+	}
+	if st.CountWordsWithSuffix(".md") != 2 {
+		t.Errorf("Expected 2 words with suffix '.md', got %d", st.CountWordsWithSuffix(".md"))
+	}
+	want := []string{"README.md", "café", "main.go", "notes.md", "trie.go", "日本語"}
+	if got := st.GetAllWords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if !st.Delete("trie.go") || st.Delete("trie.go") {
+		t.Error("Unexpected Delete result")
+	}
+	if got := st.DeleteSuffix(".md"); got != 2 || st.EndsWith(".md") {
+		t.Errorf("Expected 2 words removed, got %d", got)
+	}
+
+	st.Clear()
+	if st.Len() != 0 || st.EndsWith("") {
+		t.Error("Expected empty trie after Clear")
+	}
+}
+
+func TestSuffixTrieLongestSuffix(t *testing.T) {
+	st := NewSuffixTrie(WithCaseFolding())
+	for _, d := range []string{"com", "example.com", "co.uk"} {
+		st.Insert(d)
+	}
+
+	tests := []struct {
+		host  string
+		want  string
+		found bool
+	}{
+		{"www.Example.COM", "example.com", true},
+		{"shop.com", "com", true},
+		{"bbc.co.uk", "co.uk", true},
+		{"example.org", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := st.LongestSuffix(tt.host); got != tt.want || ok != tt.found {
+			t.Errorf("LongestSuffix(%q) = %q, %v, want %q, %v", tt.host, got, ok, tt.want, tt.found)
+		}
+	}
+}
+
+func TestSuffixTrieByteMode(t *testing.T) {
+	st := NewSuffixTrie(WithByteMode())
+	st.Insert("ab\xff")
+	st.Insert("\xffb")
+
+	if !st.EndsWith("\xff") || !st.EndsWith("b\xff") || st.EndsWith("a\xff") {
+		t.Error("Expected byte-wise suffix matching")
+	}
+	if got := st.GetWordsWithSuffix("b"); !reflect.DeepEqual(got, []string{"\xffb"}) {
+		t.Errorf("Expected [\\xffb], got %q", got)
+	}
+}
+
+func TestSuffixTrieNormalizer(t *testing.T) {
+	// Normalizers see words in their original order, before reversal
+	nfc := strings.NewReplacer("e\u0301", "\u00e9").Replace
+	st := NewSuffixTrie(WithNormalizer(nfc))
+	st.Insert("cafe\u0301")
+
+	if !st.Search("caf\u00e9") || !st.EndsWith("\u00e9") || !st.EndsWith("e\u0301") {
+		t.Error("Expected words to be normalized before reversal")
+	}
+	if got := st.GetAllWords(); !reflect.DeepEqual(got, []string{"caf\u00e9"}) {
+		t.Errorf("Expected [caf\u00e9], got %q", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements the Interface as a ternary search tree.
+
+package trie_tree
+
+import (
+	"unicode/utf8"
+)
+
+// tstNode represents a node in a ternary search tree. Each node holds one
+// character; lo and hi lead to siblings with smaller and larger characters,
+// and eq leads to the characters that follow it.
+type tstNode struct {
+	char  rune     // character held by this node
+	lo    *tstNode // siblings with smaller characters
+	eq    *tstNode // next character of words through this node
+	hi    *tstNode // siblings with larger characters
+	isEnd bool     // true if this node represents the end of a word
+}
+
+// TernarySearchTree implements the Interface as a ternary search tree (TST).
+// Each node stores a single character and three pointers instead of a child
+// map, which typically cuts memory several times over Trie for
+// natural-language dictionaries, at the cost of O(log σ) comparisons per
+// character, where σ is the alphabet size. Words are kept in order, so
+// traversals are lexicographical without sorting.
+//
+// The shape depends on insertion order; inserting words in random order, or
+// the median first, keeps the sibling trees balanced.
+type TernarySearchTree struct {
+	root *tstNode
+	size int // number of words stored
+}
+
+// NewTernarySearchTree creates a new TernarySearchTree.
+func NewTernarySearchTree() *TernarySearchTree {
+	return &TernarySearchTree{}
+}
+
+// Insert adds a word to the tree.
+func (t *TernarySearchTree) Insert(word string) {
+	if word == "" {
+		return
+	}
+
+	link := &t.root
+	for i := 0; i < len(word); {
+		char, width := utf8.DecodeRuneInString(word[i:])
+		node := *link
+		if node == nil {
+			node = &tstNode{char: char}
+			*link = node
+		}
+		switch {
+		case char < node.char:
+			link = &node.lo
+		case char > node.char:
+			link = &node.hi
+		default:
+			i += width
+			if i == len(word) {
+				if !node.isEnd {
+					node.isEnd = true
+					t.size++
+				}
+				return
+			}
+			link = &node.eq
+		}
+	}
+}
+
+// Search returns true if the word exists in the tree.
+func (t *TernarySearchTree) Search(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	node := t.findNode(word)
+	return node != nil && node.isEnd
+}
+
+// StartsWith returns true if there are any words in the tree that start with the given prefix.
+func (t *TernarySearchTree) StartsWith(prefix string) bool {
+	if prefix == "" {
+		return t.size > 0
+	}
+
+	return t.findNode(prefix) != nil
+}
+
+// Delete removes a word from the tree and returns true if the word was found and removed.
+func (t *TernarySearchTree) Delete(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	var removed bool
+	t.root, removed = tstDelete(t.root, word)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+// tstDelete removes word from the tree rooted at n and returns the new root
+// and whether the word was found. Nodes that no longer lead to any word are
+// removed, with their lo and hi siblings merged in their place.
+func tstDelete(n *tstNode, word string) (*tstNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	char, width := utf8.DecodeRuneInString(word)
+	var removed bool
+	switch {
+	case char < n.char:
+		n.lo, removed = tstDelete(n.lo, word)
+	case char > n.char:
+		n.hi, removed = tstDelete(n.hi, word)
+	case width == len(word):
+		removed = n.isEnd
+		n.isEnd = false
+	default:
+		n.eq, removed = tstDelete(n.eq, word[width:])
+	}
+
+	if n.isEnd || n.eq != nil {
+		return n, removed
+	}
+	return tstMerge(n.lo, n.hi), removed
+}
+
+// tstMerge joins two sibling trees where every character in lo is smaller
+// than every character in hi, and returns the root of the result.
+func tstMerge(lo, hi *tstNode) *tstNode {
+	if lo == nil {
+		return hi
+	}
+	if hi != nil {
+		last := lo
+		for last.hi != nil {
+			last = last.hi
+		}
+		last.hi = hi
+	}
+	return lo
+}
+
+// Len returns the number of words stored in the tree.
+func (t *TernarySearchTree) Len() int {
+	return t.size
+}
+
+// Clear removes all words from the tree.
+func (t *TernarySearchTree) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// GetAllWords returns a slice of all words stored in the tree in lexicographical order.
+func (t *TernarySearchTree) GetAllWords() []string {
+	var words []string
+	t.rangeNode(t.root, nil, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+	return words
+}
+
+// GetWordsWithPrefix returns a slice of all words that start with the given prefix
+// in lexicographical order.
+func (t *TernarySearchTree) GetWordsWithPrefix(prefix string) []string {
+	var words []string
+	t.rangePrefix(prefix, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+	return words
+}
+
+// rangePrefix calls yield for each word starting with prefix in
+// lexicographical order until yield returns false.
+func (t *TernarySearchTree) rangePrefix(prefix string, yield func(string) bool) {
+	if prefix == "" {
+		t.rangeNode(t.root, nil, yield)
+		return
+	}
+
+	node := t.findNode(prefix)
+	if node == nil {
+		return
+	}
+	if node.isEnd && !yield(prefix) {
+		return
+	}
+	t.rangeNode(node.eq, []byte(prefix), yield)
+}
+
+// rangeNode calls yield in lexicographical order for each word in the tree
+// rooted at n, where path is the key leading to n. Returns false if yield
+// stopped the iteration.
+func (t *TernarySearchTree) rangeNode(n *tstNode, path []byte, yield func(string) bool) bool {
+	for n != nil {
+		if !t.rangeNode(n.lo, path, yield) {
+			return false
+		}
+		next := utf8.AppendRune(path, n.char)
+		if n.isEnd && !yield(string(next)) {
+			return false
+		}
+		if !t.rangeNode(n.eq, next, yield) {
+			return false
+		}
+		// Continue with the larger siblings iteratively
+		n = n.hi
+	}
+	return true
+}
+
+// findNode returns the node holding the last character of str.
+// Returns nil if str is not a prefix of any word.
+func (t *TernarySearchTree) findNode(str string) *tstNode {
+	node := t.root
+	for i := 0; i < len(str); {
+		char, width := utf8.DecodeRuneInString(str[i:])
+		for node != nil && node.char != char {
+			if char < node.char {
+				node = node.lo
+			} else {
+				node = node.hi
+			}
+		}
+		if node == nil {
+			return nil
+		}
+		i += width
+		if i == len(str) {
+			return node
+		}
+		node = node.eq
+	}
+	return nil
+}
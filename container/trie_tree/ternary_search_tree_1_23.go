@@ -0,0 +1,26 @@
+//go:build go1.23
+// +build go1.23
+
+// Package trie_tree provides go1.23-specific methods for TernarySearchTree.
+// This file adds iter.Seq related methods for Interface.
+
+package trie_tree
+
+import (
+	"iter"
+)
+
+// WordSeq returns an iterator for all words in the tree in lexicographical order (go1.23).
+func (t *TernarySearchTree) WordSeq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.rangePrefix("", yield)
+	}
+}
+
+// PrefixSeq returns an iterator for all words that start with the given prefix
+// in lexicographical order (go1.23).
+func (t *TernarySearchTree) PrefixSeq(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.rangePrefix(prefix, yield)
+	}
+}
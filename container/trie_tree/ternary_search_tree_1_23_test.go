@@ -0,0 +1,28 @@
+//go:build go1.23
+// +build go1.23
+
+package trie_tree
+
+import (
+	"testing"
+)
+
+func TestTernarySearchTreeSeq(t *testing.T) {
+	tst := NewTernarySearchTree()
+	for _, w := range []string{"to", "te", "tea", "ten"} {
+		tst.Insert(w)
+	}
+	var got []string
+	for w := range tst.PrefixSeq("te") {
+		got = append(got, w)
+	}
+	if len(got) != 3 || got[0] != "te" || got[1] != "tea" || got[2] != "ten" {
+		t.Errorf("Expected [te tea ten], got %v", got)
+	}
+	for w := range tst.WordSeq() {
+		if w != "te" {
+			t.Errorf("Expected first word 'te', got %q", w)
+		}
+		break
+	}
+}
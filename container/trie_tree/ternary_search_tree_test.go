@@ -0,0 +1,123 @@
+package trie_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTernarySearchTreeBasic(t *testing.T) {
+	tst := NewTernarySearchTree()
+
+	if tst.Len() != 0 || tst.StartsWith("") || tst.Search("a") {
+		t.Error("Expected empty tree")
+	}
+
+	words := []string{"hello", "help", "he", "hero", "world", "café", "测试"}
+	for _, w := range words {
+		tst.Insert(w)
+	}
+	tst.Insert("help")
+	tst.Insert("")
+
+	if tst.Len() != len(words) {
+		t.Errorf("Expected length %d, got %d", len(words), tst.Len())
+	}
+	for _, w := range words {
+		if !tst.Search(w) {
+			t.Errorf("Expected to find word '%s'", w)
+		}
+	}
+	for _, w := range []string{"", "h", "hel", "heroes", "caf", "测"} {
+		if tst.Search(w) {
+			t.Errorf("Expected not to find word '%s'", w)
+		}
+	}
+	for _, p := range []string{"", "h", "hel", "café", "测"} {
+		if !tst.StartsWith(p) {
+			t.Errorf("Expected to find prefix '%s'", p)
+		}
+	}
+	if tst.StartsWith("hi") || tst.StartsWith("helpx") {
+		t.Error("Expected missing prefixes not to be found")
+	}
+
+	want := []string{"café", "he", "hello", "help", "hero", "world", "测试"}
+	if got := tst.GetAllWords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	want = []string{"he", "hello", "help", "hero"}
+	if got := tst.GetWordsWithPrefix("he"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := tst.GetWordsWithPrefix("x"); len(got) != 0 {
+		t.Errorf("Expected no words, got %v", got)
+	}
+
+	tst.Clear()
+	if tst.Len() != 0 || tst.root != nil {
+		t.Error("Expected empty tree after Clear")
+	}
+}
+
+func TestTernarySearchTreeDelete(t *testing.T) {
+	tst := NewTernarySearchTree()
+	for _, w := range []string{"m", "d", "x", "da", "db", "dc"} {
+		tst.Insert(w)
+	}
+
+	if tst.Delete("dd") || tst.Delete("") || tst.Delete("q") {
+		t.Error("Expected false when deleting missing words")
+	}
+	if !tst.Delete("d") || tst.Search("d") || !tst.StartsWith("d") {
+		t.Error("Expected 'd' to be deleted while its extensions remain")
+	}
+	for _, w := range []string{"da", "db", "dc"} {
+		tst.Delete(w)
+	}
+	if tst.StartsWith("d") {
+		t.Error("Expected 'd' branch to be pruned")
+	}
+
+	// Deleting the root word merges its siblings in its place
+	if !tst.Delete("m") || tst.root == nil || tst.root.char != 'x' {
+		t.Error("Expected remaining sibling to become the root")
+	}
+	if !tst.Delete("x") || tst.root != nil || tst.Len() != 0 {
+		t.Error("Expected empty tree after deleting every word")
+	}
+}
+
+func TestTernarySearchTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tst := NewTernarySearchTree()
+	ref := NewTrie()
+
+	for i := 0; i < 5000; i++ {
+		w := fmt.Sprintf("%o", rng.Intn(4096))
+		if rng.Intn(3) == 0 {
+			if got, want := tst.Delete(w), ref.Delete(w); got != want {
+				t.Fatalf("Delete(%q) = %v, want %v", w, got, want)
+			}
+		} else {
+			tst.Insert(w)
+			ref.Insert(w)
+		}
+	}
+	if got, want := tst.GetAllWords(), ref.GetAllWords(); !reflect.DeepEqual(got, want) || tst.Len() != ref.Len() {
+		t.Errorf("Expected %d words, got %d", ref.Len(), tst.Len())
+	}
+	for _, p := range []string{"1", "27", "777", "8"} {
+		if got, want := tst.GetWordsWithPrefix(p), ref.GetWordsWithPrefix(p); !reflect.DeepEqual(got, want) {
+			t.Errorf("GetWordsWithPrefix(%q) = %v, want %v", p, got, want)
+		}
+		if got, want := tst.StartsWith(p), ref.StartsWith(p); got != want {
+			t.Errorf("StartsWith(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestTernarySearchTreeInterfaceCompliance(t *testing.T) {
+	var _ Interface = NewTernarySearchTree()
+}
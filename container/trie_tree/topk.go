@@ -0,0 +1,127 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements weighted words and top-k completion on Trie.
+
+package trie_tree
+
+import (
+	"container/heap"
+	"math"
+)
+
+// InsertWithWeight adds a word with the given weight, or updates the weight
+// of an existing word. Weights rank completions returned by TopK.
+func (t *Trie) InsertWithWeight(word string, weight float64) {
+	node, path, added := t.insert(word)
+	if node != nil {
+		t.setWeight(node, path, weight, added)
+	}
+}
+
+// Weight returns the weight of word and whether the word exists.
+func (t *Trie) Weight(word string) (float64, bool) {
+	word = t.key(word)
+	node := t.findNode(word)
+	if word == "" || node == nil || !node.isEnd {
+		return 0, false
+	}
+	return node.weight, true
+}
+
+// setWeight sets the weight of the word at node and updates the subtree
+// maxima cached along path.
+func (t *Trie) setWeight(node *trieNode, path []*trieNode, weight float64, added bool) {
+	old := node.weight
+	node.weight = weight
+	if added || weight >= old {
+		// A weight can only raise the maxima of its ancestors
+		for _, n := range path {
+			n.best = max(n.best, weight)
+		}
+		return
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].refreshBest()
+	}
+}
+
+// refreshBest recomputes the cached maximum of n from its own word and the
+// maxima of its children.
+func (n *trieNode) refreshBest() {
+	n.best = math.Inf(-1)
+	if n.isEnd {
+		n.best = n.weight
+	}
+	for _, child := range n.children {
+		n.best = max(n.best, child.best)
+	}
+}
+
+// TopK returns up to k words starting with prefix, ordered by descending
+// weight and then lexicographically.
+//
+// It runs a best-first search guided by the subtree maxima cached on every
+// node, so only the branches that can still contribute are expanded,
+// instead of listing and sorting every completion.
+func (t *Trie) TopK(prefix string, k int) []string {
+	prefix = t.key(prefix)
+	node := t.findNode(prefix)
+	if node == nil || k <= 0 || node.count == 0 {
+		return nil
+	}
+
+	words := make([]string, 0, min(k, node.count))
+	h := &completionHeap{{node: node, key: prefix, weight: node.best}}
+	for h.Len() > 0 && len(words) < k {
+		c := heap.Pop(h).(completion)
+		if c.word {
+			words = append(words, c.key)
+			continue
+		}
+		if c.node.isEnd {
+			heap.Push(h, completion{key: c.key, weight: c.node.weight, word: true})
+		}
+		for char, child := range c.node.children {
+			key := string(t.mode.appendUnit([]byte(c.key), char))
+			heap.Push(h, completion{node: child, key: key, weight: child.best})
+		}
+	}
+	return words
+}
+
+// completion is a TopK search candidate: either a finished word, or a
+// subtree whose best word has the given weight.
+type completion struct {
+	node   *trieNode // subtree to expand, nil for a word
+	key    string
+	weight float64
+	word   bool
+}
+
+// completionHeap orders candidates by descending weight, then by key, with
+// a word before a subtree of the same key. Since every word of a subtree
+// sorts after the subtree key, words pop in the order TopK returns them.
+type completionHeap []completion
+
+func (h completionHeap) Len() int { return len(h) }
+
+func (h completionHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if a.weight != b.weight {
+		return a.weight > b.weight
+	}
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.word && !b.word
+}
+
+func (h completionHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *completionHeap) Push(x any) { *h = append(*h, x.(completion)) }
+
+func (h *completionHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,97 @@
+package trie_tree
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTrieTopK(t *testing.T) {
+	trie := NewTrie()
+	trie.InsertWithWeight("apple", 5)
+	trie.InsertWithWeight("app", 9)
+	trie.InsertWithWeight("application", 7)
+	trie.InsertWithWeight("apply", 7)
+	trie.InsertWithWeight("banana", 10)
+	trie.Insert("apex")
+
+	tests := []struct {
+		prefix string
+		k      int
+		want   []string
+	}{
+		{"ap", 3, []string{"app", "application", "apply"}},
+		{"ap", 10, []string{"app", "application", "apply", "apple", "apex"}},
+		{"", 2, []string{"banana", "app"}},
+		{"appl", 1, []string{"application"}},
+		{"x", 3, nil},
+		{"ap", 0, nil},
+	}
+	for _, tt := range tests {
+		if got := trie.TopK(tt.prefix, tt.k); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TopK(%q, %d) = %v, want %v", tt.prefix, tt.k, got, tt.want)
+		}
+	}
+
+	// Lowering a weight and deleting words refresh the cached maxima
+	trie.InsertWithWeight("app", 1)
+	trie.Delete("application")
+	if got, want := trie.TopK("ap", 2), []string{"apply", "apple"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if w, ok := trie.Weight("app"); !ok || w != 1 {
+		t.Errorf("Expected weight 1, got %v, %v", w, ok)
+	}
+	if _, ok := trie.Weight("ap"); ok {
+		t.Error("Expected no weight for a missing word")
+	}
+
+	// Insert keeps the weight of an existing word
+	trie.Insert("apply")
+	if w, _ := trie.Weight("apply"); w != 7 {
+		t.Errorf("Expected Insert to keep weight 7, got %v", w)
+	}
+}
+
+func TestTrieTopKRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	trie := NewTrie()
+	ref := make(map[string]float64)
+	for i := 0; i < 2000; i++ {
+		b := make([]byte, rng.Intn(4)+1)
+		for j := range b {
+			b[j] = "abc"[rng.Intn(3)]
+		}
+		w := string(b)
+		if rng.Intn(4) == 0 {
+			trie.Delete(w)
+			delete(ref, w)
+		} else {
+			weight := float64(rng.Intn(20) - 10)
+			trie.InsertWithWeight(w, weight)
+			ref[w] = weight
+		}
+
+		prefix := string(b[:rng.Intn(len(b)+1)])
+		var want []string
+		for word := range ref {
+			if len(word) >= len(prefix) && word[:len(prefix)] == prefix {
+				want = append(want, word)
+			}
+		}
+		sort.Slice(want, func(i, j int) bool {
+			if ref[want[i]] != ref[want[j]] {
+				return ref[want[i]] > ref[want[j]]
+			}
+			return want[i] < want[j]
+		})
+		k := rng.Intn(5) + 1
+		if len(want) > k {
+			want = want[:k]
+		}
+		if got := trie.TopK(prefix, k); len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Fatalf("step %d: TopK(%q, %d) = %v, want %v", i, prefix, k, got, want)
+		}
+	}
+}
@@ -4,12 +4,57 @@
 package trie_tree
 
 import (
+	"math"
 	"sort"
+	"strings"
+	"unicode/utf8"
 )
 
+// unitMode selects how strings are decomposed into trie edges.
+type unitMode uint8
+
+const (
+	runeMode unitMode = iota // one edge per rune, invalid bytes decode as utf8.RuneError
+	byteMode                 // one edge per byte
+)
+
+// next returns the unit of s starting at byte offset i and its width in bytes.
+func (m unitMode) next(s string, i int) (rune, int) {
+	if m == byteMode {
+		return rune(s[i]), 1
+	}
+	if c := s[i]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(s[i:])
+}
+
+// appendUnit appends the encoding of unit c to b.
+func (m unitMode) appendUnit(b []byte, c rune) []byte {
+	if m == byteMode {
+		return append(b, byte(c))
+	}
+	return utf8.AppendRune(b, c)
+}
+
+// units decomposes s into its units.
+func (m unitMode) units(s string) []rune {
+	if m == runeMode {
+		return []rune(s)
+	}
+	units := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		units[i] = rune(s[i])
+	}
+	return units
+}
+
 // trieNode represents a node in the Trie tree.
 type trieNode struct {
 	children map[rune]*trieNode // children nodes mapped by character
+	count    int                // number of words ending at or below this node
+	weight   float64            // weight of the word ending here, valid when isEnd is true
+	best     float64            // highest word weight at or below this node, -Inf if none
 	isEnd    bool               // true if this node represents the end of a word
 }
 
@@ -17,48 +62,152 @@ type trieNode struct {
 func newTrieNode() *trieNode {
 	return &trieNode{
 		children: make(map[rune]*trieNode),
+		best:     math.Inf(-1),
 		isEnd:    false,
 	}
 }
 
+// sortedChars returns the characters of the node's children in ascending order.
+func (n *trieNode) sortedChars() []rune {
+	chars := make([]rune, 0, len(n.children))
+	for char := range n.children {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		return chars[i] < chars[j]
+	})
+	return chars
+}
+
 // Trie implements the Interface using a standard Trie data structure.
-// It uses a tree of nodes where each edge represents a character.
+// It uses a tree of nodes where each edge represents a character: a rune by
+// default, or a byte when created with WithByteMode.
 type Trie struct {
-	root *trieNode
-	size int // number of words stored
+	root      *trieNode
+	size      int                 // number of words stored
+	mode      unitMode            // how words are decomposed into edges
+	normalize func(string) string // applied to every word, prefix and query, nil for none
+}
+
+// Option configures a Trie created by NewTrie.
+type Option func(*trieOptions)
+
+type trieOptions struct {
+	mode       unitMode
+	normalizer func(string) string
+	foldCase   bool
+}
+
+// WithByteMode makes the trie decompose words into bytes instead of runes.
+// This skips UTF-8 decoding and stores arbitrary binary keys exactly,
+// including invalid UTF-8, which rune mode maps to utf8.RuneError.
+// Queries such as SearchFuzzy and SearchPattern then operate on bytes too.
+func WithByteMode() Option {
+	return func(o *trieOptions) {
+		o.mode = byteMode
+	}
+}
+
+// WithNormalizer applies normalize to every word on insert and to every
+// word, prefix and pattern passed to queries, so equivalent spellings share
+// one entry. Pass a Unicode normalization form such as norm.NFC.String from
+// golang.org/x/text/unicode/norm to treat "cafe\u0301" and "café" alike.
+// Stored words, and words returned by queries, are in normalized form.
+func WithNormalizer(normalize func(string) string) Option {
+	return func(o *trieOptions) {
+		o.normalizer = normalize
+	}
+}
+
+// WithCaseFolding makes the trie case-insensitive by lower-casing words,
+// prefixes and patterns with strings.ToLower, after any normalizer set by
+// WithNormalizer. Stored words, and words returned by queries, are lower case.
+func WithCaseFolding() Option {
+	return func(o *trieOptions) {
+		o.foldCase = true
+	}
 }
 
 // NewTrie creates a new Trie.
-func NewTrie() *Trie {
+func NewTrie(opts ...Option) *Trie {
+	var o trieOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	normalize := o.normalizer
+	if o.foldCase {
+		if normalize == nil {
+			normalize = strings.ToLower
+		} else {
+			inner := normalize
+			normalize = func(s string) string {
+				return strings.ToLower(inner(s))
+			}
+		}
+	}
+
 	return &Trie{
-		root: newTrieNode(),
-		size: 0,
+		root:      newTrieNode(),
+		size:      0,
+		mode:      o.mode,
+		normalize: normalize,
 	}
 }
 
-// Insert adds a word to the trie.
+// key returns s as stored in the trie, after normalization and case folding.
+func (t *Trie) key(s string) string {
+	if t.normalize == nil {
+		return s
+	}
+	return t.normalize(s)
+}
+
+// Insert adds a word to the trie. A new word gets weight 0; the weight of
+// an existing word is kept.
 func (t *Trie) Insert(word string) {
+	if node, path, added := t.insert(word); added {
+		t.setWeight(node, path, 0, true)
+	}
+}
+
+// insert adds a word and returns its node, the nodes on its path from the
+// root, and whether the word was newly added. Returns nil for the empty word.
+func (t *Trie) insert(word string) (*trieNode, []*trieNode, bool) {
+	word = t.key(word)
 	if word == "" {
-		return
+		return nil, nil, false
 	}
 
 	node := t.root
-	for _, char := range word {
-		if _, exists := node.children[char]; !exists {
-			node.children[char] = newTrieNode()
+	path := []*trieNode{node}
+	for i := 0; i < len(word); {
+		char, width := t.mode.next(word, i)
+		i += width
+		child, exists := node.children[char]
+		if !exists {
+			child = newTrieNode()
+			node.children[char] = child
 		}
-		node = node.children[char]
+		node = child
+		path = append(path, node)
 	}
 
-	// Mark the end of the word
-	if !node.isEnd {
-		node.isEnd = true
-		t.size++
+	// Mark the end of the word and count it on every node along the path
+	if node.isEnd {
+		return node, path, false
 	}
+	node.isEnd = true
+	t.size++
+	for _, n := range path {
+		n.count++
+	}
+	return node, path, true
 }
 
 // Search returns true if the word exists in the trie.
 func (t *Trie) Search(word string) bool {
+	word = t.key(word)
 	if word == "" {
 		return false
 	}
@@ -69,6 +218,7 @@ func (t *Trie) Search(word string) bool {
 
 // StartsWith returns true if there are any words in the trie that start with the given prefix.
 func (t *Trie) StartsWith(prefix string) bool {
+	prefix = t.key(prefix)
 	if prefix == "" {
 		return t.size > 0
 	}
@@ -78,51 +228,89 @@ func (t *Trie) StartsWith(prefix string) bool {
 
 // Delete removes a word from the trie and returns true if the word was found and removed.
 func (t *Trie) Delete(word string) bool {
+	word = t.key(word)
 	if word == "" {
 		return false
 	}
 
-	// First check if the word exists
-	node := t.findNode(word)
-	if node == nil || !node.isEnd {
+	// Record the path so it can be uncounted and pruned bottom-up
+	path := []*trieNode{t.root}
+	var chars []rune
+	node := t.root
+	for i := 0; i < len(word); {
+		char, width := t.mode.next(word, i)
+		i += width
+		child, exists := node.children[char]
+		if !exists {
+			return false
+		}
+		node = child
+		path = append(path, node)
+		chars = append(chars, char)
+	}
+	if !node.isEnd {
 		return false
 	}
 
-	// Word exists, so remove it
-	t.deleteHelper(t.root, word, 0)
+	node.isEnd = false
+	t.size--
+	for _, n := range path {
+		n.count--
+	}
+
+	// Remove nodes that no longer lead to any word
+	for i := len(chars) - 1; i >= 0 && path[i+1].count == 0; i-- {
+		delete(path[i].children, chars[i])
+		path = path[:i+1]
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].refreshBest()
+	}
 	return true
 }
 
-// deleteHelper is a recursive helper function for deletion.
-func (t *Trie) deleteHelper(node *trieNode, word string, index int) bool {
-	chars := []rune(word)
+// DeletePrefix removes every word that starts with the given prefix and
+// returns how many were removed. The subtree under the prefix is detached in
+// one step, in O(len(prefix)) regardless of how many words it holds.
+// An empty prefix removes all words.
+func (t *Trie) DeletePrefix(prefix string) int {
+	prefix = t.key(prefix)
+	if prefix == "" {
+		removed := t.size
+		t.Clear()
+		return removed
+	}
 
-	if index == len(chars) {
-		// We've reached the end of the word
-		if !node.isEnd {
-			return false // Word doesn't exist
+	path := []*trieNode{t.root}
+	var chars []rune
+	node := t.root
+	for i := 0; i < len(prefix); {
+		char, width := t.mode.next(prefix, i)
+		i += width
+		child, exists := node.children[char]
+		if !exists {
+			return 0
 		}
-		node.isEnd = false
-		t.size--
-		// Return true if current node has no children (can be deleted)
-		return len(node.children) == 0
+		node = child
+		path = append(path, node)
+		chars = append(chars, char)
 	}
 
-	char := chars[index]
-	childNode, exists := node.children[char]
-	if !exists {
-		return false // Word doesn't exist
+	removed := node.count
+	t.size -= removed
+	for _, n := range path {
+		n.count -= removed
 	}
 
-	shouldDeleteChild := t.deleteHelper(childNode, word, index+1)
-
-	if shouldDeleteChild {
-		delete(node.children, char)
-		// Return true if current node is not end of another word and has no children
-		return !node.isEnd && len(node.children) == 0
+	// Detach the subtree, then remove ancestors that no longer lead to any word
+	for i := len(chars) - 1; i >= 0 && path[i+1].count == 0; i-- {
+		delete(path[i].children, chars[i])
+		path = path[:i+1]
 	}
-
-	return false
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].refreshBest()
+	}
+	return removed
 }
 
 // Len returns the number of words stored in the trie.
@@ -136,10 +324,25 @@ func (t *Trie) Clear() {
 	t.size = 0
 }
 
+// CountWords returns the number of words stored in the trie.
+func (t *Trie) CountWords() int {
+	return t.size
+}
+
+// CountWordsWithPrefix returns the number of words that start with the given
+// prefix in O(len(prefix)), without enumerating them.
+func (t *Trie) CountWordsWithPrefix(prefix string) int {
+	node := t.findNode(t.key(prefix))
+	if node == nil {
+		return 0
+	}
+	return node.count
+}
+
 // GetAllWords returns a slice of all words stored in the trie in lexicographical order.
 func (t *Trie) GetAllWords() []string {
 	var words []string
-	t.collectWords(t.root, "", &words)
+	t.collectWords(t.root, nil, &words)
 	return words
 }
 
@@ -148,6 +351,7 @@ func (t *Trie) GetAllWords() []string {
 func (t *Trie) GetWordsWithPrefix(prefix string) []string {
 	var words []string
 
+	prefix = t.key(prefix)
 	if prefix == "" {
 		return t.GetAllWords()
 	}
@@ -158,43 +362,59 @@ func (t *Trie) GetWordsWithPrefix(prefix string) []string {
 	}
 
 	// Collect all words that start with the prefix
-	t.collectWords(prefixNode, prefix, &words)
+	t.collectWords(prefixNode, []byte(prefix), &words)
 
 	return words
 }
 
+// LongestPrefix returns the longest word in the trie that is a prefix of s,
+// and false if there is none. This is the lookup used for route and path
+// matching. With a normalizer or case folding, the match is a prefix of the
+// normalized s.
+func (t *Trie) LongestPrefix(s string) (string, bool) {
+	s = t.key(s)
+	match, found := 0, false
+	node := t.root
+	for i := 0; i < len(s); {
+		char, width := t.mode.next(s, i)
+		i += width
+		child, exists := node.children[char]
+		if !exists {
+			break
+		}
+		node = child
+		if node.isEnd {
+			match, found = i, true
+		}
+	}
+	return s[:match], found
+}
+
 // findNode traverses the trie to find the node representing the given string.
 // Returns nil if the string is not found.
 func (t *Trie) findNode(str string) *trieNode {
 	node := t.root
-	for _, char := range str {
-		if child, exists := node.children[char]; exists {
-			node = child
-		} else {
+	for i := 0; i < len(str); {
+		char, width := t.mode.next(str, i)
+		i += width
+		child, exists := node.children[char]
+		if !exists {
 			return nil
 		}
+		node = child
 	}
 	return node
 }
 
 // collectWords performs a depth-first search to collect all words from a given node.
-func (t *Trie) collectWords(node *trieNode, prefix string, words *[]string) {
+// path holds the key of node and is extended in place for the children.
+func (t *Trie) collectWords(node *trieNode, path []byte, words *[]string) {
 	if node.isEnd {
-		*words = append(*words, prefix)
-	}
-
-	// Get all children characters and sort them for consistent ordering
-	var chars []rune
-	for char := range node.children {
-		chars = append(chars, char)
+		*words = append(*words, string(path))
 	}
-	sort.Slice(chars, func(i, j int) bool {
-		return chars[i] < chars[j]
-	})
 
-	// Recursively collect words from children
-	for _, char := range chars {
-		child := node.children[char]
-		t.collectWords(child, prefix+string(char), words)
+	// Recursively collect words from children in sorted order
+	for _, char := range node.sortedChars() {
+		t.collectWords(node.children[char], t.mode.appendUnit(path, char), words)
 	}
 }
@@ -15,7 +15,7 @@ import (
 // Uses efficient depth-first traversal without pre-allocating all words.
 func (t *Trie) WordSeq() iter.Seq[string] {
 	return func(yield func(string) bool) {
-		collectWordsIterative(t.root, "", yield)
+		collectWordsIterative(t.mode, t.root, "", yield)
 	}
 }
 
@@ -24,9 +24,10 @@ func (t *Trie) WordSeq() iter.Seq[string] {
 // Uses efficient depth-first traversal without pre-allocating all words.
 func (t *Trie) PrefixSeq(prefix string) iter.Seq[string] {
 	return func(yield func(string) bool) {
+		prefix := t.key(prefix)
 		node := t.findNode(prefix)
 		if node != nil {
-			collectWordsIterative(node, prefix, yield)
+			collectWordsIterative(t.mode, node, prefix, yield)
 		}
 	}
 }
@@ -34,7 +35,7 @@ func (t *Trie) PrefixSeq(prefix string) iter.Seq[string] {
 // collectWordsIterative performs depth-first search to iterate over all words from a given node.
 // It yields words in lexicographical order and stops early if yield returns false.
 // Returns false if iteration should stop (early termination requested).
-func collectWordsIterative(node *trieNode, prefix string, yield func(string) bool) bool {
+func collectWordsIterative(mode unitMode, node *trieNode, prefix string, yield func(string) bool) bool {
 	if node == nil {
 		return true // Continue iteration
 	}
@@ -58,10 +59,26 @@ func collectWordsIterative(node *trieNode, prefix string, yield func(string) boo
 	// Recursively iterate through children in sorted order
 	for _, char := range chars {
 		child := node.children[char]
-		if !collectWordsIterative(child, prefix+string(char), yield) {
+		if !collectWordsIterative(mode, child, string(mode.appendUnit([]byte(prefix), char)), yield) {
 			return false // Propagate early termination
 		}
 	}
 
 	return true // Continue iteration
 }
+
+// FuzzySeq returns an iterator over all words within maxDistance edits of
+// word in lexicographical order (go1.23). See SearchFuzzy.
+func (t *Trie) FuzzySeq(word string, maxDistance int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.fuzzy(word, maxDistance, yield)
+	}
+}
+
+// PatternSeq returns an iterator over all words matching pattern in
+// lexicographical order (go1.23). See SearchPattern.
+func (t *Trie) PatternSeq(pattern string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.matchPattern(pattern, yield)
+	}
+}
@@ -149,3 +149,35 @@ func TestTrieIteratorUnicodeSupport(t *testing.T) {
 		t.Errorf("PrefixSeq(\"你\") = %v, want %v", prefixCollected, expectedPrefix)
 	}
 }
+
+func TestTrieFuzzySeq(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"cat", "bat", "rat", "dog"} {
+		trie.Insert(w)
+	}
+	var got []string
+	for w := range trie.FuzzySeq("hat", 1) {
+		got = append(got, w)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if len(got) != 2 || got[0] != "bat" || got[1] != "cat" {
+		t.Errorf("Expected [bat cat] before break, got %v", got)
+	}
+}
+
+func TestTriePatternSeq(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"car", "cat", "cut"} {
+		trie.Insert(w)
+	}
+	var got []string
+	for w := range trie.PatternSeq("c?t") {
+		got = append(got, w)
+		break
+	}
+	if len(got) != 1 || got[0] != "cat" {
+		t.Errorf("Expected [cat] before break, got %v", got)
+	}
+}
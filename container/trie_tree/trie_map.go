@@ -0,0 +1,232 @@
+// Package trie_tree provides a Trie (prefix tree) data structure implementation.
+// This file implements TrieMap, a trie that stores a value with every key.
+
+package trie_tree
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+// mapNode represents a node in a TrieMap.
+type mapNode[V any] struct {
+	children map[rune]*mapNode[V] // children nodes mapped by character
+	value    V                    // value of the key ending here, valid when isEnd is true
+	isEnd    bool                 // true if a key ends at this node
+}
+
+// newMapNode creates a new TrieMap node.
+func newMapNode[V any]() *mapNode[V] {
+	return &mapNode[V]{
+		children: make(map[rune]*mapNode[V]),
+	}
+}
+
+// sortedChars returns the characters of the node's children in ascending order.
+func (n *mapNode[V]) sortedChars() []rune {
+	chars := make([]rune, 0, len(n.children))
+	for char := range n.children {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		return chars[i] < chars[j]
+	})
+	return chars
+}
+
+// TrieMap is a prefix-keyed map: a Trie that stores a value with every key,
+// for uses such as mapping routes to handlers. Unlike Trie, the empty string
+// is a valid key.
+type TrieMap[V any] struct {
+	root *mapNode[V]
+	size int // number of keys stored
+}
+
+// NewTrieMap creates a new empty TrieMap.
+func NewTrieMap[V any]() *TrieMap[V] {
+	return &TrieMap[V]{
+		root: newMapNode[V](),
+	}
+}
+
+// Insert sets the value for key, replacing any previous value.
+func (t *TrieMap[V]) Insert(key string, value V) {
+	node := t.root
+	for _, char := range key {
+		child, exists := node.children[char]
+		if !exists {
+			child = newMapNode[V]()
+			node.children[char] = child
+		}
+		node = child
+	}
+
+	if !node.isEnd {
+		node.isEnd = true
+		t.size++
+	}
+	node.value = value
+}
+
+// Get returns the value stored for key and whether it exists.
+func (t *TrieMap[V]) Get(key string) (V, bool) {
+	node := t.findNode(key)
+	if node == nil || !node.isEnd {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Has returns true if key exists in the map.
+func (t *TrieMap[V]) Has(key string) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// StartsWith returns true if there are any keys that start with the given prefix.
+func (t *TrieMap[V]) StartsWith(prefix string) bool {
+	if prefix == "" {
+		return t.size > 0
+	}
+	return t.findNode(prefix) != nil
+}
+
+// Delete removes key from the map and returns true if it was found.
+// Nodes left without keys below them are pruned.
+func (t *TrieMap[V]) Delete(key string) bool {
+	path := []*mapNode[V]{t.root}
+	var chars []rune
+	node := t.root
+	for _, char := range key {
+		child, exists := node.children[char]
+		if !exists {
+			return false
+		}
+		node = child
+		path = append(path, node)
+		chars = append(chars, char)
+	}
+	if !node.isEnd {
+		return false
+	}
+
+	var zero V
+	node.isEnd = false
+	node.value = zero
+	t.size--
+
+	for i := len(chars) - 1; i >= 0; i-- {
+		child := path[i+1]
+		if child.isEnd || len(child.children) > 0 {
+			break
+		}
+		delete(path[i].children, chars[i])
+	}
+	return true
+}
+
+// Len returns the number of keys stored in the map.
+func (t *TrieMap[V]) Len() int {
+	return t.size
+}
+
+// Clear removes all keys from the map.
+func (t *TrieMap[V]) Clear() {
+	t.root = newMapNode[V]()
+	t.size = 0
+}
+
+// Keys returns all keys in lexicographical order.
+func (t *TrieMap[V]) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.Range(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Pairs returns all key-value pairs in lexicographical order of keys.
+func (t *TrieMap[V]) Pairs() []pair.Pair[string, V] {
+	return t.GetWordsWithPrefix("")
+}
+
+// GetWordsWithPrefix returns the key-value pairs whose keys start with the
+// given prefix, in lexicographical order of keys.
+func (t *TrieMap[V]) GetWordsWithPrefix(prefix string) []pair.Pair[string, V] {
+	var pairs []pair.Pair[string, V]
+	t.RangePrefix(prefix, func(key string, value V) bool {
+		pairs = append(pairs, pair.Pair[string, V]{First: key, Second: value})
+		return true
+	})
+	return pairs
+}
+
+// Range calls fn for every key-value pair in lexicographical order of keys.
+// If fn returns false, the iteration stops.
+func (t *TrieMap[V]) Range(fn func(key string, value V) bool) {
+	collectPairs(t.root, []rune{}, fn)
+}
+
+// RangePrefix calls fn for every key-value pair whose key starts with the
+// given prefix, in lexicographical order of keys.
+// If fn returns false, the iteration stops.
+func (t *TrieMap[V]) RangePrefix(prefix string, fn func(key string, value V) bool) {
+	if node := t.findNode(prefix); node != nil {
+		collectPairs(node, []rune(prefix), fn)
+	}
+}
+
+// LongestPrefix returns the longest key in the map that is a prefix of s,
+// together with its value, and false if there is none.
+func (t *TrieMap[V]) LongestPrefix(s string) (string, V, bool) {
+	node := t.root
+	match, value, found := 0, node.value, node.isEnd
+	for i, char := range s {
+		child, exists := node.children[char]
+		if !exists {
+			break
+		}
+		node = child
+		if node.isEnd {
+			match, value, found = i+utf8.RuneLen(char), node.value, true
+		}
+	}
+	if !found {
+		var zero V
+		return "", zero, false
+	}
+	return s[:match], value, true
+}
+
+// findNode traverses the trie to find the node representing the given string.
+// Returns nil if the string is not found.
+func (t *TrieMap[V]) findNode(str string) *mapNode[V] {
+	node := t.root
+	for _, char := range str {
+		child, exists := node.children[char]
+		if !exists {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+// collectPairs performs a depth-first traversal from node, whose key is
+// path, calling fn for every stored key in lexicographical order.
+// Returns false if fn requested the iteration to stop.
+func collectPairs[V any](node *mapNode[V], path []rune, fn func(string, V) bool) bool {
+	if node.isEnd && !fn(string(path), node.value) {
+		return false
+	}
+	for _, char := range node.sortedChars() {
+		if !collectPairs(node.children[char], append(path, char), fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+// +build go1.23
+
+// Package trie_tree provides go1.23-specific methods for TrieMap.
+// This file adds iter.Seq2 related methods for TrieMap.
+
+package trie_tree
+
+import (
+	"iter"
+)
+
+// PairSeq returns an iterator over all key-value pairs in lexicographical
+// order of keys (go1.23).
+func (t *TrieMap[V]) PairSeq() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.Range(yield)
+	}
+}
+
+// PrefixPairSeq returns an iterator over the key-value pairs whose keys
+// start with the given prefix, in lexicographical order of keys (go1.23).
+func (t *TrieMap[V]) PrefixPairSeq(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.RangePrefix(prefix, yield)
+	}
+}
+
+// NewTrieMapFromSeq creates a trie map of the key-value pairs of seq
+// (go1.23). When a key repeats, the last value wins.
+func NewTrieMapFromSeq[V any](seq iter.Seq2[string, V]) *TrieMap[V] {
+	t := NewTrieMap[V]()
+	for k, v := range seq {
+		t.Insert(k, v)
+	}
+	return t
+}
@@ -0,0 +1,42 @@
+//go:build go1.23
+// +build go1.23
+
+package trie_tree
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestTrieMapPairSeq(t *testing.T) {
+	m := NewTrieMap[int]()
+	m.Insert("b", 2)
+	m.Insert("a", 1)
+	m.Insert("ab", 3)
+
+	var keys []string
+	sum := 0
+	for k, v := range m.PairSeq() {
+		keys = append(keys, k)
+		sum += v
+	}
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "ab" || sum != 6 {
+		t.Errorf("Unexpected iteration result %v, sum %d", keys, sum)
+	}
+
+	keys = nil
+	for k := range m.PrefixPairSeq("a") {
+		keys = append(keys, k)
+		break
+	}
+	if len(keys) != 1 || keys[0] != "a" {
+		t.Errorf("Expected early stop after 'a', got %v", keys)
+	}
+}
+
+func TestNewTrieMapFromSeq(t *testing.T) {
+	tm := NewTrieMapFromSeq(maps.All(map[string]int{"x": 1, "xy": 2}))
+	if v, ok := tm.Get("xy"); !ok || v != 2 || tm.Len() != 2 {
+		t.Errorf("Expected 2 keys with xy=2, got %d keys and xy=%d", tm.Len(), v)
+	}
+}
@@ -0,0 +1,119 @@
+package trie_tree
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/pair"
+)
+
+func TestTrieMapBasic(t *testing.T) {
+	m := NewTrieMap[int]()
+	if m.Len() != 0 || m.StartsWith("") {
+		t.Error("Expected empty map")
+	}
+
+	m.Insert("/users", 1)
+	m.Insert("/users/list", 2)
+	m.Insert("/posts", 3)
+	m.Insert("/users", 10) // replace
+
+	if m.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", m.Len())
+	}
+	if v, ok := m.Get("/users"); !ok || v != 10 {
+		t.Errorf("Expected /users -> 10, got %d, %v", v, ok)
+	}
+	if _, ok := m.Get("/user"); ok {
+		t.Error("Expected prefix of a key not to be found")
+	}
+	if !m.Has("/posts") || m.Has("/nope") {
+		t.Error("Unexpected Has result")
+	}
+	if !m.StartsWith("/us") || m.StartsWith("/x") {
+		t.Error("Unexpected StartsWith result")
+	}
+
+	// The empty key is a regular key
+	m.Insert("", 0)
+	if v, ok := m.Get(""); !ok || v != 0 || m.Len() != 4 {
+		t.Errorf("Expected empty key to be stored, got %d, %v", v, ok)
+	}
+}
+
+func TestTrieMapPrefix(t *testing.T) {
+	m := NewTrieMap[string]()
+	m.Insert("café", "a")
+	m.Insert("car", "b")
+	m.Insert("cart", "c")
+	m.Insert("dog", "d")
+
+	want := []pair.Pair[string, string]{{First: "car", Second: "b"}, {First: "cart", Second: "c"}}
+	if got := m.GetWordsWithPrefix("car"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := m.GetWordsWithPrefix("x"); len(got) != 0 {
+		t.Errorf("Expected no pairs, got %v", got)
+	}
+	if got, want := m.Keys(), []string{"café", "car", "cart", "dog"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected keys %v, got %v", want, got)
+	}
+	if got := m.Pairs(); len(got) != 4 || got[0].Second != "a" {
+		t.Errorf("Unexpected pairs %v", got)
+	}
+
+	var visited []string
+	m.Range(func(key, _ string) bool {
+		visited = append(visited, key)
+		return len(visited) < 2
+	})
+	if len(visited) != 2 {
+		t.Errorf("Expected Range to stop after 2 keys, got %v", visited)
+	}
+}
+
+func TestTrieMapDelete(t *testing.T) {
+	m := NewTrieMap[int]()
+	m.Insert("ab", 1)
+	m.Insert("abcd", 2)
+
+	if m.Delete("abc") || m.Delete("x") {
+		t.Error("Expected false when deleting missing keys")
+	}
+	if !m.Delete("abcd") {
+		t.Error("Expected true when deleting existing key")
+	}
+	if m.Len() != 1 || m.StartsWith("abc") {
+		t.Error("Expected branch of deleted key to be pruned")
+	}
+	if v, ok := m.Get("ab"); !ok || v != 1 {
+		t.Errorf("Expected ab -> 1 to remain, got %d, %v", v, ok)
+	}
+
+	m.Clear()
+	if m.Len() != 0 || m.Has("ab") {
+		t.Error("Expected empty map after Clear")
+	}
+}
+
+func TestTrieMapLongestPrefix(t *testing.T) {
+	m := NewTrieMap[string]()
+	m.Insert("/api", "api")
+	m.Insert("/api/v1", "v1")
+
+	if key, v, ok := m.LongestPrefix("/api/v1/users"); !ok || key != "/api/v1" || v != "v1" {
+		t.Errorf("Expected /api/v1 -> v1, got %q -> %q, %v", key, v, ok)
+	}
+	if key, v, ok := m.LongestPrefix("/api/v"); !ok || key != "/api" || v != "api" {
+		t.Errorf("Expected /api -> api, got %q -> %q, %v", key, v, ok)
+	}
+	if _, _, ok := m.LongestPrefix("/static"); ok {
+		t.Error("Expected no match for /static")
+	}
+
+	// A value at the empty key acts as the default route
+	m.Insert("", "default")
+	if key, v, ok := m.LongestPrefix("/static"); !ok || key != "" || v != "default" {
+		t.Errorf("Expected default route, got %q -> %q, %v", key, v, ok)
+	}
+}
@@ -2,6 +2,7 @@ package trie_tree
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -349,3 +350,193 @@ func TestTrieComplexScenario(t *testing.T) {
 		t.Error("Expected other 'be' words to remain after deleting 'bee'")
 	}
 }
+
+func TestTrieLongestPrefix(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"10.0", "10.0.0", "10.1", "日本"} {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		input, want string
+		ok          bool
+	}{
+		{"10.0.0.1", "10.0.0", true},
+		{"10.0.1", "10.0", true},
+		{"10.1", "10.1", true},
+		{"10.2", "", false},
+		{"日本語", "日本", true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := trie.LongestPrefix(tt.input); got != tt.want || ok != tt.ok {
+			t.Errorf("LongestPrefix(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTrieCountWordsWithPrefix(t *testing.T) {
+	trie := NewTrie()
+	for _, w := range []string{"car", "card", "care", "cat", "dog", "car"} {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		prefix string
+		want   int
+	}{
+		{"", 5}, {"c", 4}, {"car", 3}, {"card", 1}, {"ca", 4}, {"d", 1}, {"x", 0}, {"cards", 0},
+	}
+	for _, tt := range tests {
+		if got := trie.CountWordsWithPrefix(tt.prefix); got != tt.want {
+			t.Errorf("CountWordsWithPrefix(%q) = %d, want %d", tt.prefix, got, tt.want)
+		}
+	}
+
+	trie.Delete("car")
+	trie.Delete("cards") // missing, must not change counts
+	if got := trie.CountWordsWithPrefix("car"); got != 2 {
+		t.Errorf("Expected 2 words with prefix 'car' after delete, got %d", got)
+	}
+	if trie.CountWords() != 4 || trie.CountWordsWithPrefix("") != 4 {
+		t.Errorf("Expected 4 words, got %d", trie.CountWords())
+	}
+}
+
+func TestTrieByteMode(t *testing.T) {
+	trie := NewTrie(WithByteMode())
+	keys := []string{"\xff\x00", "\xff\x01", "é", "e", "\xc3"}
+	for _, k := range keys {
+		trie.Insert(k)
+	}
+
+	for _, k := range keys {
+		if !trie.Search(k) {
+			t.Errorf("Expected to find %q", k)
+		}
+	}
+	want := []string{"e", "\xc3", "é", "\xff\x00", "\xff\x01"}
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// "é" is two bytes, so "\xc3" is a stored word and a prefix of it
+	if got := trie.CountWordsWithPrefix("\xc3"); got != 2 {
+		t.Errorf("Expected 2 words with prefix \\xc3, got %d", got)
+	}
+	if got, ok := trie.LongestPrefix("é!"); !ok || got != "é" {
+		t.Errorf("Expected longest prefix 'é', got %q, %v", got, ok)
+	}
+	if got := trie.SearchPattern("?"); !reflect.DeepEqual(got, []string{"e", "\xc3"}) {
+		t.Errorf("Expected '?' to match single bytes, got %q", got)
+	}
+	if got := trie.SearchFuzzy("e", 1); !reflect.DeepEqual(got, []string{"e", "\xc3"}) {
+		t.Errorf("Expected byte-level edit distance, got %q", got)
+	}
+
+	if !trie.Delete("\xff\x00") || trie.Search("\xff\x00") || !trie.Search("\xff\x01") {
+		t.Error("Unexpected result deleting a binary key")
+	}
+
+	data, _ := trie.MarshalBinary()
+	decoded := NewTrie(WithByteMode())
+	if err := decoded.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(decoded.GetAllWords(), trie.GetAllWords()) {
+		t.Errorf("Expected byte mode round trip, got %q, %v", decoded.GetAllWords(), err)
+	}
+}
+
+func TestTrieRuneModeInvalidUTF8(t *testing.T) {
+	trie := NewTrie()
+	trie.Insert("a\xffb")
+
+	// Invalid bytes decode to utf8.RuneError in rune mode
+	if !trie.Search("a\xfeb") {
+		t.Error("Expected invalid bytes to be treated alike in rune mode")
+	}
+	if got, ok := trie.LongestPrefix("a\xffbc"); !ok || got != "a\xffb" {
+		t.Errorf("Expected longest prefix %q, got %q, %v", "a\xffb", got, ok)
+	}
+}
+
+func TestTrieCaseFoldingAndNormalizer(t *testing.T) {
+	// A stand-in for norm.NFC.String that composes decomposed e/E + acute accent
+	nfc := strings.NewReplacer("e\u0301", "\u00e9", "E\u0301", "\u00c9").Replace
+	trie := NewTrie(WithCaseFolding(), WithNormalizer(nfc))
+
+	trie.Insert("Caf\u00e9")
+	trie.Insert("caf\u00e9")
+	trie.Insert("cafe\u0301")
+	trie.Insert("CAFE\u0301S")
+
+	if trie.Len() != 2 {
+		t.Errorf("Expected 2 distinct words, got %q", trie.GetAllWords())
+	}
+	for _, q := range []string{"CAF\u00c9", "CAFE\u0301", "cafe\u0301", "Caf\u00e9"} {
+		if !trie.Search(q) {
+			t.Errorf("Expected to find %q", q)
+		}
+	}
+	if got := trie.GetWordsWithPrefix("CAF"); !reflect.DeepEqual(got, []string{"caf\u00e9", "caf\u00e9s"}) {
+		t.Errorf("Expected normalized words, got %q", got)
+	}
+	if !trie.StartsWith("CAFE\u0301") || trie.CountWordsWithPrefix("Caf\u00e9") != 2 {
+		t.Error("Expected prefix queries to be normalized")
+	}
+	if got, ok := trie.LongestPrefix("CAF\u00c9T\u00c9RIA"); !ok || got != "caf\u00e9" {
+		t.Errorf("Expected longest prefix %q, got %q, %v", "caf\u00e9", got, ok)
+	}
+	if got := trie.SearchPattern("C?F\u00c9*"); len(got) != 2 {
+		t.Errorf("Expected patterns to be normalized, got %q", got)
+	}
+	if !trie.Delete("CAF\u00c9S") || trie.Len() != 1 {
+		t.Error("Expected Delete to be normalized")
+	}
+
+	trie.Clear()
+	trie.Insert("ABC")
+	if !trie.Search("abc") {
+		t.Error("Expected options to survive Clear")
+	}
+}
+
+func TestTrieDeletePrefix(t *testing.T) {
+	trie := NewTrie()
+	words := []string{"user:1", "user:12", "user:123:name", "user:123:mail", "user:2", "users"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	trie.InsertWithWeight("user:2", 5)
+	trie.InsertWithWeight("user:123:name", 9)
+
+	if got := trie.DeletePrefix("user:123:"); got != 2 {
+		t.Errorf("Expected 2 words removed, got %d", got)
+	}
+	if trie.Len() != 4 || trie.CountWordsWithPrefix("user:") != 3 {
+		t.Errorf("Expected counts to be updated, got %d words", trie.Len())
+	}
+	if trie.StartsWith("user:123") {
+		t.Error("Expected 'user:123' branch to be pruned")
+	}
+	if !trie.Search("user:12") {
+		t.Error("Expected 'user:12' to survive")
+	}
+	if got := trie.TopK("user", 1); !reflect.DeepEqual(got, []string{"user:2"}) {
+		t.Errorf("Expected cached weights to be refreshed, got %v", got)
+	}
+
+	if trie.DeletePrefix("admin") != 0 || trie.DeletePrefix("user:3") != 0 {
+		t.Error("Expected 0 for missing prefixes")
+	}
+
+	// A prefix that is itself a word is removed too
+	if got := trie.DeletePrefix("user:1"); got != 2 {
+		t.Errorf("Expected 2 words removed, got %d", got)
+	}
+	if got := trie.GetAllWords(); !reflect.DeepEqual(got, []string{"user:2", "users"}) {
+		t.Errorf("Expected [user:2 users], got %v", got)
+	}
+
+	if got := trie.DeletePrefix(""); got != 2 || trie.Len() != 0 {
+		t.Errorf("Expected empty prefix to remove all words, got %d", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Package txn provides a small helper that groups mutations across several
+// containers and applies them as a unit. Mutations are staged first and
+// applied on Commit; if any step fails, the steps already applied are undone
+// in reverse order, so multi-structure invariants (a map plus its secondary
+// index, for example) are never left half-updated.
+//
+// Rollback uses an undo log rather than Clone or copy-on-write snapshots:
+// each applied step records how to revert itself, such as restoring the
+// previous value of a key. A transaction therefore costs O(changes) rather
+// than O(size) of the containers, and works with any Map, including ones
+// that cannot be cloned.
+//
+// Txn is meant for single-threaded code paths. It provides atomicity with
+// respect to errors, not isolation from concurrent goroutines.
+package txn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDone is returned when staging into or committing a transaction that has
+// already been committed or discarded.
+var ErrDone = errors.New("txn: transaction already finished")
+
+// Map is the subset of map operations Txn needs to stage key mutations.
+// It is satisfied by ordered_map.RedBlackTree, ordered_map.SplayTree and
+// skip_list.Interface.
+type Map[K any, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Delete(key K) bool
+}
+
+// step is a staged mutation and the action that reverts it.
+type step struct {
+	apply func() error
+	undo  func()
+}
+
+// Txn collects staged mutations and applies them on Commit.
+// The zero value is an empty transaction ready to use.
+type Txn struct {
+	steps []step
+	done  bool
+}
+
+// New creates a new empty transaction.
+func New() *Txn {
+	return &Txn{}
+}
+
+// Len returns the number of staged steps.
+func (t *Txn) Len() int {
+	return len(t.steps)
+}
+
+// Stage adds a custom step. apply runs on Commit; undo runs if a later step
+// fails and must revert exactly what apply did. undo may be nil for steps
+// without side effects, such as validations.
+func (t *Txn) Stage(apply func() error, undo func()) error {
+	if t.done {
+		return ErrDone
+	}
+	t.steps = append(t.steps, step{apply: apply, undo: undo})
+	return nil
+}
+
+// Check stages a validation step. If check returns an error during Commit,
+// every step applied before it is rolled back.
+func (t *Txn) Check(check func() error) error {
+	return t.Stage(check, nil)
+}
+
+// Commit applies the staged steps in order. If a step returns an error, the
+// steps already applied are undone in reverse order and the error is
+// returned, wrapped with the index of the failing step.
+// A transaction can be committed only once.
+func (t *Txn) Commit() error {
+	if t.done {
+		return ErrDone
+	}
+	t.done = true
+
+	for i, s := range t.steps {
+		if err := s.apply(); err != nil {
+			rollback(t.steps[:i])
+			return fmt.Errorf("txn: step %d: %w", i, err)
+		}
+	}
+	t.steps = nil
+	return nil
+}
+
+// Discard drops all staged steps without applying them.
+func (t *Txn) Discard() {
+	t.done = true
+	t.steps = nil
+}
+
+// rollback undoes applied steps from last to first.
+func rollback(applied []step) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if undo := applied[i].undo; undo != nil {
+			undo()
+		}
+	}
+}
+
+// Set stages m.Set(key, value). On rollback the previous value is restored,
+// or the key is deleted if it did not exist before.
+func Set[K any, V any](t *Txn, m Map[K, V], key K, value V) error {
+	var old V
+	var existed bool
+	return t.Stage(func() error {
+		// Capture the previous state at apply time, after earlier steps ran
+		old, existed = m.Get(key)
+		m.Set(key, value)
+		return nil
+	}, func() {
+		if existed {
+			m.Set(key, old)
+		} else {
+			m.Delete(key)
+		}
+	})
+}
+
+// Delete stages m.Delete(key). On rollback the removed value is restored.
+// Deleting a missing key is not an error.
+func Delete[K any, V any](t *Txn, m Map[K, V], key K) error {
+	var old V
+	var existed bool
+	return t.Stage(func() error {
+		old, existed = m.Get(key)
+		if existed {
+			m.Delete(key)
+		}
+		return nil
+	}, func() {
+		if existed {
+			m.Set(key, old)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package txn
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/feepwang/br/container/ordered_map"
+)
+
+func TestTxnCommit(t *testing.T) {
+	users := ordered_map.NewRedBlackTree[int, string]()
+	byName := ordered_map.NewRedBlackTree[string, int]()
+
+	tx := New()
+	for _, err := range []error{
+		Set[int, string](tx, users, 1, "alice"),
+		Set[string, int](tx, byName, "alice", 1),
+	} {
+		if err != nil {
+			t.Fatalf("staging error = %v", err)
+		}
+	}
+
+	// Nothing is applied before Commit
+	if users.Len() != 0 || byName.Len() != 0 {
+		t.Error("Expected staged steps not to be applied before Commit")
+	}
+	if tx.Len() != 2 {
+		t.Errorf("Expected 2 staged steps, got %d", tx.Len())
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if v, _ := users.Get(1); v != "alice" {
+		t.Errorf("Expected users[1] = alice, got %q", v)
+	}
+	if v, _ := byName.Get("alice"); v != 1 {
+		t.Errorf("Expected byName[alice] = 1, got %d", v)
+	}
+
+	if err := tx.Commit(); !errors.Is(err, ErrDone) {
+		t.Errorf("Expected ErrDone on second Commit, got %v", err)
+	}
+	if err := tx.Check(func() error { return nil }); !errors.Is(err, ErrDone) {
+		t.Errorf("Expected ErrDone when staging after Commit, got %v", err)
+	}
+}
+
+func TestTxnRollback(t *testing.T) {
+	users := ordered_map.NewRedBlackTree[int, string]()
+	byName := ordered_map.NewRedBlackTree[string, int]()
+	users.Set(1, "alice")
+	byName.Set("alice", 1)
+
+	// Rename alice to bob, then fail validation
+	errInvalid := errors.New("invalid")
+	tx := New()
+	for _, err := range []error{
+		Set[int, string](tx, users, 1, "bob"),
+		Delete[string, int](tx, byName, "alice"),
+		Set[string, int](tx, byName, "bob", 1),
+		Set[int, string](tx, users, 2, "carol"),
+		tx.Check(func() error { return errInvalid }),
+	} {
+		if err != nil {
+			t.Fatalf("staging error = %v", err)
+		}
+	}
+
+	err := tx.Commit()
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("Expected wrapped errInvalid, got %v", err)
+	}
+
+	// Everything is back to the state before Commit
+	if !reflect.DeepEqual(users.Keys(), []int{1}) {
+		t.Errorf("Expected users keys [1], got %v", users.Keys())
+	}
+	if v, _ := users.Get(1); v != "alice" {
+		t.Errorf("Expected users[1] restored to alice, got %q", v)
+	}
+	if !reflect.DeepEqual(byName.Keys(), []string{"alice"}) {
+		t.Errorf("Expected byName keys [alice], got %v", byName.Keys())
+	}
+}
+
+func TestTxnCustomStepAndDiscard(t *testing.T) {
+	var log []string
+	tx := New()
+	if err := tx.Stage(func() error {
+		log = append(log, "a")
+		return nil
+	}, func() {
+		log = append(log, "undo a")
+	}); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	if err := tx.Stage(func() error {
+		return errors.New("boom")
+	}, func() {
+		log = append(log, "undo b")
+	}); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+
+	if err := tx.Commit(); err == nil {
+		t.Fatal("Expected error from failing step")
+	}
+	// The failing step itself is not undone, only the ones before it
+	if want := []string{"a", "undo a"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("Expected log %v, got %v", want, log)
+	}
+
+	applied := false
+	tx = New()
+	if err := tx.Stage(func() error {
+		applied = true
+		return nil
+	}, nil); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	tx.Discard()
+	if err := tx.Commit(); !errors.Is(err, ErrDone) {
+		t.Errorf("Expected ErrDone after Discard, got %v", err)
+	}
+	if applied {
+		t.Error("Expected discarded step not to be applied")
+	}
+}
@@ -0,0 +1,163 @@
+// Package verify cross-checks a primary container against indexes derived
+// from it and reports every divergence together with the offending keys.
+// It is intended to run periodically in debug builds of long-running
+// services that keep secondary indexes in sync by hand.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Source is the primary container being verified.
+// It is satisfied by ordered_map.RedBlackTree and skip_list.Interface.
+type Source[K any, V any] interface {
+	Get(key K) (V, bool)
+	Range(fn func(key K, value V) bool)
+}
+
+// Kind classifies a divergence between a primary container and an index.
+type Kind int
+
+const (
+	// Missing means the index has no entry for a primary key.
+	Missing Kind = iota
+	// Mismatch means the index entry points at a different primary key.
+	Mismatch
+	// Stale means the index has an entry that no primary entry derives.
+	Stale
+)
+
+// String returns the name of the divergence kind.
+func (k Kind) String() string {
+	switch k {
+	case Missing:
+		return "missing"
+	case Mismatch:
+		return "mismatch"
+	case Stale:
+		return "stale"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Divergence describes a single inconsistency found by Check.
+type Divergence struct {
+	Index    string // name of the index
+	Kind     Kind
+	Key      any // primary key involved, nil for stale entries without one
+	IndexKey any // derived index key involved
+	Detail   string
+}
+
+// String returns a human-readable description of the divergence.
+func (d Divergence) String() string {
+	return fmt.Sprintf("%s: %s entry for key %v (index key %v): %s", d.Index, d.Kind, d.Key, d.IndexKey, d.Detail)
+}
+
+// Report collects the divergences found by Check.
+type Report struct {
+	Checked     int // number of primary entries examined
+	Divergences []Divergence
+}
+
+// OK reports whether no divergence was found.
+func (r *Report) OK() bool {
+	return len(r.Divergences) == 0
+}
+
+// Err returns nil if the report is clean, or an error listing every divergence.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	lines := make([]string, 0, len(r.Divergences))
+	for _, d := range r.Divergences {
+		lines = append(lines, d.String())
+	}
+	return errors.New("verify: " + strings.Join(lines, "; "))
+}
+
+func (r *Report) add(d Divergence) {
+	r.Divergences = append(r.Divergences, d)
+}
+
+// Index is a derived index that Check can verify against a primary container.
+// Create one with NewIndex.
+type Index[K comparable, V any] interface {
+	verify(primary Source[K, V], r *Report)
+}
+
+// IndexSpec describes how an index is derived from the primary container
+// and how to read it back.
+type IndexSpec[K comparable, V any, IK comparable] struct {
+	// Name identifies the index in reports.
+	Name string
+
+	// Derive returns the index key for a primary entry, or false if the
+	// entry is not indexed (for example, a partial index).
+	Derive func(key K, value V) (IK, bool)
+
+	// Lookup returns the primary key stored in the index under ik.
+	Lookup func(ik IK) (K, bool)
+
+	// Range, if set, enumerates the index so entries that no primary entry
+	// derives can be reported as stale. Without it only missing and
+	// mismatched entries are detected.
+	Range func(fn func(ik IK, key K) bool)
+}
+
+// NewIndex returns an Index for the given spec.
+func NewIndex[K comparable, V any, IK comparable](spec IndexSpec[K, V, IK]) Index[K, V] {
+	return spec
+}
+
+func (s IndexSpec[K, V, IK]) verify(primary Source[K, V], r *Report) {
+	primary.Range(func(key K, value V) bool {
+		ik, ok := s.Derive(key, value)
+		if !ok {
+			return true
+		}
+		got, found := s.Lookup(ik)
+		switch {
+		case !found:
+			r.add(Divergence{Index: s.Name, Kind: Missing, Key: key, IndexKey: ik,
+				Detail: "primary entry is not indexed"})
+		case got != key:
+			r.add(Divergence{Index: s.Name, Kind: Mismatch, Key: key, IndexKey: ik,
+				Detail: fmt.Sprintf("index points at key %v", got)})
+		}
+		return true
+	})
+
+	if s.Range == nil {
+		return
+	}
+	s.Range(func(ik IK, key K) bool {
+		value, ok := primary.Get(key)
+		if !ok {
+			r.add(Divergence{Index: s.Name, Kind: Stale, Key: key, IndexKey: ik,
+				Detail: "primary key does not exist"})
+			return true
+		}
+		if derived, ok := s.Derive(key, value); !ok || derived != ik {
+			r.add(Divergence{Index: s.Name, Kind: Stale, Key: key, IndexKey: ik,
+				Detail: "primary entry no longer derives this index key"})
+		}
+		return true
+	})
+}
+
+// Check verifies every index against primary and returns a report.
+func Check[K comparable, V any](primary Source[K, V], indexes ...Index[K, V]) *Report {
+	r := &Report{}
+	primary.Range(func(K, V) bool {
+		r.Checked++
+		return true
+	})
+	for _, idx := range indexes {
+		idx.verify(primary, r)
+	}
+	return r
+}
@@ -0,0 +1,35 @@
+//go:build go1.23
+// +build go1.23
+
+package verify
+
+import (
+	"testing"
+
+	"github.com/feepwang/br/container/skip_list"
+)
+
+func TestCheckPartialIndexOnSkipList(t *testing.T) {
+	users := skip_list.NewOrderedSkipList[int, user]()
+	users.Set(1, user{Name: "alice", Email: "a@example.com"})
+	users.Set(2, user{Name: "bob"})
+
+	byEmail := map[string]int{"a@example.com": 1}
+	idx := NewIndex(IndexSpec[int, user, string]{
+		Name: "byEmail",
+		Derive: func(_ int, u user) (string, bool) {
+			return u.Email, u.Email != ""
+		},
+		Lookup: func(email string) (int, bool) {
+			id, ok := byEmail[email]
+			return id, ok
+		},
+	})
+
+	if r := Check[int, user](users, idx); !r.OK() {
+		t.Errorf("Expected clean report, got %v", r.Err())
+	}
+	if Stale.String() != "stale" || Kind(9).String() != "Kind(9)" {
+		t.Error("Unexpected Kind names")
+	}
+}
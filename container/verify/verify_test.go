@@ -0,0 +1,71 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/feepwang/br/container/ordered_map"
+)
+
+type user struct {
+	Name  string
+	Email string
+}
+
+func byNameIndex(idx *ordered_map.RedBlackTree[string, int]) Index[int, user] {
+	return NewIndex(IndexSpec[int, user, string]{
+		Name: "byName",
+		Derive: func(_ int, u user) (string, bool) {
+			return u.Name, true
+		},
+		Lookup: idx.Get,
+		Range:  idx.Range,
+	})
+}
+
+func TestCheckConsistent(t *testing.T) {
+	users := ordered_map.NewRedBlackTree[int, user]()
+	byName := ordered_map.NewRedBlackTree[string, int]()
+	for id, name := range []string{"alice", "bob", "carol"} {
+		users.Set(id, user{Name: name})
+		byName.Set(name, id)
+	}
+
+	r := Check[int, user](users, byNameIndex(byName))
+	if !r.OK() || r.Err() != nil {
+		t.Errorf("Expected clean report, got %v", r.Err())
+	}
+	if r.Checked != 3 {
+		t.Errorf("Expected 3 entries checked, got %d", r.Checked)
+	}
+}
+
+func TestCheckDivergences(t *testing.T) {
+	users := ordered_map.NewRedBlackTree[int, user]()
+	byName := ordered_map.NewRedBlackTree[string, int]()
+	users.Set(1, user{Name: "alice"})
+	users.Set(2, user{Name: "bob"})
+	users.Set(3, user{Name: "carol"})
+
+	byName.Set("alice", 1)
+	byName.Set("bob", 3)   // mismatch: points at carol's id
+	byName.Set("dave", 4)  // stale: no such user
+	byName.Set("carol", 3) // consistent
+
+	r := Check[int, user](users, byNameIndex(byName))
+
+	kinds := map[Kind]int{}
+	for _, d := range r.Divergences {
+		kinds[d.Kind]++
+	}
+	// bob -> 3 is a mismatch for user 2 and stale for user 3
+	if kinds[Mismatch] != 1 || kinds[Stale] != 2 || kinds[Missing] != 0 {
+		t.Errorf("Unexpected divergences: %v", r.Divergences)
+	}
+
+	byName.Delete("alice")
+	r = Check[int, user](users, byNameIndex(byName))
+	if err := r.Err(); err == nil || !strings.Contains(err.Error(), "missing entry for key 1") {
+		t.Errorf("Expected missing entry for key 1, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Package work_stealing provides a Chase–Lev work-stealing deque.
+// The owner goroutine pushes and pops tasks at the bottom of its deque while
+// idle goroutines steal from the top, which keeps contention low for
+// fork/join style parallel algorithms.
+package work_stealing
+
+import (
+	"sync/atomic"
+)
+
+// minCapacity is the initial size of the circular buffer. Must be a power of two.
+const minCapacity = 32
+
+// ring is a fixed-size circular buffer indexed by ever-increasing positions.
+// Slots hold pointers so that concurrent readers never observe torn values.
+type ring[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+// newRing creates a circular buffer with the given power-of-two capacity.
+func newRing[T any](capacity int64) *ring[T] {
+	return &ring[T]{
+		slots: make([]atomic.Pointer[T], capacity),
+		mask:  capacity - 1,
+	}
+}
+
+func (r *ring[T]) capacity() int64 {
+	return r.mask + 1
+}
+
+func (r *ring[T]) get(i int64) *T {
+	return r.slots[i&r.mask].Load()
+}
+
+func (r *ring[T]) put(i int64, v *T) {
+	r.slots[i&r.mask].Store(v)
+}
+
+// grow returns a buffer twice as large holding the live range [top, bottom).
+func (r *ring[T]) grow(top, bottom int64) *ring[T] {
+	bigger := newRing[T](r.capacity() * 2)
+	for i := top; i < bottom; i++ {
+		bigger.put(i, r.get(i))
+	}
+	return bigger
+}
+
+// Deque is a lock-free Chase–Lev work-stealing deque.
+//
+// PushBottom and PopBottom may only be called by a single owner goroutine.
+// Steal may be called concurrently by any number of goroutines.
+// The zero value is not usable; create deques with NewDeque.
+type Deque[T any] struct {
+	top    atomic.Int64 // next index to steal from
+	bottom atomic.Int64 // next index to push to
+	buf    atomic.Pointer[ring[T]]
+}
+
+// NewDeque creates a new empty Deque.
+func NewDeque[T any]() *Deque[T] {
+	d := &Deque[T]{}
+	d.buf.Store(newRing[T](minCapacity))
+	return d
+}
+
+// PushBottom adds a task at the bottom of the deque. Owner only.
+func (d *Deque[T]) PushBottom(v T) {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	r := d.buf.Load()
+
+	// Grow the buffer when full; thieves holding the old buffer still read valid slots
+	if b-t >= r.capacity() {
+		r = r.grow(t, b)
+		d.buf.Store(r)
+	}
+
+	r.put(b, &v)
+	d.bottom.Store(b + 1)
+}
+
+// PopBottom removes and returns the most recently pushed task. Owner only.
+// Returns the zero value and false if the deque is empty or the last task
+// was taken by a concurrent thief.
+func (d *Deque[T]) PopBottom() (T, bool) {
+	var zero T
+
+	// Reserve the bottom slot before looking at top, so a thief racing for
+	// the same element is detected by the CAS below
+	b := d.bottom.Load() - 1
+	r := d.buf.Load()
+	d.bottom.Store(b)
+	t := d.top.Load()
+
+	if t > b {
+		// Deque was empty, restore bottom
+		d.bottom.Store(b + 1)
+		return zero, false
+	}
+
+	v := r.get(b)
+	if t == b {
+		// Last element: race against thieves by advancing top
+		won := d.top.CompareAndSwap(t, t+1)
+		d.bottom.Store(b + 1)
+		if !won {
+			return zero, false
+		}
+	}
+	return *v, true
+}
+
+// Steal removes and returns the oldest task. Safe for concurrent use.
+// Returns the zero value and false if the deque is empty.
+func (d *Deque[T]) Steal() (T, bool) {
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+		if t >= b {
+			var zero T
+			return zero, false
+		}
+
+		// Read the slot before claiming it; if the CAS fails, another
+		// goroutine took it and the value read here is discarded
+		v := d.buf.Load().get(t)
+		if d.top.CompareAndSwap(t, t+1) {
+			return *v, true
+		}
+	}
+}
+
+// Len returns the number of tasks in the deque.
+// Under concurrent access the result is only a snapshot.
+func (d *Deque[T]) Len() int {
+	n := d.bottom.Load() - d.top.Load()
+	if n < 0 {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,129 @@
+package work_stealing
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDequeBasic(t *testing.T) {
+	d := NewDeque[int]()
+
+	// Test empty deque
+	if d.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", d.Len())
+	}
+	if _, ok := d.PopBottom(); ok {
+		t.Error("Expected false when popping from empty deque")
+	}
+	if _, ok := d.Steal(); ok {
+		t.Error("Expected false when stealing from empty deque")
+	}
+
+	for i := 1; i <= 3; i++ {
+		d.PushBottom(i)
+	}
+	if d.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", d.Len())
+	}
+
+	// Owner pops LIFO, thieves steal FIFO
+	if v, ok := d.PopBottom(); !ok || v != 3 {
+		t.Errorf("Expected (3, true) from PopBottom, got (%d, %t)", v, ok)
+	}
+	if v, ok := d.Steal(); !ok || v != 1 {
+		t.Errorf("Expected (1, true) from Steal, got (%d, %t)", v, ok)
+	}
+	if v, ok := d.PopBottom(); !ok || v != 2 {
+		t.Errorf("Expected (2, true) from PopBottom, got (%d, %t)", v, ok)
+	}
+	if _, ok := d.PopBottom(); ok {
+		t.Error("Expected false when popping from drained deque")
+	}
+}
+
+func TestDequeGrow(t *testing.T) {
+	d := NewDeque[int]()
+	const n = minCapacity*4 + 3
+
+	for i := 0; i < n; i++ {
+		d.PushBottom(i)
+	}
+	if d.Len() != n {
+		t.Errorf("Expected length %d, got %d", n, d.Len())
+	}
+	for i := 0; i < n/2; i++ {
+		if v, ok := d.Steal(); !ok || v != i {
+			t.Fatalf("Expected (%d, true) from Steal, got (%d, %t)", i, v, ok)
+		}
+	}
+	for i := n - 1; i >= n/2; i-- {
+		if v, ok := d.PopBottom(); !ok || v != i {
+			t.Fatalf("Expected (%d, true) from PopBottom, got (%d, %t)", i, v, ok)
+		}
+	}
+}
+
+// TestDequeConcurrentSteal checks that every pushed task is taken exactly once
+// while the owner pushes and pops and several thieves steal concurrently.
+// Run with -race to also check memory ordering.
+func TestDequeConcurrentSteal(t *testing.T) {
+	const (
+		tasks   = 20000
+		thieves = 4
+	)
+
+	d := NewDeque[int]()
+	seen := make([]atomic.Int32, tasks)
+	var taken atomic.Int64
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if v, ok := d.Steal(); ok {
+					seen[v].Add(1)
+					taken.Add(1)
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < tasks; i++ {
+		d.PushBottom(i)
+		if i%3 == 0 {
+			if v, ok := d.PopBottom(); ok {
+				seen[v].Add(1)
+				taken.Add(1)
+			}
+		}
+	}
+	for {
+		v, ok := d.PopBottom()
+		if !ok {
+			break
+		}
+		seen[v].Add(1)
+		taken.Add(1)
+	}
+	close(done)
+	wg.Wait()
+
+	if got := taken.Load(); got != tasks {
+		t.Fatalf("Expected %d tasks taken, got %d", tasks, got)
+	}
+	for i := range seen {
+		if c := seen[i].Load(); c != 1 {
+			t.Fatalf("Task %d taken %d times", i, c)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package work_stealing_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/feepwang/br/container/work_stealing"
+)
+
+// parallelFor runs body(i) for every i in [0, n) on the given number of workers.
+// Ranges are split recursively: a worker keeps halving its range, pushing the
+// upper half onto its own deque, and idle workers steal the oldest (largest)
+// pending ranges from others.
+func parallelFor(n, workers int, body func(i int)) {
+	type span struct{ lo, hi int }
+	const grain = 16
+
+	deques := make([]*work_stealing.Deque[span], workers)
+	for i := range deques {
+		deques[i] = work_stealing.NewDeque[span]()
+	}
+	deques[0].PushBottom(span{0, n})
+
+	var remaining atomic.Int64
+	remaining.Store(int64(n))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(self int) {
+			defer wg.Done()
+			own := deques[self]
+			for remaining.Load() > 0 {
+				s, ok := own.PopBottom()
+				for victim := 0; !ok && victim < workers; victim++ {
+					if victim != self {
+						s, ok = deques[victim].Steal()
+					}
+				}
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+				for s.hi-s.lo > grain {
+					mid := s.lo + (s.hi-s.lo)/2
+					own.PushBottom(span{mid, s.hi})
+					s.hi = mid
+				}
+				for i := s.lo; i < s.hi; i++ {
+					body(i)
+				}
+				remaining.Add(-int64(s.hi - s.lo))
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func ExampleDeque_parallelFor() {
+	squares := make([]int, 1000)
+	parallelFor(len(squares), 4, func(i int) {
+		squares[i] = i * i
+	})
+
+	sum := 0
+	for _, v := range squares {
+		sum += v
+	}
+	fmt.Println(sum)
+	// Output: 332833500
+}
+
+func ExampleDeque() {
+	d := work_stealing.NewDeque[string]()
+	d.PushBottom("a")
+	d.PushBottom("b")
+	d.PushBottom("c")
+
+	stolen, _ := d.Steal()
+	popped, _ := d.PopBottom()
+	fmt.Println(stolen, popped, d.Len())
+	// Output: a c 1
+}